@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+)
+
+// factories are the factories of the processors whose configuration holds OTTL statements.
+var factories = map[component.Type]processor.Factory{
+	transformprocessor.NewFactory().Type(): transformprocessor.NewFactory(),
+	filterprocessor.NewFactory().Type():    filterprocessor.NewFactory(),
+}
+
+// processorConfig is the configuration of a processor read from the collector configuration.
+type processorConfig struct {
+	id      component.ID
+	factory processor.Factory
+	cfg     component.Config
+	// err is the error returned while unmarshaling or validating cfg.
+	err error
+}
+
+// run validates the processors of the collector configuration at configPath and writes a report to out.
+// If inputPath is set, every valid processor is then run against the OTLP JSON data it holds.
+// If processorID is set, only the processor with that ID is checked.
+func run(configPath string, inputPath string, processorID string, out io.Writer) error {
+	configs, err := loadProcessorConfigs(configPath)
+	if err != nil {
+		return err
+	}
+	if processorID != "" {
+		configs = filterConfigs(configs, processorID)
+		if len(configs) == 0 {
+			return fmt.Errorf("processor %q not found in %s", processorID, configPath)
+		}
+	}
+	if len(configs) == 0 {
+		return fmt.Errorf("no transform or filter processors found in %s", configPath)
+	}
+
+	invalid := 0
+	for _, pc := range configs {
+		if pc.err != nil {
+			invalid++
+			fmt.Fprintf(out, "%v: %v\n", pc.id, pc.err)
+			continue
+		}
+		fmt.Fprintf(out, "%v: OK\n", pc.id)
+	}
+
+	if inputPath != "" {
+		input, err := os.ReadFile(inputPath)
+		if err != nil {
+			return err
+		}
+		before, err := indent(input)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", inputPath, err)
+		}
+		fmt.Fprintf(out, "\n--- before\n%s\n", before)
+		for _, pc := range configs {
+			if pc.err != nil {
+				continue
+			}
+			after, err := apply(context.Background(), pc, input)
+			if err != nil {
+				return fmt.Errorf("failed to run %v: %w", pc.id, err)
+			}
+			fmt.Fprintf(out, "\n--- after %v\n%s\n", pc.id, after)
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d processors are invalid", invalid, len(configs))
+	}
+	return nil
+}
+
+// loadProcessorConfigs reads the configuration of the transform and filter processors of the collector
+// configuration at path, sorted by ID. Other components are ignored.
+func loadProcessorConfigs(path string) ([]processorConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err = yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	processors, err := confmap.NewFromStringMap(raw).Sub("processors")
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []processorConfig
+	for key := range processors.ToStringMap() {
+		var id component.ID
+		if err = id.UnmarshalText([]byte(key)); err != nil {
+			return nil, fmt.Errorf("invalid processor ID %q: %w", key, err)
+		}
+		factory, ok := factories[id.Type()]
+		if !ok {
+			continue
+		}
+		pc := processorConfig{
+			id:      id,
+			factory: factory,
+			cfg:     factory.CreateDefaultConfig(),
+		}
+		sub, err := processors.Sub(key)
+		if err != nil {
+			return nil, err
+		}
+		if pc.err = component.UnmarshalConfig(sub, pc.cfg); pc.err == nil {
+			pc.err = component.ValidateConfig(pc.cfg)
+		}
+		configs = append(configs, pc)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].id.String() < configs[j].id.String()
+	})
+	return configs, nil
+}
+
+func filterConfigs(configs []processorConfig, processorID string) []processorConfig {
+	for _, pc := range configs {
+		if pc.id.String() == processorID {
+			return []processorConfig{pc}
+		}
+	}
+	return nil
+}
+
+// apply runs the processor against input, which holds OTLP JSON traces, metrics or logs,
+// and returns the resulting data as indented OTLP JSON.
+func apply(ctx context.Context, pc processorConfig, input []byte) ([]byte, error) {
+	var signals map[string]json.RawMessage
+	if err := json.Unmarshal(input, &signals); err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	var err error
+	switch {
+	case signals["resourceSpans"] != nil:
+		result, err = applyTraces(ctx, pc, input)
+	case signals["resourceMetrics"] != nil:
+		result, err = applyMetrics(ctx, pc, input)
+	case signals["resourceLogs"] != nil:
+		result, err = applyLogs(ctx, pc, input)
+	default:
+		return nil, errors.New("input must hold OTLP JSON resourceSpans, resourceMetrics or resourceLogs")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return indent(result)
+}
+
+func applyTraces(ctx context.Context, pc processorConfig, input []byte) ([]byte, error) {
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(input)
+	if err != nil {
+		return nil, err
+	}
+	sink := new(consumertest.TracesSink)
+	p, err := pc.factory.CreateTracesProcessor(ctx, processortest.NewNopCreateSettings(), pc.cfg, sink)
+	if err != nil {
+		return nil, err
+	}
+	if err = consume(ctx, p, func() error { return p.ConsumeTraces(ctx, td) }); err != nil {
+		return nil, err
+	}
+	result := ptrace.NewTraces()
+	if all := sink.AllTraces(); len(all) > 0 {
+		result = all[0]
+	}
+	return (&ptrace.JSONMarshaler{}).MarshalTraces(result)
+}
+
+func applyMetrics(ctx context.Context, pc processorConfig, input []byte) ([]byte, error) {
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(input)
+	if err != nil {
+		return nil, err
+	}
+	sink := new(consumertest.MetricsSink)
+	p, err := pc.factory.CreateMetricsProcessor(ctx, processortest.NewNopCreateSettings(), pc.cfg, sink)
+	if err != nil {
+		return nil, err
+	}
+	if err = consume(ctx, p, func() error { return p.ConsumeMetrics(ctx, md) }); err != nil {
+		return nil, err
+	}
+	result := pmetric.NewMetrics()
+	if all := sink.AllMetrics(); len(all) > 0 {
+		result = all[0]
+	}
+	return (&pmetric.JSONMarshaler{}).MarshalMetrics(result)
+}
+
+func applyLogs(ctx context.Context, pc processorConfig, input []byte) ([]byte, error) {
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(input)
+	if err != nil {
+		return nil, err
+	}
+	sink := new(consumertest.LogsSink)
+	p, err := pc.factory.CreateLogsProcessor(ctx, processortest.NewNopCreateSettings(), pc.cfg, sink)
+	if err != nil {
+		return nil, err
+	}
+	if err = consume(ctx, p, func() error { return p.ConsumeLogs(ctx, ld) }); err != nil {
+		return nil, err
+	}
+	result := plog.NewLogs()
+	if all := sink.AllLogs(); len(all) > 0 {
+		result = all[0]
+	}
+	return (&plog.JSONMarshaler{}).MarshalLogs(result)
+}
+
+// consume starts p, calls consumeFunc and shuts p down.
+func consume(ctx context.Context, p component.Component, consumeFunc func() error) error {
+	if err := p.Start(ctx, componenttest.NewNopHost()); err != nil {
+		return err
+	}
+	return multierr.Combine(consumeFunc(), p.Shutdown(ctx))
+}
+
+func indent(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(data), "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_run(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		processorID string
+		expected    []string
+		unexpected  []string
+		expectedErr string
+	}{
+		{
+			name: "validate all processors",
+			expected: []string{
+				"filter/health: OK\n",
+				"transform/redact: OK\n",
+				"transform/severity: OK\n",
+				`transform/typo: unable to parse OTTL statement "set(atributes[\"http.url\"], \"redacted\")": column 5 near "atributes"`,
+				`did you mean "attributes"?`,
+			},
+			unexpected:  []string{"batch", "--- before"},
+			expectedErr: "1 of 4 processors are invalid",
+		},
+		{
+			name:        "single processor",
+			processorID: "transform/redact",
+			expected:    []string{"transform/redact: OK\n"},
+			unexpected:  []string{"filter/health", "transform/typo"},
+		},
+		{
+			name:        "run against traces",
+			input:       "traces.json",
+			processorID: "transform/redact",
+			expected: []string{
+				"--- before\n",
+				`"stringValue": "https://example.com/users?token=secret"`,
+				"--- after transform/redact\n",
+				`"stringValue": "redacted"`,
+				`"name": "GET /users-checked"`,
+			},
+		},
+		{
+			name:        "run against logs",
+			input:       "logs.json",
+			processorID: "transform/severity",
+			expected: []string{
+				"--- after transform/severity\n",
+				`"severityText": "INFO"`,
+			},
+		},
+		{
+			name:        "unknown processor",
+			processorID: "transform/unknown",
+			expectedErr: `processor "transform/unknown" not found in ` + filepath.Join("testdata", "config.yaml"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inputPath string
+			if tt.input != "" {
+				inputPath = filepath.Join("testdata", tt.input)
+			}
+			var out bytes.Buffer
+			err := run(filepath.Join("testdata", "config.yaml"), inputPath, tt.processorID, &out)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tt.expected {
+				assert.Contains(t, out.String(), expected)
+			}
+			for _, unexpected := range tt.unexpected {
+				assert.NotContains(t, out.String(), unexpected)
+			}
+		})
+	}
+}
+
+func Test_run_filterDropsSpans(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, run(filepath.Join("testdata", "config.yaml"), filepath.Join("testdata", "traces.json"), "filter/health", &out))
+	after := out.String()[strings.Index(out.String(), "--- after filter/health"):]
+	assert.Contains(t, after, "GET /users")
+	assert.NotContains(t, after, "GET /health")
+}
+
+func Test_loadProcessorConfigs_missingFile(t *testing.T) {
+	_, err := loadProcessorConfigs(filepath.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func Test_apply_invalidInput(t *testing.T) {
+	configs, err := loadProcessorConfigs(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	_, err = apply(context.Background(), configs[0], []byte(`{"unknown": []}`))
+	assert.EqualError(t, err, "input must hold OTLP JSON resourceSpans, resourceMetrics or resourceLogs")
+}
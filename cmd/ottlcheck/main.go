@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program ottlcheck validates the OTTL statements of the transform and filter processors of a
+// collector configuration and, optionally, shows how they change a sample of OTLP JSON data.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to the collector configuration to validate")
+	inputPath := flag.String("input", "", "optional path to an OTLP JSON file to run the processors against")
+	processorID := flag.String("processor", "", "optional ID of the only processor to check, e.g. transform/redact")
+	flag.Parse()
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "the -config flag is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*configPath, *inputPath, *processorID, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
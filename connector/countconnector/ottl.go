@@ -91,19 +91,11 @@ func statementsToExpr[K any](statements []*ottl.Statement[K]) expr.BoolExpr[K] {
 }
 
 func ottlFunctions[K any]() map[string]interface{} {
-	return map[string]interface{}{
-		"TraceID":     ottlfuncs.TraceID[K],
-		"SpanID":      ottlfuncs.SpanID[K],
-		"IsMatch":     ottlfuncs.IsMatch[K],
-		"Concat":      ottlfuncs.Concat[K],
-		"Split":       ottlfuncs.Split[K],
-		"Int":         ottlfuncs.Int[K],
-		"Substring":   ottlfuncs.Substring[K],
-		"ConvertCase": ottlfuncs.ConvertCase[K],
-		"noop": func() (ottl.ExprFunc[K], error) {
-			return func(context.Context, K) (interface{}, error) {
-				return true, nil
-			}, nil
-		},
+	functions := ottlfuncs.StandardConverters[K]()
+	functions["noop"] = func() (ottl.ExprFunc[K], error) {
+		return func(context.Context, K) (interface{}, error) {
+			return true, nil
+		}, nil
 	}
+	return functions
 }
@@ -57,6 +57,14 @@ type Config struct {
 	// This setting is required when traces pipelines used.
 	TracesIndex string `mapstructure:"traces_index"`
 
+	// This setting is required when metrics pipelines used.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
+	// MetricsGrouping configures which data points of metrics are indexed in the same document.
+	// With `data_point` every data point is indexed in its own document. With `resource_timestamp`
+	// the data points of a resource having the same timestamp and attributes are indexed in one document.
+	MetricsGrouping string `mapstructure:"metrics_grouping"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -173,6 +181,12 @@ const (
 	MappingECS
 )
 
+// Values of MetricsGrouping.
+const (
+	metricsGroupingDataPoint         = "data_point"
+	metricsGroupingResourceTimestamp = "resource_timestamp"
+)
+
 var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
@@ -225,5 +239,11 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
 
+	switch cfg.MetricsGrouping {
+	case metricsGroupingDataPoint, metricsGroupingResourceTimestamp:
+	default:
+		return fmt.Errorf("unknown metrics grouping %v", cfg.MetricsGrouping)
+	}
+
 	return nil
 }
@@ -36,12 +36,14 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
 	assert.Equal(t, cfg, &Config{
-		Endpoints:   []string{"http://localhost:9200"},
-		CloudID:     "TRNMxjXlNJEt",
-		Index:       "my_log_index",
-		LogsIndex:   "logs-generic-default",
-		TracesIndex: "traces-generic-default",
-		Pipeline:    "mypipeline",
+		Endpoints:       []string{"http://localhost:9200"},
+		CloudID:         "TRNMxjXlNJEt",
+		Index:           "my_log_index",
+		LogsIndex:       "logs-generic-default",
+		TracesIndex:     "traces-generic-default",
+		MetricsIndex:    "metrics-generic-default",
+		MetricsGrouping: "data_point",
+		Pipeline:        "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -93,12 +95,14 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "trace"),
 			expected: &Config{
-				Endpoints:   []string{"https://elastic.example.com:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "logs-generic-default",
-				TracesIndex: "trace_index",
-				Pipeline:    "mypipeline",
+				Endpoints:       []string{"https://elastic.example.com:9200"},
+				CloudID:         "TRNMxjXlNJEt",
+				Index:           "",
+				LogsIndex:       "logs-generic-default",
+				TracesIndex:     "trace_index",
+				MetricsIndex:    "metrics-generic-default",
+				MetricsGrouping: "data_point",
+				Pipeline:        "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -132,12 +136,14 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "log"),
 			expected: &Config{
-				Endpoints:   []string{"http://localhost:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "my_log_index",
-				TracesIndex: "traces-generic-default",
-				Pipeline:    "mypipeline",
+				Endpoints:       []string{"http://localhost:9200"},
+				CloudID:         "TRNMxjXlNJEt",
+				Index:           "",
+				LogsIndex:       "my_log_index",
+				TracesIndex:     "traces-generic-default",
+				MetricsIndex:    "metrics-generic-default",
+				MetricsGrouping: "data_point",
+				Pipeline:        "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -168,6 +174,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "metric"),
+			expected: &Config{
+				Endpoints:       []string{"http://localhost:9200"},
+				Index:           "",
+				LogsIndex:       "logs-generic-default",
+				TracesIndex:     "traces-generic-default",
+				MetricsIndex:    "my_metric_index",
+				MetricsGrouping: "resource_timestamp",
+				HTTPClientSettings: HTTPClientSettings{
+					Timeout: 90 * time.Second,
+				},
+				Retry: RetrySettings{
+					Enabled:         true,
+					MaxRequests:     3,
+					InitialInterval: 100 * time.Millisecond,
+					MaxInterval:     1 * time.Minute,
+				},
+				Mapping: MappingsSettings{
+					Mode:  "ecs",
+					Dedup: true,
+					Dedot: true,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -26,9 +26,10 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr            = "elasticsearch"
-	defaultLogsIndex   = "logs-generic-default"
-	defaultTracesIndex = "traces-generic-default"
+	typeStr             = "elasticsearch"
+	defaultLogsIndex    = "logs-generic-default"
+	defaultTracesIndex  = "traces-generic-default"
+	defaultMetricsIndex = "metrics-generic-default"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
 )
@@ -40,6 +41,7 @@ func NewFactory() exporter.Factory {
 		createDefaultConfig,
 		exporter.WithLogs(createLogsExporter, stability),
 		exporter.WithTraces(createTracesExporter, stability),
+		exporter.WithMetrics(createMetricsExporter, stability),
 	)
 }
 
@@ -48,9 +50,11 @@ func createDefaultConfig() component.Config {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index:       "",
-		LogsIndex:   defaultLogsIndex,
-		TracesIndex: defaultTracesIndex,
+		Index:           "",
+		LogsIndex:       defaultLogsIndex,
+		TracesIndex:     defaultTracesIndex,
+		MetricsIndex:    defaultMetricsIndex,
+		MetricsGrouping: metricsGroupingDataPoint,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -102,3 +106,18 @@ func createTracesExporter(ctx context.Context,
 	return exporterhelper.NewTracesExporter(ctx, set, cfg, exporter.pushTraceData,
 		exporterhelper.WithShutdown(exporter.Shutdown))
 }
+
+// createMetricsExporter creates a new exporter for metrics.
+//
+// The data points of metrics are indexed as documents into Elasticsearch.
+func createMetricsExporter(ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config) (exporter.Metrics, error) {
+
+	exporter, err := newMetricsExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+	return exporterhelper.NewMetricsExporter(ctx, set, cfg, exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown))
+}
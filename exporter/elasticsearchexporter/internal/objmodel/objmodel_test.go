@@ -433,6 +433,15 @@ func TestValue_Serialize(t *testing.T) {
 			value: Value{kind: KindObject, doc: Document{}},
 			want:  "null",
 		},
+		"object from document": {
+			value: func() Value {
+				doc := Document{}
+				doc.Add("values", ArrValue(DoubleValue(0.5), DoubleValue(1.5)))
+				doc.Add("counts", ArrValue(IntValue(1), IntValue(2)))
+				return ObjectValue(doc)
+			}(),
+			want: `{"values":[0.5,1.5],"counts":[1,2]}`,
+		},
 	}
 
 	for name, test := range tests {
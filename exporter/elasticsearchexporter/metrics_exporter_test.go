@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	tests := map[string]struct {
+		config      *Config
+		expectedErr string
+	}{
+		"no endpoint": {
+			config:      withDefaultConfig(),
+			expectedErr: errConfigNoEndpoint.Error(),
+		},
+		"create from default with endpoints": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+			}),
+		},
+		"create with resource_timestamp grouping": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.MetricsGrouping = "resource_timestamp"
+			}),
+		},
+		"fail with unknown grouping": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.MetricsGrouping = "metric"
+			}),
+			expectedErr: "unknown metrics grouping metric",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(defaultElasticsearchEnvName, "")
+
+			exporter, err := newMetricsExporter(zap.NewNop(), test.config)
+			if test.expectedErr != "" {
+				require.Nil(t, exporter)
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, exporter)
+			require.NoError(t, exporter.Shutdown(context.TODO()))
+		})
+	}
+}
+
+func TestExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/14759")
+	}
+	tests := map[string]struct {
+		grouping      string
+		expectedItems int
+	}{
+		"data_point":         {grouping: "data_point", expectedItems: 4},
+		"resource_timestamp": {grouping: "resource_timestamp", expectedItems: 2},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := newBulkRecorder()
+			server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+				rec.Record(docs)
+				return itemsAllOK(docs)
+			})
+
+			exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+				cfg.MetricsGrouping = test.grouping
+			})
+			require.NoError(t, exporter.pushMetricsData(context.TODO(), newTestMetrics()))
+
+			rec.WaitItems(test.expectedItems)
+			for _, item := range rec.Items() {
+				assert.Contains(t, string(item.Action), `"metrics-generic-default"`)
+				assert.Contains(t, string(item.Document), `"Resource.service.name":"app"`)
+			}
+		})
+	}
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestMetricsExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
+
+func withTestMetricsExporterConfig(fns ...func(*Config)) func(string) *Config {
+	return func(url string) *Config {
+		var configMods []func(*Config)
+		configMods = append(configMods, func(cfg *Config) {
+			cfg.Endpoints = []string{url}
+			cfg.NumWorkers = 1
+			cfg.Flush.Interval = 10 * time.Millisecond
+		})
+		configMods = append(configMods, fns...)
+		return withDefaultConfig(configMods...)
+	}
+}
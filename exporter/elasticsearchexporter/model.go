@@ -17,9 +17,13 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"bytes"
 	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
@@ -29,6 +33,7 @@ import (
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
 	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeMetrics(pcommon.Resource, pmetric.ScopeMetricsSlice, bool) ([][]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -46,6 +51,7 @@ const (
 	traceIDField   = "traceID"
 	spanIDField    = "spanID"
 	attributeField = "attribute"
+	metricsField   = "Metrics"
 )
 
 func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
@@ -96,6 +102,226 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+// encodeMetrics encodes the data points of the metrics of a resource. The value of a data point is set
+// in the field named after its metric under Metrics, typed for the Elasticsearch fields meant for time
+// series: a number for gauges and sums, a histogram for (exponential) histograms and an aggregate metric
+// double for summaries. The attributes of the data point and its resource are the dimensions of the document.
+//
+// When grouped, the data points with the same timestamp and attributes are encoded in the same document.
+// Otherwise every data point is encoded in its own document, which has the name of the metric as dimension.
+func (m *encodeModel) encodeMetrics(resource pcommon.Resource, scopeMetrics pmetric.ScopeMetricsSlice, grouped bool) ([][]byte, error) {
+	documents := &metricDocuments{resource: resource, grouped: grouped, groups: map[string]*objmodel.Document{}}
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		metrics := scopeMetrics.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			documents.addMetric(metrics.At(j))
+		}
+	}
+
+	encoded := make([][]byte, 0, len(documents.documents))
+	for _, document := range documents.documents {
+		if m.dedup {
+			document.Dedup()
+		} else if m.dedot {
+			document.Sort()
+		}
+
+		var buf bytes.Buffer
+		if err := document.Serialize(&buf, m.dedot); err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, buf.Bytes())
+	}
+	return encoded, nil
+}
+
+// metricDocuments collects the documents of the data points of a resource, in order.
+type metricDocuments struct {
+	resource  pcommon.Resource
+	grouped   bool
+	groups    map[string]*objmodel.Document
+	documents []*objmodel.Document
+}
+
+type dataPoint interface {
+	Timestamp() pcommon.Timestamp
+	Attributes() pcommon.Map
+	Flags() pmetric.DataPointFlags
+}
+
+type dataPointSlice[T dataPoint] interface {
+	Len() int
+	At(int) T
+}
+
+func (d *metricDocuments) addMetric(metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		addDataPoints[pmetric.NumberDataPoint](d, metric.Name(), metric.Gauge().DataPoints(), numberValue)
+	case pmetric.MetricTypeSum:
+		addDataPoints[pmetric.NumberDataPoint](d, metric.Name(), metric.Sum().DataPoints(), numberValue)
+	case pmetric.MetricTypeHistogram:
+		addDataPoints[pmetric.HistogramDataPoint](d, metric.Name(), metric.Histogram().DataPoints(), histogramValue)
+	case pmetric.MetricTypeExponentialHistogram:
+		addDataPoints[pmetric.ExponentialHistogramDataPoint](d, metric.Name(), metric.ExponentialHistogram().DataPoints(), exponentialHistogramValue)
+	case pmetric.MetricTypeSummary:
+		addDataPoints[pmetric.SummaryDataPoint](d, metric.Name(), metric.Summary().DataPoints(), summaryValue)
+	}
+}
+
+// addDataPoints adds the values of the data points of a metric to their documents,
+// skipping the data points without a recorded value.
+func addDataPoints[T dataPoint](d *metricDocuments, name string, dataPoints dataPointSlice[T], value func(T) (objmodel.Value, bool)) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		if v, ok := value(dp); ok {
+			d.document(name, dp.Timestamp(), dp.Attributes()).Add(metricsField+"."+name, v)
+		}
+	}
+}
+
+// document returns the document of a data point, creating it unless the data point is grouped with a previous one.
+func (d *metricDocuments) document(name string, timestamp pcommon.Timestamp, attributes pcommon.Map) *objmodel.Document {
+	var key string
+	if d.grouped {
+		key = groupKey(timestamp, attributes)
+		if document, ok := d.groups[key]; ok {
+			return document
+		}
+	}
+
+	document := &objmodel.Document{}
+	document.AddTimestamp("@timestamp", timestamp)
+	if !d.grouped {
+		document.AddString("Name", name)
+	}
+	document.AddAttributes("Attributes", attributes)
+	document.AddAttributes("Resource", d.resource.Attributes())
+
+	if d.grouped {
+		d.groups[key] = document
+	}
+	d.documents = append(d.documents, document)
+	return document
+}
+
+// groupKey identifies the data points having the same timestamp and attributes,
+// regardless of the order of the attributes.
+func groupKey(timestamp pcommon.Timestamp, attributes pcommon.Map) string {
+	document := objmodel.DocumentFromAttributes(attributes)
+	document.Dedup()
+
+	var buf strings.Builder
+	buf.WriteString(strconv.FormatUint(uint64(timestamp), 10))
+	_ = document.Serialize(&buf, false)
+	return buf.String()
+}
+
+func numberValue(dp pmetric.NumberDataPoint) (objmodel.Value, bool) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return objmodel.IntValue(dp.IntValue()), true
+	case pmetric.NumberDataPointValueTypeDouble:
+		return objmodel.DoubleValue(dp.DoubleValue()), true
+	default:
+		return objmodel.Value{}, false
+	}
+}
+
+// histogramValue represents the buckets of a histogram by their midpoint. The first and last buckets,
+// which are unbounded, are represented by their bound, or half of it for a positive first bound.
+// A histogram without bounds has a single bucket, represented by the mean of the data point.
+func histogramValue(dp pmetric.HistogramDataPoint) (objmodel.Value, bool) {
+	bounds := dp.ExplicitBounds()
+	bucketCounts := dp.BucketCounts()
+
+	var values, counts []objmodel.Value
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := bucketCounts.At(i)
+		if count == 0 {
+			continue
+		}
+
+		var value float64
+		switch {
+		case bounds.Len() == 0:
+			if dp.HasSum() && dp.Count() > 0 {
+				value = dp.Sum() / float64(dp.Count())
+			}
+		case i == 0:
+			value = bounds.At(0)
+			if value > 0 {
+				value /= 2
+			}
+		case i >= bounds.Len():
+			value = bounds.At(bounds.Len() - 1)
+		default:
+			value = (bounds.At(i-1) + bounds.At(i)) / 2
+		}
+		values = append(values, objmodel.DoubleValue(value))
+		counts = append(counts, objmodel.IntValue(int64(count)))
+	}
+	return newHistogramValue(values, counts), true
+}
+
+// exponentialHistogramValue represents the buckets of an exponential histogram by their midpoint,
+// in increasing order: the negative buckets, the zero bucket and the positive buckets.
+func exponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) (objmodel.Value, bool) {
+	base := math.Pow(2, math.Pow(2, -float64(dp.Scale())))
+
+	var values, counts []objmodel.Value
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if count := negative.BucketCounts().At(i); count != 0 {
+			values = append(values, objmodel.DoubleValue(-exponentialBucketMidpoint(base, negative.Offset(), i)))
+			counts = append(counts, objmodel.IntValue(int64(count)))
+		}
+	}
+	if count := dp.ZeroCount(); count != 0 {
+		values = append(values, objmodel.DoubleValue(0))
+		counts = append(counts, objmodel.IntValue(int64(count)))
+	}
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if count := positive.BucketCounts().At(i); count != 0 {
+			values = append(values, objmodel.DoubleValue(exponentialBucketMidpoint(base, positive.Offset(), i)))
+			counts = append(counts, objmodel.IntValue(int64(count)))
+		}
+	}
+	return newHistogramValue(values, counts), true
+}
+
+// exponentialBucketMidpoint returns the midpoint of the bucket of an exponential histogram
+// at index in its buckets, which covers the absolute values in (base^(offset+index), base^(offset+index+1)].
+func exponentialBucketMidpoint(base float64, offset int32, index int) float64 {
+	lower := math.Pow(base, float64(int(offset)+index))
+	return (lower + lower*base) / 2
+}
+
+// newHistogramValue returns the value of an Elasticsearch histogram field.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/histogram.html
+func newHistogramValue(values, counts []objmodel.Value) objmodel.Value {
+	var document objmodel.Document
+	document.Add("values", objmodel.ArrValue(values...))
+	document.Add("counts", objmodel.ArrValue(counts...))
+	return objmodel.ObjectValue(document)
+}
+
+// summaryValue returns the value of an Elasticsearch aggregate metric double field, with the sum and count of a summary.
+// Its quantiles are not kept.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/aggregate-metric-double.html
+func summaryValue(dp pmetric.SummaryDataPoint) (objmodel.Value, bool) {
+	var document objmodel.Document
+	document.Add("sum", objmodel.DoubleValue(dp.Sum()))
+	document.Add("value_count", objmodel.IntValue(int64(dp.Count())))
+	return objmodel.ObjectValue(document), true
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
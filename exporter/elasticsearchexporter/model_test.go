@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+var testMetricsTimestamp = pcommon.NewTimestampFromTime(time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC))
+
+func newTestMetrics() pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "app")
+	sm := rm.ScopeMetrics().AppendEmpty()
+
+	cpu := sm.Metrics().AppendEmpty()
+	cpu.SetName("cpu")
+	dp := cpu.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(testMetricsTimestamp)
+	dp.Attributes().PutStr("state", "user")
+	dp.SetIntValue(42)
+
+	requests := sm.Metrics().AppendEmpty()
+	requests.SetName("requests")
+	dp = requests.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(testMetricsTimestamp)
+	dp.Attributes().PutStr("state", "user")
+	dp.SetDoubleValue(1.5)
+
+	skipped := sm.Metrics().AppendEmpty()
+	skipped.SetName("skipped")
+	dp = skipped.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(testMetricsTimestamp)
+	dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+	latency := sm.Metrics().AppendEmpty()
+	latency.SetName("latency")
+	hdp := latency.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(testMetricsTimestamp)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 5, 10.5})
+	hdp.BucketCounts().FromRaw([]uint64{2, 0, 3, 1})
+
+	rpc := sm.Metrics().AppendEmpty()
+	rpc.SetName("rpc")
+	sdp := rpc.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(testMetricsTimestamp)
+	sdp.SetSum(12.5)
+	sdp.SetCount(5)
+
+	return metrics
+}
+
+func TestEncodeMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		grouped  bool
+		expected []string
+	}{
+		{
+			name: "data_point",
+			expected: []string{
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Attributes.state":"user","Metrics.cpu":42,"Name":"cpu","Resource.service.name":"app"}`,
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Attributes.state":"user","Metrics.requests":1.5,"Name":"requests","Resource.service.name":"app"}`,
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Metrics.latency":{"counts":[2,3,1],"values":[0.5,7.75,10.5]},"Name":"latency","Resource.service.name":"app"}`,
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Metrics.rpc":{"sum":12.5,"value_count":5},"Name":"rpc","Resource.service.name":"app"}`,
+			},
+		},
+		{
+			name:    "resource_timestamp",
+			grouped: true,
+			expected: []string{
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Attributes.state":"user","Metrics.cpu":42,"Metrics.requests":1.5,"Resource.service.name":"app"}`,
+				`{"@timestamp":"2023-04-01T12:00:00.000000000Z","Metrics.latency":{"counts":[2,3,1],"values":[0.5,7.75,10.5]},"Metrics.rpc":{"sum":12.5,"value_count":5},"Resource.service.name":"app"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := newTestMetrics().ResourceMetrics().At(0)
+			model := &encodeModel{dedup: true, dedot: false}
+			documents, err := model.encodeMetrics(rm.Resource(), rm.ScopeMetrics(), tt.grouped)
+			require.NoError(t, err)
+
+			actual := make([]string, len(documents))
+			for i, document := range documents {
+				actual[i] = string(document)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestGroupKey(t *testing.T) {
+	a := pcommon.NewMap()
+	a.PutStr("state", "user")
+	a.PutInt("cpu", 0)
+	b := pcommon.NewMap()
+	b.PutInt("cpu", 0)
+	b.PutStr("state", "user")
+	c := pcommon.NewMap()
+	c.PutInt("cpu", 1)
+	c.PutStr("state", "user")
+
+	assert.Equal(t, groupKey(testMetricsTimestamp, a), groupKey(testMetricsTimestamp, b))
+	assert.NotEqual(t, groupKey(testMetricsTimestamp, a), groupKey(testMetricsTimestamp, c))
+	assert.NotEqual(t, groupKey(testMetricsTimestamp, a), groupKey(testMetricsTimestamp+1, a))
+}
+
+func TestHistogramValue(t *testing.T) {
+	tests := []struct {
+		name           string
+		bounds         []float64
+		counts         []uint64
+		sum            float64
+		expectedValues []float64
+		expectedCounts []int64
+	}{
+		{
+			name:           "positive_bounds",
+			bounds:         []float64{1, 5, 10.5},
+			counts:         []uint64{2, 0, 3, 1},
+			expectedValues: []float64{0.5, 7.75, 10.5},
+			expectedCounts: []int64{2, 3, 1},
+		},
+		{
+			name:           "negative_first_bound",
+			bounds:         []float64{-2, 2},
+			counts:         []uint64{1, 2, 3},
+			expectedValues: []float64{-2, 0, 2},
+			expectedCounts: []int64{1, 2, 3},
+		},
+		{
+			name:           "no_bounds",
+			counts:         []uint64{4},
+			sum:            10,
+			expectedValues: []float64{2.5},
+			expectedCounts: []int64{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := pmetric.NewHistogramDataPoint()
+			dp.ExplicitBounds().FromRaw(tt.bounds)
+			dp.BucketCounts().FromRaw(tt.counts)
+			dp.SetCount(4)
+			if tt.sum != 0 {
+				dp.SetSum(tt.sum)
+			}
+
+			value, ok := histogramValue(dp)
+			assert.True(t, ok)
+			assert.Equal(t, newTestHistogramValue(tt.expectedValues, tt.expectedCounts), value)
+		})
+	}
+}
+
+func TestExponentialHistogramValue(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetZeroCount(5)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp.Negative().SetOffset(1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{4, 0})
+
+	value, ok := exponentialHistogramValue(dp)
+	assert.True(t, ok)
+	assert.Equal(t, newTestHistogramValue([]float64{-3, 0, 1.5, 3}, []int64{4, 5, 1, 2}), value)
+}
+
+func newTestHistogramValue(values []float64, counts []int64) objmodel.Value {
+	var valueValues, countValues []objmodel.Value
+	for _, value := range values {
+		valueValues = append(valueValues, objmodel.DoubleValue(value))
+	}
+	for _, count := range counts {
+		countValues = append(countValues, objmodel.IntValue(count))
+	}
+	return newHistogramValue(valueValues, countValues)
+}
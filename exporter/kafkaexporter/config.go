@@ -36,6 +36,18 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// TopicFromAttribute is the name of the resource attribute to take the topic of the messages from.
+	// The messages of resources without the attribute are sent to Topic.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
+	// PartitionTracesByID sends the spans of each trace in separate messages keyed by the trace ID,
+	// so that all the spans of a trace are written to the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// PartitionByResourceAttributes is the list of resource attributes whose values key the messages,
+	// so that the data of resources with the same values are written to the same partition.
+	PartitionByResourceAttributes []string `mapstructure:"partition_by_resource_attributes"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
@@ -111,6 +123,12 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	for _, attribute := range cfg.PartitionByResourceAttributes {
+		if attribute == "" {
+			return fmt.Errorf("partition_by_resource_attributes must not contain empty attribute names")
+		}
+	}
+
 	return nil
 }
 
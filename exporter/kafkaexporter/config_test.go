@@ -58,9 +58,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:                         "spans",
+				TopicFromAttribute:            "tenant",
+				PartitionTracesByID:           true,
+				PartitionByResourceAttributes: []string{"service.name"},
+				Encoding:                      "otlp_proto",
+				Brokers:                       []string{"foo:123", "bar:456"},
 				Authentication: Authentication{
 					PlainText: &PlainTextConfig{
 						Username: "jdoe",
@@ -110,6 +113,18 @@ func TestValidate_err_compression(t *testing.T) {
 	assert.Equal(t, err.Error(), "producer.compression should be one of 'none', 'gzip', 'snappy', 'lz4', or 'zstd'. configured value idk")
 }
 
+func TestValidate_err_partition_by_resource_attributes(t *testing.T) {
+	config := &Config{
+		Producer: Producer{
+			Compression: "none",
+		},
+		PartitionByResourceAttributes: []string{"service.name", ""},
+	}
+
+	err := config.Validate()
+	assert.EqualError(t, err, "partition_by_resource_attributes must not contain empty attribute names")
+}
+
 func Test_saramaProducerCompressionCodec(t *testing.T) {
 	tests := map[string]struct {
 		compression         string
@@ -32,10 +32,10 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	partitioner partitioner
+	marshaler   TracesMarshaler
+	logger      *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -48,11 +48,16 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range e.partitioner.splitTraces(td) {
+		batchMessages, err := e.marshaler.Marshal(batch.traces, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -71,18 +76,23 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	partitioner partitioner
+	marshaler   MetricsMarshaler
+	logger      *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range e.partitioner.splitMetrics(md) {
+		batchMessages, err := e.marshaler.Marshal(batch.metrics, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -101,18 +111,23 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	partitioner partitioner
+	marshaler   LogsMarshaler
+	logger      *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range e.partitioner.splitLogs(ld) {
+		batchMessages, err := e.marshaler.Marshal(batch.logs, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -179,10 +194,10 @@ func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers m
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		partitioner: newPartitioner(config),
+		marshaler:   marshaler,
+		logger:      set.Logger,
 	}, nil
 
 }
@@ -198,10 +213,10 @@ func newTracesExporter(config Config, set exporter.CreateSettings, marshalers ma
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		partitioner: newPartitioner(config),
+		marshaler:   marshaler,
+		logger:      set.Logger,
 	}, nil
 }
 
@@ -216,10 +231,10 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		partitioner: newPartitioner(config),
+		marshaler:   marshaler,
+		logger:      set.Logger,
 	}, nil
 
 }
@@ -182,6 +182,43 @@ func TestTracesPusher_marshal_error(t *testing.T) {
 	assert.Contains(t, err.Error(), expErr.Error())
 }
 
+func TestTracesPusher_partition(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := &recordingProducer{SyncProducer: mocks.NewSyncProducer(t, c)}
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+
+	p := kafkaTracesProducer{
+		producer: producer,
+		partitioner: newPartitioner(Config{
+			Topic:               "spans",
+			TopicFromAttribute:  "tenant",
+			PartitionTracesByID: true,
+		}),
+		marshaler: newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("tenant", "otlp-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetTraceID(traceIDA)
+	spans.AppendEmpty().SetTraceID(traceIDB)
+	spans.AppendEmpty().SetTraceID(traceIDA)
+
+	err := p.tracesPusher(context.Background(), td)
+	require.NoError(t, err)
+	require.Len(t, producer.messages, 2)
+
+	for i, traceID := range []string{traceIDA.String(), traceIDB.String()} {
+		assert.Equal(t, "otlp-a", producer.messages[i].Topic)
+		assert.Equal(t, sarama.ByteEncoder(traceID), producer.messages[i].Key)
+	}
+}
+
 func TestMetricsDataPusher(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
@@ -276,6 +313,17 @@ func TestLogsDataPusher_marshal_error(t *testing.T) {
 	assert.Contains(t, err.Error(), expErr.Error())
 }
 
+// recordingProducer records the messages sent to a mock producer
+type recordingProducer struct {
+	*mocks.SyncProducer
+	messages []*sarama.ProducerMessage
+}
+
+func (p *recordingProducer) SendMessages(messages []*sarama.ProducerMessage) error {
+	p.messages = append(p.messages, messages...)
+	return p.SyncProducer.SendMessages(messages)
+}
+
 type tracesErrorMarshaler struct {
 	err error
 }
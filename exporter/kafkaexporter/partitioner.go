@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+)
+
+// keyAttributesSeparator separates the values of the resource attributes in a message key
+const keyAttributesSeparator = ","
+
+// partitioner splits the data into the batches sent as separate messages,
+// and chooses the topic and the key of their messages.
+type partitioner struct {
+	topic               string
+	topicFromAttribute  string
+	keyAttributes       []string
+	partitionTracesByID bool
+}
+
+func newPartitioner(config Config) partitioner {
+	return partitioner{
+		topic:               config.Topic,
+		topicFromAttribute:  config.TopicFromAttribute,
+		keyAttributes:       config.PartitionByResourceAttributes,
+		partitionTracesByID: config.PartitionTracesByID,
+	}
+}
+
+// tracesBatch is the traces sent in the messages of a topic and key
+type tracesBatch struct {
+	topic  string
+	key    []byte
+	traces ptrace.Traces
+}
+
+// metricsBatch is the metrics sent in the messages of a topic and key
+type metricsBatch struct {
+	topic   string
+	key     []byte
+	metrics pmetric.Metrics
+}
+
+// logsBatch is the logs sent in the messages of a topic and key
+type logsBatch struct {
+	topic string
+	key   []byte
+	logs  plog.Logs
+}
+
+// splitsResources reports whether the resources may be sent in different messages
+func (p partitioner) splitsResources() bool {
+	return p.topicFromAttribute != "" || len(p.keyAttributes) > 0
+}
+
+// route returns the topic and the key of the messages of a resource.
+// The key is nil when the messages are not partitioned by resource attributes.
+func (p partitioner) route(resource pcommon.Resource) (string, []byte) {
+	topic := p.topic
+	if p.topicFromAttribute != "" {
+		if value, ok := resource.Attributes().Get(p.topicFromAttribute); ok && value.AsString() != "" {
+			topic = value.AsString()
+		}
+	}
+
+	if len(p.keyAttributes) == 0 {
+		return topic, nil
+	}
+	values := make([]string, 0, len(p.keyAttributes))
+	found := false
+	for _, name := range p.keyAttributes {
+		value, ok := resource.Attributes().Get(name)
+		if !ok {
+			values = append(values, "")
+			continue
+		}
+		found = true
+		values = append(values, value.AsString())
+	}
+	if !found {
+		return topic, nil
+	}
+	return topic, []byte(strings.Join(values, keyAttributesSeparator))
+}
+
+// batchID identifies the batch of a topic and key
+func batchID(topic string, key []byte) string {
+	return topic + "\x00" + string(key)
+}
+
+// splitTraces splits the traces by the topic and the key of their messages.
+// When partitioning by trace ID, each batch holds the spans of a single trace.
+func (p partitioner) splitTraces(td ptrace.Traces) []tracesBatch {
+	if !p.splitsResources() && !p.partitionTracesByID {
+		return []tracesBatch{{topic: p.topic, traces: td}}
+	}
+
+	var batches []tracesBatch
+	indexes := make(map[string]int)
+	batchOf := func(topic string, key []byte) ptrace.ResourceSpansSlice {
+		id := batchID(topic, key)
+		i, ok := indexes[id]
+		if !ok {
+			i = len(batches)
+			indexes[id] = i
+			batches = append(batches, tracesBatch{topic: topic, key: key, traces: ptrace.NewTraces()})
+		}
+		return batches[i].traces.ResourceSpans()
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		topic, key := p.route(rs.Resource())
+		if !p.partitionTracesByID {
+			rs.CopyTo(batchOf(topic, key).AppendEmpty())
+			continue
+		}
+
+		// The trace ID takes precedence over the resource attributes as the key
+		resourceTraces := ptrace.NewTraces()
+		rs.CopyTo(resourceTraces.ResourceSpans().AppendEmpty())
+		for _, trace := range batchpersignal.SplitTraces(resourceTraces) {
+			traceID := trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+			trace.ResourceSpans().MoveAndAppendTo(batchOf(topic, []byte(traceID.String())))
+		}
+	}
+	return batches
+}
+
+// splitMetrics splits the metrics by the topic and the key of their messages
+func (p partitioner) splitMetrics(md pmetric.Metrics) []metricsBatch {
+	if !p.splitsResources() {
+		return []metricsBatch{{topic: p.topic, metrics: md}}
+	}
+
+	var batches []metricsBatch
+	indexes := make(map[string]int)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		topic, key := p.route(rm.Resource())
+
+		id := batchID(topic, key)
+		j, ok := indexes[id]
+		if !ok {
+			j = len(batches)
+			indexes[id] = j
+			batches = append(batches, metricsBatch{topic: topic, key: key, metrics: pmetric.NewMetrics()})
+		}
+		rm.CopyTo(batches[j].metrics.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitLogs splits the logs by the topic and the key of their messages
+func (p partitioner) splitLogs(ld plog.Logs) []logsBatch {
+	if !p.splitsResources() {
+		return []logsBatch{{topic: p.topic, logs: ld}}
+	}
+
+	var batches []logsBatch
+	indexes := make(map[string]int)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		topic, key := p.route(rl.Resource())
+
+		id := batchID(topic, key)
+		j, ok := indexes[id]
+		if !ok {
+			j = len(batches)
+			indexes[id] = j
+			batches = append(batches, logsBatch{topic: topic, key: key, logs: plog.NewLogs()})
+		}
+		rl.CopyTo(batches[j].logs.ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
+
+// setMessagesKey sets the key of the messages of a batch, keeping the key set by the marshaler when there is none.
+func setMessagesKey(messages []*sarama.ProducerMessage, key []byte) {
+	if key == nil {
+		return
+	}
+	for _, message := range messages {
+		message.Key = sarama.ByteEncoder(key)
+	}
+}
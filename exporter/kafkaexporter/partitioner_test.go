@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	traceIDA = pcommon.TraceID([16]byte{1})
+	traceIDB = pcommon.TraceID([16]byte{2})
+)
+
+func TestPartitionerRoute(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		attributes    map[string]interface{}
+		expectedTopic string
+		expectedKey   []byte
+	}{
+		{
+			name:          "static_topic",
+			config:        Config{Topic: "spans"},
+			attributes:    map[string]interface{}{"tenant": "a"},
+			expectedTopic: "spans",
+		},
+		{
+			name:          "topic_from_attribute",
+			config:        Config{Topic: "spans", TopicFromAttribute: "tenant"},
+			attributes:    map[string]interface{}{"tenant": "otlp-a"},
+			expectedTopic: "otlp-a",
+		},
+		{
+			name:          "topic_from_missing_attribute",
+			config:        Config{Topic: "spans", TopicFromAttribute: "tenant"},
+			attributes:    map[string]interface{}{"service.name": "a"},
+			expectedTopic: "spans",
+		},
+		{
+			name:          "topic_from_empty_attribute",
+			config:        Config{Topic: "spans", TopicFromAttribute: "tenant"},
+			attributes:    map[string]interface{}{"tenant": ""},
+			expectedTopic: "spans",
+		},
+		{
+			name:          "key_from_attribute",
+			config:        Config{Topic: "spans", PartitionByResourceAttributes: []string{"service.name"}},
+			attributes:    map[string]interface{}{"service.name": "checkout"},
+			expectedTopic: "spans",
+			expectedKey:   []byte("checkout"),
+		},
+		{
+			name:          "key_from_attributes",
+			config:        Config{Topic: "spans", PartitionByResourceAttributes: []string{"service.name", "host.name", "port"}},
+			attributes:    map[string]interface{}{"service.name": "checkout", "port": 8080},
+			expectedTopic: "spans",
+			expectedKey:   []byte("checkout,,8080"),
+		},
+		{
+			name:          "key_from_missing_attributes",
+			config:        Config{Topic: "spans", PartitionByResourceAttributes: []string{"service.name"}},
+			attributes:    map[string]interface{}{"host.name": "a"},
+			expectedTopic: "spans",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := pcommon.NewResource()
+			require.NoError(t, resource.Attributes().FromRaw(tt.attributes))
+
+			topic, key := newPartitioner(tt.config).route(resource)
+			assert.Equal(t, tt.expectedTopic, topic)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+}
+
+// newTestTraces returns traces with a resource for each of the tenants, and a span for each of the trace IDs in each resource
+func newTestTraces(tenants []string, traceIDs ...pcommon.TraceID) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, tenant := range tenants {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, traceID := range traceIDs {
+			spans.AppendEmpty().SetTraceID(traceID)
+		}
+	}
+	return td
+}
+
+func TestPartitionerSplitTraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		traces   ptrace.Traces
+		expected []tracesBatch
+	}{
+		{
+			name:   "no_partitioning",
+			config: Config{Topic: "spans"},
+			traces: newTestTraces([]string{"a", "b"}, traceIDA),
+			expected: []tracesBatch{
+				{topic: "spans", traces: newTestTraces([]string{"a", "b"}, traceIDA)},
+			},
+		},
+		{
+			name:   "topic_from_attribute",
+			config: Config{Topic: "spans", TopicFromAttribute: "tenant"},
+			traces: newTestTraces([]string{"a", "b", "a"}, traceIDA),
+			expected: []tracesBatch{
+				{topic: "a", traces: newTestTraces([]string{"a", "a"}, traceIDA)},
+				{topic: "b", traces: newTestTraces([]string{"b"}, traceIDA)},
+			},
+		},
+		{
+			name:   "partition_by_resource_attributes",
+			config: Config{Topic: "spans", PartitionByResourceAttributes: []string{"tenant"}},
+			traces: newTestTraces([]string{"a", "b"}, traceIDA),
+			expected: []tracesBatch{
+				{topic: "spans", key: []byte("a"), traces: newTestTraces([]string{"a"}, traceIDA)},
+				{topic: "spans", key: []byte("b"), traces: newTestTraces([]string{"b"}, traceIDA)},
+			},
+		},
+		{
+			name:   "partition_traces_by_id",
+			config: Config{Topic: "spans", PartitionTracesByID: true, PartitionByResourceAttributes: []string{"tenant"}},
+			traces: newTestTraces([]string{"a", "b"}, traceIDA, traceIDB),
+			expected: []tracesBatch{
+				{topic: "spans", key: []byte(traceIDA.String()), traces: newTestTraces([]string{"a", "b"}, traceIDA)},
+				{topic: "spans", key: []byte(traceIDB.String()), traces: newTestTraces([]string{"a", "b"}, traceIDB)},
+			},
+		},
+		{
+			name:   "partition_traces_by_id_and_topic",
+			config: Config{Topic: "spans", TopicFromAttribute: "tenant", PartitionTracesByID: true},
+			traces: newTestTraces([]string{"a", "b"}, traceIDA),
+			expected: []tracesBatch{
+				{topic: "a", key: []byte(traceIDA.String()), traces: newTestTraces([]string{"a"}, traceIDA)},
+				{topic: "b", key: []byte(traceIDA.String()), traces: newTestTraces([]string{"b"}, traceIDA)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newPartitioner(tt.config).splitTraces(tt.traces))
+		})
+	}
+}
+
+func TestPartitionerSplitMetrics(t *testing.T) {
+	newMetrics := func(tenants ...string) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		for _, tenant := range tenants {
+			rm := md.ResourceMetrics().AppendEmpty()
+			rm.Resource().Attributes().PutStr("tenant", tenant)
+			rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+		}
+		return md
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		metrics  pmetric.Metrics
+		expected []metricsBatch
+	}{
+		{
+			name:    "no_partitioning",
+			config:  Config{Topic: "metrics", PartitionTracesByID: true},
+			metrics: newMetrics("a", "b"),
+			expected: []metricsBatch{
+				{topic: "metrics", metrics: newMetrics("a", "b")},
+			},
+		},
+		{
+			name:    "topic_and_key",
+			config:  Config{Topic: "metrics", TopicFromAttribute: "topic", PartitionByResourceAttributes: []string{"tenant"}},
+			metrics: newMetrics("a", "b", "a"),
+			expected: []metricsBatch{
+				{topic: "metrics", key: []byte("a"), metrics: newMetrics("a", "a")},
+				{topic: "metrics", key: []byte("b"), metrics: newMetrics("b")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newPartitioner(tt.config).splitMetrics(tt.metrics))
+		})
+	}
+}
+
+func TestPartitionerSplitLogs(t *testing.T) {
+	newLogs := func(tenants ...string) plog.Logs {
+		ld := plog.NewLogs()
+		for _, tenant := range tenants {
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("tenant", tenant)
+			rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+		}
+		return ld
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		logs     plog.Logs
+		expected []logsBatch
+	}{
+		{
+			name:   "no_partitioning",
+			config: Config{Topic: "logs"},
+			logs:   newLogs("a", "b"),
+			expected: []logsBatch{
+				{topic: "logs", logs: newLogs("a", "b")},
+			},
+		},
+		{
+			name:   "topic_from_attribute",
+			config: Config{Topic: "logs", TopicFromAttribute: "tenant"},
+			logs:   newLogs("a", "b", "a"),
+			expected: []logsBatch{
+				{topic: "a", logs: newLogs("a", "a")},
+				{topic: "b", logs: newLogs("b")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newPartitioner(tt.config).splitLogs(tt.logs))
+		})
+	}
+}
+
+func TestSetMessagesKey(t *testing.T) {
+	messages := []*sarama.ProducerMessage{
+		{Key: sarama.ByteEncoder("marshaler")},
+	}
+
+	setMessagesKey(messages, nil)
+	assert.Equal(t, sarama.ByteEncoder("marshaler"), messages[0].Key)
+
+	setMessagesKey(messages, []byte("partition"))
+	assert.Equal(t, sarama.ByteEncoder("partition"), messages[0].Key)
+}
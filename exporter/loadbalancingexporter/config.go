@@ -15,6 +15,7 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
@@ -25,6 +26,8 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricNameRouting
 )
 
 // Config defines configuration for the exporter.
@@ -32,6 +35,22 @@ type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RoutingAttributes is the list of resource attributes used to route metrics when the routing key is "resource".
+	// When empty, all the resource attributes are used.
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
+}
+
+// Validate checks that the routing key is known. The same configuration is shared by the
+// exporters of all signals, so a key supported by only some signals is accepted: the
+// exporters of the other signals fall back to their default routing.
+func (c *Config) Validate() error {
+	switch c.RoutingKey {
+	case "", "traceID", "service", "resource", "metric":
+		return nil
+	default:
+		return fmt.Errorf("unsupported routing_key: %s", c.RoutingKey)
+	}
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -43,6 +62,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -57,3 +77,10 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sSvcResolver defines the configuration for the Kubernetes service resolver
+type K8sSvcResolver struct {
+	// Service is the name of the Kubernetes service, optionally followed by its namespace: "<name>.<namespace>"
+	Service string  `mapstructure:"service"`
+	Ports   []int32 `mapstructure:"ports"`
+}
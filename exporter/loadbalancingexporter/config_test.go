@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -34,3 +35,26 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestValidateRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		valid      bool
+	}{
+		{routingKey: "", valid: true},
+		{routingKey: "traceID", valid: true},
+		{routingKey: "service", valid: true},
+		{routingKey: "resource", valid: true},
+		{routingKey: "metric", valid: true},
+		{routingKey: "span", valid: false},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := &Config{RoutingKey: tt.routingKey}
+			if tt.valid {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), "unsupported routing_key: span")
+			}
+		})
+	}
+}
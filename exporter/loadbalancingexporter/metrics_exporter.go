@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var _ exporter.Metrics = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	logger            *zap.Logger
+	loadBalancer      loadBalancer
+	routingKey        routingKey
+	routingAttributes []string
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{
+		logger:            params.Logger,
+		loadBalancer:      lb,
+		routingKey:        svcRouting,
+		routingAttributes: cfg.(*Config).RoutingAttributes,
+	}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service", "":
+	case "resource":
+		metricExporter.routingKey = resourceRouting
+	case "metric":
+		metricExporter.routingKey = metricNameRouting
+	case "traceID":
+		// metrics don't have trace IDs, the metrics exporter of the same configuration keeps the default
+		params.Logger.Warn("routing_key is not supported for metrics, routing metrics by their service name", zap.String("routing_key", cfg.(*Config).RoutingKey))
+	default:
+		return nil, fmt.Errorf("unsupported routing_key for metrics: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batches := splitMetricsByRoutingKey(md, e.routingKey, e.routingAttributes, e.logger)
+
+	var errs error
+	for rid, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, rid, batch))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, rid string, md pmetric.Metrics) error {
+	endpoint := e.loadBalancer.Endpoint([]byte(rid))
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(exporter.Metrics)
+	if !ok {
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitMetricsByRoutingKey groups the metrics by the identifier used to select their backend,
+// so that all the data points of a series are always sent to the same backend. The metrics of
+// resources without a routing identifier are dropped, without affecting the other resources.
+func splitMetricsByRoutingKey(md pmetric.Metrics, key routingKey, attributes []string, logger *zap.Logger) map[string]pmetric.Metrics {
+	batches := map[string]pmetric.Metrics{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		if key == metricNameRouting {
+			splitResourceMetricsByName(rm, batches)
+			continue
+		}
+
+		rid, err := resourceRoutingIdentifier(rm.Resource(), key, attributes)
+		if err != nil {
+			logger.Warn("dropping the metrics of a resource that can't be routed", zap.Error(err))
+			continue
+		}
+		rm.CopyTo(batchFor(batches, rid).ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitResourceMetricsByName adds each metric of the resource to the batch for its name,
+// keeping the resource and scope it belongs to.
+func splitResourceMetricsByName(rm pmetric.ResourceMetrics, batches map[string]pmetric.Metrics) {
+	resources := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		sm := rm.ScopeMetrics().At(i)
+		scopes := map[string]pmetric.ScopeMetrics{}
+		for j := 0; j < sm.Metrics().Len(); j++ {
+			m := sm.Metrics().At(j)
+			dest, ok := scopes[m.Name()]
+			if !ok {
+				destRm, found := resources[m.Name()]
+				if !found {
+					destRm = batchFor(batches, m.Name()).ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(destRm.Resource())
+					destRm.SetSchemaUrl(rm.SchemaUrl())
+					resources[m.Name()] = destRm
+				}
+				dest = destRm.ScopeMetrics().AppendEmpty()
+				sm.Scope().CopyTo(dest.Scope())
+				dest.SetSchemaUrl(sm.SchemaUrl())
+				scopes[m.Name()] = dest
+			}
+			m.CopyTo(dest.Metrics().AppendEmpty())
+		}
+	}
+}
+
+func batchFor(batches map[string]pmetric.Metrics, rid string) pmetric.Metrics {
+	batch, ok := batches[rid]
+	if !ok {
+		batch = pmetric.NewMetrics()
+		batches[rid] = batch
+	}
+	return batch
+}
+
+func resourceRoutingIdentifier(res pcommon.Resource, key routingKey, attributes []string) (string, error) {
+	if key == svcRouting {
+		svc, ok := res.Attributes().Get(conventions.AttributeServiceName)
+		if !ok {
+			return "", errors.New("unable to get service name")
+		}
+		return svc.Str(), nil
+	}
+
+	attrs := res.Attributes()
+	if len(attributes) > 0 {
+		// only the configured attributes identify the resource, the others are allowed to change
+		attrs = pcommon.NewMap()
+		for _, name := range attributes {
+			if v, ok := res.Attributes().Get(name); ok {
+				v.CopyTo(attrs.PutEmpty(name))
+			}
+		}
+	}
+	hash := pdatautil.MapHash(attrs)
+	return string(hash[:]), nil
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/zap"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		routingKey string
+		expected   routingKey
+		err        error
+	}{
+		{
+			desc:     "default",
+			expected: svcRouting,
+		},
+		{
+			desc:       "service",
+			routingKey: "service",
+			expected:   svcRouting,
+		},
+		{
+			desc:       "resource",
+			routingKey: "resource",
+			expected:   resourceRouting,
+		},
+		{
+			desc:       "metric",
+			routingKey: "metric",
+			expected:   metricNameRouting,
+		},
+		{
+			desc:       "trace ID",
+			routingKey: "traceID",
+			expected:   svcRouting,
+		},
+		{
+			desc:       "unknown",
+			routingKey: "span",
+			err:        errors.New("unsupported routing_key for metrics: span"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.RoutingKey = tt.routingKey
+
+			// test
+			p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+
+			// verify
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.expected, p.routingKey)
+			}
+		})
+	}
+}
+
+func TestNewMetricsExporterNoResolver(t *testing.T) {
+	// test
+	_, err := newMetricsExporter(exportertest.NewNopCreateSettings(), &Config{})
+
+	// verify
+	require.Equal(t, errNoResolver, err)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]pmetric.Metrics{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[endpoint] = append(received[endpoint], md)
+			return nil
+		}), nil
+	}
+	cfg := simpleConfig()
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.ConsumeMetrics(context.Background(), simpleMetricsWithServices("service-1", "service-2", "service-3", "service-4")))
+	}
+
+	// verify
+	mu.Lock()
+	defer mu.Unlock()
+	backends := map[string]string{}
+	total := 0
+	for endpoint, batches := range received {
+		for _, md := range batches {
+			total += md.ResourceMetrics().Len()
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				svc, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+				if previous, ok := backends[svc.Str()]; ok {
+					assert.Equal(t, previous, endpoint, "the metrics of %s were sent to different backends", svc.Str())
+				}
+				backends[svc.Str()] = endpoint
+			}
+		}
+	}
+	assert.Equal(t, 12, total)
+	assert.Len(t, backends, 4)
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetricsWithServices("service-1"))
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsWithoutServiceName(t *testing.T) {
+	var mu sync.Mutex
+	var received []pmetric.Metrics
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, md)
+			return nil
+		}), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	md := simpleMetricsWithServices("service-1")
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	// the resource without a service name is dropped, the other one is still exported
+	require.NoError(t, res)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 1)
+	require.Equal(t, 1, received[0].ResourceMetrics().Len())
+	svc, _ := received[0].ResourceMetrics().At(0).Resource().Attributes().Get(conventions.AttributeServiceName)
+	assert.Equal(t, "service-1", svc.Str())
+}
+
+func TestSplitMetricsByResource(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, pod := range []string{"pod-1", "pod-2", "pod-1"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("k8s.pod.name", pod)
+		rm.Resource().Attributes().PutStr("host.name", "host-"+pod)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	}
+	// the same pod, reporting a different host
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("k8s.pod.name", "pod-1")
+	rm.Resource().Attributes().PutStr("host.name", "other")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+
+	for _, tt := range []struct {
+		desc       string
+		attributes []string
+		sizes      []int
+	}{
+		{
+			desc:  "all attributes",
+			sizes: []int{1, 1, 2},
+		},
+		{
+			desc:       "selected attributes",
+			attributes: []string{"k8s.pod.name"},
+			sizes:      []int{1, 3},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			batches := splitMetricsByRoutingKey(md, resourceRouting, tt.attributes, zap.NewNop())
+
+			// verify
+			var sizes []int
+			for _, batch := range batches {
+				sizes = append(sizes, batch.ResourceMetrics().Len())
+			}
+			assert.ElementsMatch(t, tt.sizes, sizes)
+		})
+	}
+}
+
+func TestSplitMetricsByName(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope-1")
+	sm.Metrics().AppendEmpty().SetName("requests")
+	sm.Metrics().AppendEmpty().SetName("errors")
+	sm.Metrics().AppendEmpty().SetName("requests")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope-2")
+	sm.Metrics().AppendEmpty().SetName("requests")
+
+	// test
+	batches := splitMetricsByRoutingKey(md, metricNameRouting, nil, zap.NewNop())
+
+	// verify
+	require.Len(t, batches, 2)
+
+	requests := batches["requests"]
+	require.Equal(t, 1, requests.ResourceMetrics().Len())
+	svc, _ := requests.ResourceMetrics().At(0).Resource().Attributes().Get(conventions.AttributeServiceName)
+	assert.Equal(t, "service-1", svc.Str())
+	scopes := requests.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 2, scopes.Len())
+	assert.Equal(t, "scope-1", scopes.At(0).Scope().Name())
+	assert.Equal(t, 2, scopes.At(0).Metrics().Len())
+	assert.Equal(t, "scope-2", scopes.At(1).Scope().Name())
+	assert.Equal(t, 1, scopes.At(1).Metrics().Len())
+
+	assert.Equal(t, 1, batches["errors"].MetricCount())
+}
+
+func simpleMetricsWithServices(services ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, svc := range services {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, svc)
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+	return md
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) exporter.Metrics {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		consumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.consumeMetricsFn == nil {
+		return nil
+	}
+	return e.consumeMetricsFn(ctx, md)
+}
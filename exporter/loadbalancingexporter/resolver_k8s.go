@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace = "default"
+
+	// defaultK8sSyncTimeout bounds the time the resolver waits for the initial list of endpoints when starting
+	defaultK8sSyncTimeout = 30 * time.Second
+
+	// inClusterNamespacePath is where the namespace of the collector's pod is mounted when running in a cluster
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+var (
+	errNoSvc      = errors.New("no service specified to resolve the backends")
+	errInvalidSvc = errors.New("invalid service, expected <name>, <name>.<namespace> or <name>.<namespace>.svc.<cluster domain>")
+
+	k8sResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+
+	k8sResolverSuccessTrueMutators  = []tag.Mutator{k8sResolverMutator, successTrueMutator}
+	k8sResolverSuccessFalseMutators = []tag.Mutator{k8sResolverMutator, successFalseMutator}
+)
+
+// k8sResolver watches the Endpoints of a Kubernetes service, so that changes to the list
+// of backends are propagated as soon as the cluster reports them.
+type k8sResolver struct {
+	logger      *zap.Logger
+	svcName     string
+	svcNs       string
+	ports       []int32
+	newClient   func() (kubernetes.Interface, error)
+	syncTimeout time.Duration
+
+	handler        *handler
+	endpointsStore *sync.Map
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	once               sync.Once
+	stopOnce           sync.Once
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+// newK8sResolver creates a resolver for the given service. The Kubernetes client is only created
+// with newClient when the resolver starts.
+func newK8sResolver(newClient func() (kubernetes.Interface, error), logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	name, namespace, err := parseServiceName(service)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespace) == 0 {
+		namespace = currentNamespace(logger)
+	}
+
+	if len(ports) == 0 {
+		port, _ := strconv.ParseInt(defaultPort, 10, 32)
+		ports = []int32{int32(port)}
+	}
+
+	epsStore := &sync.Map{}
+	h := &handler{endpoints: epsStore, logger: logger}
+	r := &k8sResolver{
+		logger:         logger,
+		svcName:        name,
+		svcNs:          namespace,
+		ports:          ports,
+		newClient:      newClient,
+		syncTimeout:    defaultK8sSyncTimeout,
+		handler:        h,
+		endpointsStore: epsStore,
+		stopCh:         make(chan struct{}),
+	}
+	h.callback = r.resolve
+
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	var initErr error
+	r.once.Do(func() {
+		clt, err := r.newClient()
+		if err != nil {
+			initErr = fmt.Errorf("unable to create the Kubernetes client: %w", err)
+			return
+		}
+
+		r.logger.Debug("creating and starting endpoints informer")
+		epsInformer := cache.NewSharedInformer(r.newListWatcher(clt), &corev1.Endpoints{}, 0)
+		if _, err = epsInformer.AddEventHandler(r.handler); err != nil {
+			initErr = fmt.Errorf("unable to watch the endpoints of the service: %w", err)
+			return
+		}
+
+		go epsInformer.Run(r.stopCh)
+
+		// the informer keeps running until the resolver is shut down, but starting
+		// the resolver only waits for the initial list as long as the context allows
+		syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(syncCtx.Done(), epsInformer.HasSynced) {
+			_ = stats.RecordWithTags(ctx, k8sResolverSuccessFalseMutators, mNumResolutions.M(1))
+			initErr = fmt.Errorf("endpoints informer didn't sync: %w", syncCtx.Err())
+			return
+		}
+
+		// the notifications are delivered asynchronously, make sure the initial
+		// list of backends is known once the resolver is started
+		for _, obj := range epsInformer.GetStore().List() {
+			r.handler.OnAdd(obj)
+		}
+	})
+	if initErr != nil {
+		return initErr
+	}
+
+	// the service might not have any endpoints yet, in which case the handler wasn't triggered
+	if _, err := r.resolve(ctx); err != nil {
+		return err
+	}
+
+	r.logger.Debug("K8s service resolver started",
+		zap.String("service", r.svcName), zap.String("namespace", r.svcNs), zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) newListWatcher(clt kubernetes.Interface) cache.ListerWatcher {
+	epsSelector := fmt.Sprintf("metadata.name=%s", r.svcName)
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = epsSelector
+			return clt.CoreV1().Endpoints(r.svcNs).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = epsSelector
+			return clt.CoreV1().Endpoints(r.svcNs).Watch(context.Background(), options)
+		},
+	}
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	// informer notifications and the initial resolution can happen concurrently,
+	// the lock makes sure the latest view of the endpoints is the one propagated
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	var backends []string
+	r.endpointsStore.Range(func(address, _ interface{}) bool {
+		for _, port := range r.ports {
+			backends = append(backends, net.JoinHostPort(address.(string), strconv.FormatInt(int64(port), 10)))
+		}
+		return true
+	})
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+// newInClusterClient creates a Kubernetes client using the service account of the collector's pod.
+func newInClusterClient() (kubernetes.Interface, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// parseServiceName splits the service into its name and namespace. The namespace is empty when the
+// service is only made of its name. Fully qualified names, like lb.observability.svc.cluster.local,
+// are accepted, in which case the parts after the namespace are ignored.
+func parseServiceName(service string) (string, string, error) {
+	parts := strings.Split(service, ".")
+	name := parts[0]
+	if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("%w: %q is not a valid service name: %s", errInvalidSvc, name, strings.Join(errs, ", "))
+	}
+	if len(parts) == 1 {
+		return name, "", nil
+	}
+
+	namespace := parts[1]
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", "", fmt.Errorf("%w: %q is not a valid namespace: %s", errInvalidSvc, namespace, strings.Join(errs, ", "))
+	}
+	if len(parts) > 2 && parts[2] != "svc" {
+		return "", "", fmt.Errorf("%w: %q", errInvalidSvc, service)
+	}
+	return name, namespace, nil
+}
+
+// currentNamespace returns the namespace the collector is running in, or "default" if it can't be determined.
+func currentNamespace(logger *zap.Logger) string {
+	ns, err := os.ReadFile(inClusterNamespacePath)
+	if err != nil || len(strings.TrimSpace(string(ns))) == 0 {
+		logger.Warn("could not determine the namespace of the collector, using the default namespace for the service", zap.Error(err))
+		return defaultK8sNamespace
+	}
+	return strings.TrimSpace(string(ns))
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ cache.ResourceEventHandler = (*handler)(nil)
+
+// handler keeps track of the ready addresses of a service, based on the informer callbacks.
+type handler struct {
+	// endpoints is a map[string]bool with all the ready addresses at any given moment
+	endpoints *sync.Map
+	callback  func(ctx context.Context) ([]string, error)
+	logger    *zap.Logger
+}
+
+// OnAdd is called in response to the endpoints of the service being detected.
+func (h handler) OnAdd(obj interface{}) {
+	eps, ok := obj.(*corev1.Endpoints)
+	if !ok {
+		h.logger.Warn("got an unexpected Kubernetes data type when adding the endpoints of the service", zap.Any("obj", obj))
+		return
+	}
+
+	changed := false
+	for _, addr := range convertToAddresses(eps) {
+		if _, loaded := h.endpoints.LoadOrStore(addr, true); !loaded {
+			changed = true
+		}
+	}
+	if changed {
+		_, _ = h.callback(context.Background())
+	}
+}
+
+// OnUpdate is called in response to the endpoints of the service changing, for instance when pods are scaled.
+func (h handler) OnUpdate(oldObj, newObj interface{}) {
+	oldEps, ok := oldObj.(*corev1.Endpoints)
+	if !ok {
+		h.logger.Warn("got an unexpected Kubernetes data type when updating the endpoints of the service", zap.Any("obj", oldObj))
+		return
+	}
+	newEps, ok := newObj.(*corev1.Endpoints)
+	if !ok {
+		h.logger.Warn("got an unexpected Kubernetes data type when updating the endpoints of the service", zap.Any("obj", newObj))
+		return
+	}
+
+	newAddresses := map[string]bool{}
+	for _, addr := range convertToAddresses(newEps) {
+		newAddresses[addr] = true
+	}
+
+	changed := false
+	for _, addr := range convertToAddresses(oldEps) {
+		if !newAddresses[addr] {
+			h.endpoints.Delete(addr)
+			changed = true
+		}
+	}
+	for addr := range newAddresses {
+		if _, loaded := h.endpoints.LoadOrStore(addr, true); !loaded {
+			changed = true
+		}
+	}
+	if changed {
+		_, _ = h.callback(context.Background())
+	}
+}
+
+// OnDelete is called in response to the endpoints of the service being removed.
+func (h handler) OnDelete(obj interface{}) {
+	var eps *corev1.Endpoints
+	switch object := obj.(type) {
+	case *cache.DeletedFinalStateUnknown:
+		h.OnDelete(object.Obj)
+		return
+	case *corev1.Endpoints:
+		eps = object
+	default:
+		h.logger.Warn("got an unexpected Kubernetes data type when removing the endpoints of the service", zap.Any("obj", obj))
+		return
+	}
+
+	addresses := convertToAddresses(eps)
+	for _, addr := range addresses {
+		h.endpoints.Delete(addr)
+	}
+	if len(addresses) > 0 {
+		_, _ = h.callback(context.Background())
+	}
+}
+
+// convertToAddresses returns the IPs of the ready addresses of the service. Addresses of pods
+// that aren't ready yet, or are terminating, aren't able to receive data and are left out.
+func convertToAddresses(eps *corev1.Endpoints) []string {
+	if eps == nil {
+		return nil
+	}
+	var addresses []string
+	for _, subset := range eps.Subsets {
+		for _, addr := range subset.Addresses {
+			addresses = append(addresses, addr.IP)
+		}
+	}
+	return addresses
+}
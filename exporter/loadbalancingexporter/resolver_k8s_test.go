@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestK8sResolve(t *testing.T) {
+	// prepare
+	service, ns := "lb", "default"
+	endpoints := newEndpoints(service, ns, "192.168.10.100")
+	cl := fake.NewSimpleClientset(endpoints)
+	res, err := newK8sResolver(fakeClient(cl), zap.NewNop(), service+"."+ns, []int32{8080, 9090})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(backends []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = backends
+	})
+	latest := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"192.168.10.100:8080", "192.168.10.100:9090"}, latest())
+
+	// scale out
+	updated := newEndpoints(service, ns, "192.168.10.100", "192.168.10.101")
+	_, err = cl.CoreV1().Endpoints(ns).Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"192.168.10.100:8080", "192.168.10.100:9090",
+			"192.168.10.101:8080", "192.168.10.101:9090",
+		}, latest())
+	}, time.Second, 10*time.Millisecond)
+
+	// scale in
+	updated = newEndpoints(service, ns, "192.168.10.101")
+	_, err = cl.CoreV1().Endpoints(ns).Update(context.Background(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"192.168.10.101:8080", "192.168.10.101:9090"}, latest())
+	}, time.Second, 10*time.Millisecond)
+
+	// the service is gone
+	require.NoError(t, cl.CoreV1().Endpoints(ns).Delete(context.Background(), service, metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		return len(latest()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolveDefaultPort(t *testing.T) {
+	// prepare
+	cl := fake.NewSimpleClientset(newEndpoints("lb", "observability", "10.0.0.1", "fd00::1"))
+	res, err := newK8sResolver(fakeClient(cl), zap.NewNop(), "lb.observability", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:4317", "[fd00::1]:4317"}, resolved)
+}
+
+func TestK8sResolveIgnoresNotReadyAddresses(t *testing.T) {
+	// prepare
+	endpoints := newEndpoints("lb", "default", "10.0.0.1")
+	endpoints.Subsets[0].NotReadyAddresses = []corev1.EndpointAddress{{IP: "10.0.0.2"}}
+	cl := fake.NewSimpleClientset(endpoints)
+	res, err := newK8sResolver(fakeClient(cl), zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:4317"}, resolved)
+}
+
+func TestK8sResolveServiceWithoutEndpoints(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fakeClient(fake.NewSimpleClientset()), zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+}
+
+func TestErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fakeClient(fake.NewSimpleClientset()), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolveFullyQualifiedService(t *testing.T) {
+	// prepare
+	cl := fake.NewSimpleClientset(newEndpoints("lb", "observability", "10.0.0.1"))
+	res, err := newK8sResolver(fakeClient(cl), zap.NewNop(), "lb.observability.svc.cluster.local", nil)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:4317"}, resolved)
+}
+
+func TestParseServiceName(t *testing.T) {
+	for _, tt := range []struct {
+		service   string
+		name      string
+		namespace string
+		err       bool
+	}{
+		{service: "lb", name: "lb"},
+		{service: "lb.observability", name: "lb", namespace: "observability"},
+		{service: "lb.observability.svc", name: "lb", namespace: "observability"},
+		{service: "lb.observability.svc.cluster.local", name: "lb", namespace: "observability"},
+		{service: ".observability", err: true},
+		{service: "lb.", err: true},
+		{service: "Lb.observability", err: true},
+		{service: "lb.observ_ability", err: true},
+		{service: "lb.observability.cluster.local", err: true},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			name, namespace, err := parseServiceName(tt.service)
+			if tt.err {
+				assert.ErrorIs(t, err, errInvalidSvc)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.namespace, namespace)
+		})
+	}
+}
+
+func TestK8sResolverClientFailure(t *testing.T) {
+	// prepare
+	expectedErr := errors.New("not running in a cluster")
+	res, err := newK8sResolver(func() (kubernetes.Interface, error) {
+		return nil, expectedErr
+	}, zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+
+	// test
+	err = res.start(context.Background())
+
+	// verify
+	assert.ErrorIs(t, err, expectedErr)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestK8sResolverSyncTimeout(t *testing.T) {
+	// prepare
+	cl := fake.NewSimpleClientset()
+	cl.PrependReactor("list", "endpoints", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	res, err := newK8sResolver(fakeClient(cl), zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+	res.syncTimeout = 50 * time.Millisecond
+
+	// test
+	err = res.start(context.Background())
+
+	// verify
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestK8sResolverShutdownTwice(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fakeClient(fake.NewSimpleClientset()), zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+
+	// test and verify
+	assert.NoError(t, res.shutdown(context.Background()))
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func fakeClient(cl kubernetes.Interface) func() (kubernetes.Interface, error) {
+	return func() (kubernetes.Interface, error) {
+		return cl, nil
+	}
+}
+
+func newEndpoints(name, namespace string, ips ...string) *corev1.Endpoints {
+	addresses := make([]corev1.EndpointAddress, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+	return &corev1.Endpoints{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Endpoints",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: addresses},
+		},
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
@@ -61,6 +62,9 @@ func newTracesExporter(params exporter.CreateSettings, cfg component.Config) (*t
 	case "service":
 		traceExporter.routingKey = svcRouting
 	case "traceID", "":
+	case "resource", "metric":
+		// only supported for metrics, the traces exporter of the same configuration keeps the default
+		params.Logger.Warn("routing_key is not supported for traces, routing spans by their trace ID", zap.String("routing_key", cfg.(*Config).RoutingKey))
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
 	}
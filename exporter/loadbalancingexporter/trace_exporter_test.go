@@ -58,6 +58,14 @@ func TestNewTracesExporter(t *testing.T) {
 			&Config{},
 			errNoResolver,
 		},
+		{
+			"metrics routing key",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "resource",
+			},
+			nil,
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			// test
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+	compressionLz4    = "lz4"
+
+	defaultCompression     = compressionSnappy
+	defaultRowGroupSizeMiB = 64
+	defaultPageSizeKiB     = 8
+	defaultMaxMegabytes    = 256
+	defaultRotationPeriod  = time.Hour
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+	compressionLz4:    parquet.CompressionCodec_LZ4,
+}
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	// Path is the directory the Parquet files are written to. It is created
+	// on start if it does not exist.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used for the column chunks.
+	// Options: none, snappy[default], gzip, zstd, lz4.
+	Compression string `mapstructure:"compression"`
+
+	// RowGroupSizeMiB is the approximate amount of buffered data, in mebibytes,
+	// after which a row group is flushed to the file.
+	RowGroupSizeMiB int `mapstructure:"row_group_size_mib"`
+
+	// PageSizeKiB is the target size, in kibibytes, of a data page within a column chunk.
+	PageSizeKiB int `mapstructure:"page_size_kib"`
+
+	// Rotation controls when the current file is closed and a new one started.
+	Rotation RotationConfig `mapstructure:"rotation"`
+}
+
+// RotationConfig defines when output files are rotated. A file is rotated
+// as soon as either of the limits is reached; a zero value disables that limit.
+type RotationConfig struct {
+	// MaxMegabytes is the approximate maximum size of a file in megabytes.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Period is the maximum amount of time a file is kept open.
+	Period time.Duration `mapstructure:"period"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.RowGroupSizeMiB <= 0 {
+		return errors.New("row_group_size_mib must be positive")
+	}
+	if cfg.PageSizeKiB <= 0 {
+		return errors.New("page_size_kib must be positive")
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation::max_megabytes must not be negative")
+	}
+	if cfg.Rotation.Period < 0 {
+		return errors.New("rotation::period must not be negative")
+	}
+	return nil
+}
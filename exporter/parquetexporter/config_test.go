@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(typeStr),
+			expected: &Config{
+				Path:            "/var/output",
+				Compression:     defaultCompression,
+				RowGroupSizeMiB: defaultRowGroupSizeMiB,
+				PageSizeKiB:     defaultPageSizeKiB,
+				Rotation: RotationConfig{
+					MaxMegabytes: defaultMaxMegabytes,
+					Period:       defaultRotationPeriod,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "custom"),
+			expected: &Config{
+				Path:            "/var/output",
+				Compression:     compressionZstd,
+				RowGroupSizeMiB: 16,
+				PageSizeKiB:     64,
+				Rotation: RotationConfig{
+					MaxMegabytes: 512,
+					Period:       15 * time.Minute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "no_path"),
+			errorMessage: "path must be non-empty",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "bad_compression"),
+			errorMessage: `compression "brotli" is not supported`,
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "bad_row_group_size"),
+			errorMessage: "row_group_size_mib must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// commonFields are the resource and scope level columns shared by all tables.
+type commonFields struct {
+	resourceAttributes map[string]string
+	serviceName        string
+	scopeName          string
+	scopeVersion       string
+}
+
+func newCommonFields(res pcommon.Resource, scope pcommon.InstrumentationScope) commonFields {
+	var serviceName string
+	if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+		serviceName = v.AsString()
+	}
+	return commonFields{
+		resourceAttributes: attributesToMap(res.Attributes()),
+		serviceName:        serviceName,
+		scopeName:          scope.Name(),
+		scopeVersion:       scope.Version(),
+	}
+}
+
+func tracesToRows(td ptrace.Traces) []interface{} {
+	rows := make([]interface{}, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			common := newCommonFields(rs.Resource(), ss.Scope())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				rows = append(rows, spanToRow(common, ss.Spans().At(k)))
+			}
+		}
+	}
+	return rows
+}
+
+func spanToRow(common commonFields, span ptrace.Span) *spanRow {
+	row := &spanRow{
+		ResourceAttributes: common.resourceAttributes,
+		ServiceName:        common.serviceName,
+		ScopeName:          common.scopeName,
+		ScopeVersion:       common.scopeVersion,
+		TraceID:            traceIDToHex(span.TraceID()),
+		SpanID:             spanIDToHex(span.SpanID()),
+		ParentSpanID:       spanIDToHex(span.ParentSpanID()),
+		TraceState:         span.TraceState().AsRaw(),
+		Name:               span.Name(),
+		Kind:               span.Kind().String(),
+		StartTime:          int64(span.StartTimestamp()),
+		EndTime:            int64(span.EndTimestamp()),
+		DurationNanos:      int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+		StatusCode:         span.Status().Code().String(),
+		StatusMessage:      span.Status().Message(),
+		Attributes:         attributesToMap(span.Attributes()),
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		row.EventTimes = append(row.EventTimes, int64(event.Timestamp()))
+		row.EventNames = append(row.EventNames, event.Name())
+		row.EventAttributes = append(row.EventAttributes, attributesToJSON(event.Attributes()))
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		row.LinkTraceIDs = append(row.LinkTraceIDs, traceIDToHex(link.TraceID()))
+		row.LinkSpanIDs = append(row.LinkSpanIDs, spanIDToHex(link.SpanID()))
+		row.LinkTraceStates = append(row.LinkTraceStates, link.TraceState().AsRaw())
+		row.LinkAttributes = append(row.LinkAttributes, attributesToJSON(link.Attributes()))
+	}
+	return row
+}
+
+func logsToRows(ld plog.Logs) []interface{} {
+	rows := make([]interface{}, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			common := newCommonFields(rl.Resource(), sl.Scope())
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				rows = append(rows, &logRow{
+					ResourceAttributes: common.resourceAttributes,
+					ServiceName:        common.serviceName,
+					ScopeName:          common.scopeName,
+					ScopeVersion:       common.scopeVersion,
+					Time:               int64(lr.Timestamp()),
+					ObservedTime:       int64(lr.ObservedTimestamp()),
+					TraceID:            traceIDToHex(lr.TraceID()),
+					SpanID:             spanIDToHex(lr.SpanID()),
+					Flags:              int32(lr.Flags()),
+					SeverityNumber:     int32(lr.SeverityNumber()),
+					SeverityText:       lr.SeverityText(),
+					Body:               lr.Body().AsString(),
+					Attributes:         attributesToMap(lr.Attributes()),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// metricsToRows splits the data points of md into one set of rows per table.
+func metricsToRows(md pmetric.Metrics) map[string][]interface{} {
+	rows := make(map[string][]interface{})
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			common := newCommonFields(rm.Resource(), sm.Scope())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := numberDataPointToRow(common, metric, dps.At(l))
+						rows[tableMetricsNumber] = append(rows[tableMetricsNumber], row)
+					}
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := numberDataPointToRow(common, metric, dps.At(l))
+						row.AggregationTemporality = metric.Sum().AggregationTemporality().String()
+						row.IsMonotonic = metric.Sum().IsMonotonic()
+						rows[tableMetricsNumber] = append(rows[tableMetricsNumber], row)
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := histogramDataPointToRow(common, metric, dps.At(l))
+						rows[tableMetricsHistogram] = append(rows[tableMetricsHistogram], row)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := exponentialHistogramDataPointToRow(common, metric, dps.At(l))
+						rows[tableMetricsExponentialHistogram] = append(rows[tableMetricsExponentialHistogram], row)
+					}
+				case pmetric.MetricTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := summaryDataPointToRow(common, metric, dps.At(l))
+						rows[tableMetricsSummary] = append(rows[tableMetricsSummary], row)
+					}
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func numberDataPointToRow(common commonFields, metric pmetric.Metric, dp pmetric.NumberDataPoint) *numberRow {
+	row := &numberRow{
+		ResourceAttributes: common.resourceAttributes,
+		ServiceName:        common.serviceName,
+		ScopeName:          common.scopeName,
+		ScopeVersion:       common.scopeVersion,
+		MetricName:         metric.Name(),
+		MetricDescription:  metric.Description(),
+		MetricUnit:         metric.Unit(),
+		MetricType:         metric.Type().String(),
+		Attributes:         attributesToMap(dp.Attributes()),
+		StartTime:          int64(dp.StartTimestamp()),
+		Time:               int64(dp.Timestamp()),
+		Flags:              int32(dp.Flags()),
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		v := dp.IntValue()
+		row.ValueInt = &v
+	case pmetric.NumberDataPointValueTypeDouble:
+		v := dp.DoubleValue()
+		row.ValueDouble = &v
+	}
+	return row
+}
+
+func histogramDataPointToRow(common commonFields, metric pmetric.Metric, dp pmetric.HistogramDataPoint) *histogramRow {
+	row := &histogramRow{
+		ResourceAttributes:     common.resourceAttributes,
+		ServiceName:            common.serviceName,
+		ScopeName:              common.scopeName,
+		ScopeVersion:           common.scopeVersion,
+		MetricName:             metric.Name(),
+		MetricDescription:      metric.Description(),
+		MetricUnit:             metric.Unit(),
+		AggregationTemporality: metric.Histogram().AggregationTemporality().String(),
+		Attributes:             attributesToMap(dp.Attributes()),
+		StartTime:              int64(dp.StartTimestamp()),
+		Time:                   int64(dp.Timestamp()),
+		Flags:                  int32(dp.Flags()),
+		Count:                  int64(dp.Count()),
+		BucketCounts:           uint64SliceToInt64(dp.BucketCounts()),
+		ExplicitBounds:         dp.ExplicitBounds().AsRaw(),
+	}
+	if dp.HasSum() {
+		v := dp.Sum()
+		row.Sum = &v
+	}
+	if dp.HasMin() {
+		v := dp.Min()
+		row.Min = &v
+	}
+	if dp.HasMax() {
+		v := dp.Max()
+		row.Max = &v
+	}
+	return row
+}
+
+func exponentialHistogramDataPointToRow(common commonFields, metric pmetric.Metric, dp pmetric.ExponentialHistogramDataPoint) *exponentialHistogramRow {
+	row := &exponentialHistogramRow{
+		ResourceAttributes:     common.resourceAttributes,
+		ServiceName:            common.serviceName,
+		ScopeName:              common.scopeName,
+		ScopeVersion:           common.scopeVersion,
+		MetricName:             metric.Name(),
+		MetricDescription:      metric.Description(),
+		MetricUnit:             metric.Unit(),
+		AggregationTemporality: metric.ExponentialHistogram().AggregationTemporality().String(),
+		Attributes:             attributesToMap(dp.Attributes()),
+		StartTime:              int64(dp.StartTimestamp()),
+		Time:                   int64(dp.Timestamp()),
+		Flags:                  int32(dp.Flags()),
+		Count:                  int64(dp.Count()),
+		Scale:                  dp.Scale(),
+		ZeroCount:              int64(dp.ZeroCount()),
+		PositiveOffset:         dp.Positive().Offset(),
+		PositiveBucketCounts:   uint64SliceToInt64(dp.Positive().BucketCounts()),
+		NegativeOffset:         dp.Negative().Offset(),
+		NegativeBucketCounts:   uint64SliceToInt64(dp.Negative().BucketCounts()),
+	}
+	if dp.HasSum() {
+		v := dp.Sum()
+		row.Sum = &v
+	}
+	if dp.HasMin() {
+		v := dp.Min()
+		row.Min = &v
+	}
+	if dp.HasMax() {
+		v := dp.Max()
+		row.Max = &v
+	}
+	return row
+}
+
+func summaryDataPointToRow(common commonFields, metric pmetric.Metric, dp pmetric.SummaryDataPoint) *summaryRow {
+	row := &summaryRow{
+		ResourceAttributes: common.resourceAttributes,
+		ServiceName:        common.serviceName,
+		ScopeName:          common.scopeName,
+		ScopeVersion:       common.scopeVersion,
+		MetricName:         metric.Name(),
+		MetricDescription:  metric.Description(),
+		MetricUnit:         metric.Unit(),
+		Attributes:         attributesToMap(dp.Attributes()),
+		StartTime:          int64(dp.StartTimestamp()),
+		Time:               int64(dp.Timestamp()),
+		Flags:              int32(dp.Flags()),
+		Count:              int64(dp.Count()),
+		Sum:                dp.Sum(),
+	}
+	for i := 0; i < dp.QuantileValues().Len(); i++ {
+		qv := dp.QuantileValues().At(i)
+		row.Quantiles = append(row.Quantiles, qv.Quantile())
+		row.QuantileValues = append(row.QuantileValues, qv.Value())
+	}
+	return row
+}
+
+func attributesToMap(attributes pcommon.Map) map[string]string {
+	m := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+// attributesToJSON encodes attributes nested in list columns, which cannot hold maps.
+func attributesToJSON(attributes pcommon.Map) string {
+	// Marshaling a map[string]string cannot fail.
+	b, _ := json.Marshal(attributesToMap(attributes))
+	return string(b)
+}
+
+func uint64SliceToInt64(s pcommon.UInt64Slice) []int64 {
+	res := make([]int64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		res[i] = int64(s.At(i))
+	}
+	return res
+}
+
+func traceIDToHex(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanIDToHex(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
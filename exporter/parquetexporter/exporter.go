@@ -16,33 +16,108 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 )
 
+// rotationCheckInterval is how often idle files are checked against the rotation period.
+const rotationCheckInterval = time.Second
+
 type parquetExporter struct {
-	path string
+	cfg     *Config
+	logger  *zap.Logger
+	writers map[string]*tableWriter
+
+	done chan struct{}
+	wg   sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+// newParquetExporter creates an exporter writing one file sequence per table.
+func newParquetExporter(cfg *Config, logger *zap.Logger, schemas map[string]interface{}) *parquetExporter {
+	writers := make(map[string]*tableWriter, len(schemas))
+	for table, schema := range schemas {
+		writers[table] = newTableWriter(cfg, logger, table, schema)
+	}
+	return &parquetExporter{
+		cfg:     cfg,
+		logger:  logger,
+		writers: writers,
+		done:    make(chan struct{}),
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
+	if err := os.MkdirAll(e.cfg.Path, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", e.cfg.Path, err)
+	}
+	for _, w := range e.writers {
+		if err := w.removeStale(); err != nil {
+			return fmt.Errorf("failed to remove incomplete files: %w", err)
+		}
+	}
+	if e.cfg.Rotation.Period > 0 {
+		e.wg.Add(1)
+		go e.rotateOnPeriod()
+	}
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+// rotateOnPeriod closes files that reached the rotation period even when no
+// new data arrives, so that they become readable without waiting for the next batch.
+func (e *parquetExporter) rotateOnPeriod() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(rotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, w := range e.writers {
+				if err := w.rotate(); err != nil {
+					e.logger.Error("Failed to rotate Parquet file", zap.Error(err))
+				}
+			}
+		case <-e.done:
+			return
+		}
+	}
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) shutdown(context.Context) error {
+	select {
+	case <-e.done:
+	default:
+		close(e.done)
+	}
+	e.wg.Wait()
+
+	var errs error
+	for _, w := range e.writers {
+		errs = multierr.Append(errs, w.close())
+	}
+	return errs
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	var errs error
+	for table, rows := range metricsToRows(md) {
+		errs = multierr.Append(errs, e.writers[table].write(rows))
+	}
+	return errs
+}
+
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.writers[tableSpans].write(tracesToRows(td))
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.writers[tableLogs].write(logsToRows(ld))
 }
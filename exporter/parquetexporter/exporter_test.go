@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func testConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+func readRows[T any](t *testing.T, file string) []T {
+	fr, err := local.NewLocalFileReader(file)
+	require.NoError(t, err)
+	defer fr.Close()
+
+	pr, err := reader.NewParquetReader(fr, new(T), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	rows := make([]T, pr.GetNumRows())
+	require.NoError(t, pr.Read(&rows))
+	return rows
+}
+
+func finishedFiles(t *testing.T, dir string, table string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, table+"-*"+fileExtension))
+	require.NoError(t, err)
+	return files
+}
+
+func TestExportTraces(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(3000))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Attributes().PutInt("http.status_code", 500)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "NPE")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, finishedFiles(t, cfg.Path, tableSpans), "file must not be published before it is closed")
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files := finishedFiles(t, cfg.Path, tableSpans)
+	require.Len(t, files, 1)
+	rows := readRows[spanRow](t, files[0])
+	require.Len(t, rows, 1)
+	assert.Equal(t, "checkout", rows[0].ServiceName)
+	assert.Equal(t, "scope", rows[0].ScopeName)
+	assert.Equal(t, "GET /cart", rows[0].Name)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", rows[0].TraceID)
+	assert.Equal(t, "0102030405060708", rows[0].SpanID)
+	assert.Equal(t, "", rows[0].ParentSpanID)
+	assert.Equal(t, "Server", rows[0].Kind)
+	assert.Equal(t, "Error", rows[0].StatusCode)
+	assert.Equal(t, int64(2000), rows[0].DurationNanos)
+	assert.Equal(t, map[string]string{"http.status_code": "500"}, rows[0].Attributes)
+	assert.Equal(t, []string{"exception"}, rows[0].EventNames)
+	assert.Equal(t, []string{`{"exception.type":"NPE"}`}, rows[0].EventAttributes)
+}
+
+func TestExportLogs(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := createLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(42))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStr("disk almost full")
+	lr.Attributes().PutStr("host", "a")
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files := finishedFiles(t, cfg.Path, tableLogs)
+	require.Len(t, files, 1)
+	rows := readRows[logRow](t, files[0])
+	require.Len(t, rows, 1)
+	assert.Equal(t, "checkout", rows[0].ServiceName)
+	assert.Equal(t, int64(42), rows[0].Time)
+	assert.Equal(t, int32(plog.SeverityNumberWarn), rows[0].SeverityNumber)
+	assert.Equal(t, "WARN", rows[0].SeverityText)
+	assert.Equal(t, "disk almost full", rows[0].Body)
+	assert.Equal(t, map[string]string{"host": "a"}, rows[0].Attributes)
+}
+
+func TestExportMetrics(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(7)
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(21.5)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{5})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("gc")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(3)
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files := finishedFiles(t, cfg.Path, tableMetricsNumber)
+	require.Len(t, files, 1)
+	numbers := readRows[numberRow](t, files[0])
+	require.Len(t, numbers, 2)
+	assert.Equal(t, "requests", numbers[0].MetricName)
+	assert.Equal(t, "Sum", numbers[0].MetricType)
+	assert.Equal(t, "Cumulative", numbers[0].AggregationTemporality)
+	assert.True(t, numbers[0].IsMonotonic)
+	require.NotNil(t, numbers[0].ValueInt)
+	assert.Equal(t, int64(7), *numbers[0].ValueInt)
+	assert.Nil(t, numbers[0].ValueDouble)
+	assert.Equal(t, "Gauge", numbers[1].MetricType)
+	require.NotNil(t, numbers[1].ValueDouble)
+	assert.Equal(t, 21.5, *numbers[1].ValueDouble)
+
+	files = finishedFiles(t, cfg.Path, tableMetricsHistogram)
+	require.Len(t, files, 1)
+	histograms := readRows[histogramRow](t, files[0])
+	require.Len(t, histograms, 1)
+	assert.Equal(t, int64(3), histograms[0].Count)
+	require.NotNil(t, histograms[0].Sum)
+	assert.Equal(t, 12.0, *histograms[0].Sum)
+	assert.Nil(t, histograms[0].Min)
+	assert.Equal(t, []int64{1, 2}, histograms[0].BucketCounts)
+	assert.Equal(t, []float64{5}, histograms[0].ExplicitBounds)
+
+	files = finishedFiles(t, cfg.Path, tableMetricsSummary)
+	require.Len(t, files, 1)
+	summaries := readRows[summaryRow](t, files[0])
+	require.Len(t, summaries, 1)
+	assert.Equal(t, []float64{0.5}, summaries[0].Quantiles)
+	assert.Equal(t, []float64{3}, summaries[0].QuantileValues)
+
+	assert.Empty(t, finishedFiles(t, cfg.Path, tableMetricsExponentialHistogram))
+}
+
+func TestRotationPeriod(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Rotation.Period = time.Minute
+	w := newTableWriter(cfg, zap.NewNop(), tableLogs, new(logRow))
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.write([]interface{}{&logRow{Body: "first"}}))
+	assert.Empty(t, finishedFiles(t, cfg.Path, tableLogs))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, w.rotate())
+	assert.Len(t, finishedFiles(t, cfg.Path, tableLogs), 1)
+
+	require.NoError(t, w.write([]interface{}{&logRow{Body: "second"}}))
+	require.NoError(t, w.close())
+
+	files := finishedFiles(t, cfg.Path, tableLogs)
+	require.Len(t, files, 2)
+	assert.Equal(t, "first", readRows[logRow](t, files[0])[0].Body)
+	assert.Equal(t, "second", readRows[logRow](t, files[1])[0].Body)
+}
+
+func TestRotationMaxMegabytes(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Rotation.MaxMegabytes = 1
+	cfg.RowGroupSizeMiB = 1
+	w := newTableWriter(cfg, zap.NewNop(), tableLogs, new(logRow))
+
+	body := make([]byte, 64*1024)
+	for i := range body {
+		body[i] = byte('a' + i%26)
+	}
+	for i := 0; i < 40; i++ {
+		require.NoError(t, w.write([]interface{}{&logRow{Body: string(body)}}))
+	}
+	require.NoError(t, w.close())
+
+	files := finishedFiles(t, cfg.Path, tableLogs)
+	assert.Greater(t, len(files), 1)
+	total := 0
+	for _, f := range files {
+		total += len(readRows[logRow](t, f))
+	}
+	assert.Equal(t, 40, total)
+}
+
+func TestRestartWithinTheSameMillisecond(t *testing.T) {
+	cfg := testConfig(t)
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, body := range []string{"before", "after"} {
+		w := newTableWriter(cfg, zap.NewNop(), tableLogs, new(logRow))
+		w.now = func() time.Time { return now }
+		require.NoError(t, w.write([]interface{}{&logRow{Body: body}}))
+		require.NoError(t, w.close())
+	}
+
+	files := finishedFiles(t, cfg.Path, tableLogs)
+	require.Len(t, files, 2)
+	var bodies []string
+	for _, f := range files {
+		bodies = append(bodies, readRows[logRow](t, f)[0].Body)
+	}
+	assert.ElementsMatch(t, []string{"before", "after"}, bodies)
+}
+
+func TestStartRemovesIncompleteFiles(t *testing.T) {
+	cfg := testConfig(t)
+	stale := filepath.Join(cfg.Path, tableLogs+"-20230301T000000.000Z-0badf00d-1"+fileExtension+inProgressSuffix)
+	require.NoError(t, os.WriteFile(stale, []byte("PAR1"), 0600))
+	other := filepath.Join(cfg.Path, "notes.txt")
+	require.NoError(t, os.WriteFile(other, []byte("keep"), 0600))
+
+	exp, err := createLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, other)
+}
@@ -29,10 +29,6 @@ const (
 	stability = component.StabilityLevelDevelopment
 )
 
-type Config struct {
-	Path string `mapstructure:"path"`
-}
-
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
@@ -44,7 +40,15 @@ func NewFactory() exporter.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Compression:     defaultCompression,
+		RowGroupSizeMiB: defaultRowGroupSizeMiB,
+		PageSizeKiB:     defaultPageSizeKiB,
+		Rotation: RotationConfig{
+			MaxMegabytes: defaultMaxMegabytes,
+			Period:       defaultRotationPeriod,
+		},
+	}
 }
 
 func createTracesExporter(
@@ -52,7 +56,9 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, map[string]interface{}{
+		tableSpans: new(spanRow),
+	})
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -68,7 +74,12 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, map[string]interface{}{
+		tableMetricsNumber:               new(numberRow),
+		tableMetricsHistogram:            new(histogramRow),
+		tableMetricsExponentialHistogram: new(exponentialHistogramRow),
+		tableMetricsSummary:              new(summaryRow),
+	})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -84,7 +95,9 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, map[string]interface{}{
+		tableLogs: new(logRow),
+	})
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
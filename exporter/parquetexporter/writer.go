@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	fileExtension = ".parquet"
+	// inProgressSuffix is appended to the name of the file being written so that
+	// readers globbing for *.parquet never see a file without a footer.
+	inProgressSuffix = ".inprogress"
+	// parallelism is the number of goroutines used to marshal rows into pages.
+	parallelism = 4
+	// fileTimeFormat has a millisecond resolution, file names also carry the random
+	// id of the writer so that a restart within the same millisecond can't reuse a name.
+	fileTimeFormat = "20060102T150405.000Z"
+)
+
+// tableWriter writes rows of a single schema to a sequence of Parquet files,
+// rotating to a new file when the configured size or age limit is reached.
+type tableWriter struct {
+	dir    string
+	table  string
+	schema interface{}
+	cfg    *Config
+	logger *zap.Logger
+	now    func() time.Time
+	// id distinguishes the files of this writer from the ones written before a restart.
+	id string
+
+	mu       sync.Mutex
+	file     *os.File
+	pw       *writer.ParquetWriter
+	openedAt time.Time
+	seq      int
+}
+
+func newTableWriter(cfg *Config, logger *zap.Logger, table string, schema interface{}) *tableWriter {
+	return &tableWriter{
+		dir:    cfg.Path,
+		table:  table,
+		schema: schema,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+		id:     newWriterID(),
+	}
+}
+
+func newWriterID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		// the clock is the fallback source of uniqueness
+		return fmt.Sprintf("%08x", uint32(time.Now().UnixNano()))
+	}
+	return hex.EncodeToString(b)
+}
+
+// removeStale deletes the files of the table left in progress by a previous run,
+// e.g. after a crash. They lack the Parquet footer, so none of their rows can be read.
+func (w *tableWriter) removeStale() error {
+	files, err := filepath.Glob(filepath.Join(w.dir, w.table+"-*"+fileExtension+inProgressSuffix))
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, f := range files {
+		w.logger.Warn("Removing incomplete Parquet file left by a previous run", zap.String("file", f))
+		errs = multierr.Append(errs, os.Remove(f))
+	}
+	return errs
+}
+
+// write appends rows to the current file, opening a new one if needed.
+func (w *tableWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotateIfNeededLocked(); err != nil {
+		return err
+	}
+	if w.pw == nil {
+		if err := w.openLocked(); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := w.pw.Write(row); err != nil {
+			return fmt.Errorf("failed to write %s row: %w", w.table, err)
+		}
+	}
+	return w.rotateIfNeededLocked()
+}
+
+// rotate closes the current file if it exceeded one of the rotation limits.
+func (w *tableWriter) rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateIfNeededLocked()
+}
+
+func (w *tableWriter) rotateIfNeededLocked() error {
+	if w.pw == nil {
+		return nil
+	}
+	rotation := w.cfg.Rotation
+	if rotation.Period > 0 && w.now().Sub(w.openedAt) >= rotation.Period {
+		return w.closeLocked()
+	}
+	if rotation.MaxMegabytes > 0 && w.estimatedSizeLocked() >= int64(rotation.MaxMegabytes)*1024*1024 {
+		return w.closeLocked()
+	}
+	return nil
+}
+
+// estimatedSizeLocked returns the bytes already written to the file plus
+// the encoded and not yet marshaled data still buffered by the writer.
+func (w *tableWriter) estimatedSizeLocked() int64 {
+	return w.pw.Offset + w.pw.Size + w.pw.ObjsSize
+}
+
+func (w *tableWriter) openLocked() error {
+	w.openedAt = w.now()
+	w.seq++
+	name := filepath.Join(w.dir, fmt.Sprintf("%s-%s-%s-%d%s%s",
+		w.table, w.openedAt.UTC().Format(fileTimeFormat), w.id, w.seq, fileExtension, inProgressSuffix))
+
+	f, err := os.Create(filepath.Clean(name))
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", name, err)
+	}
+	pw, err := writer.NewParquetWriterFromWriter(f, w.schema, parallelism)
+	if err != nil {
+		return multierr.Append(fmt.Errorf("failed to create parquet writer: %w", err), f.Close())
+	}
+	pw.CompressionType = compressionCodecs[w.cfg.Compression]
+	pw.RowGroupSize = int64(w.cfg.RowGroupSizeMiB) * 1024 * 1024
+	pw.PageSize = int64(w.cfg.PageSizeKiB) * 1024
+
+	w.file = f
+	w.pw = pw
+	return nil
+}
+
+// close writes the footer of the current file, if any, and publishes it
+// under its final name.
+func (w *tableWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeLocked()
+}
+
+func (w *tableWriter) closeLocked() error {
+	if w.pw == nil {
+		return nil
+	}
+	pw, f := w.pw, w.file
+	w.pw, w.file = nil, nil
+
+	err := pw.WriteStop()
+	err = multierr.Append(err, f.Close())
+	if err != nil {
+		return fmt.Errorf("failed to finalize file %q: %w", f.Name(), err)
+	}
+
+	final := f.Name()[:len(f.Name())-len(inProgressSuffix)]
+	if err = os.Rename(f.Name(), final); err != nil {
+		return fmt.Errorf("failed to rename file %q: %w", f.Name(), err)
+	}
+	w.logger.Debug("Finished Parquet file", zap.String("file", final))
+	return nil
+}
@@ -22,10 +22,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
@@ -69,6 +71,26 @@ func NewBoolExprForSpanEvent(conditions []string, functions map[string]interface
 	return &s, nil
 }
 
+// NewBoolExprForSpanLink creates a BoolExpr[ottlspanlink.TransformContext] that will return true if any of the given OTTL conditions evaluate to true.
+// The passed in functions should use the ottlspanlink.TransformContext.
+// If a function named `drop` is not present in the function map it will be added automatically so that parsing works as expected
+func NewBoolExprForSpanLink(conditions []string, functions map[string]interface{}, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottlspanlink.TransformContext], error) {
+	if _, ok := functions["drop"]; !ok {
+		functions["drop"] = drop[ottlspanlink.TransformContext]
+	}
+	statmentsStr := conditionsToStatements(conditions)
+	parser, err := ottlspanlink.NewParser(functions, set)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := parser.ParseStatements(statmentsStr)
+	if err != nil {
+		return nil, err
+	}
+	s := ottlspanlink.NewStatements(statements, set, ottlspanlink.WithErrorMode(errorMode))
+	return &s, nil
+}
+
 // NewBoolExprForMetric creates a BoolExpr[ottlmetric.TransformContext] that will return true if any of the given OTTL conditions evaluate to true.
 // The passed in functions should use the ottlmetric.TransformContext.
 // If a function named `drop` is not present in the function map it will be added automatically so that parsing works as expected
@@ -109,6 +131,26 @@ func NewBoolExprForDataPoint(conditions []string, functions map[string]interface
 	return &s, nil
 }
 
+// NewBoolExprForExemplar creates a BoolExpr[ottlexemplar.TransformContext] that will return true if any of the given OTTL conditions evaluate to true.
+// The passed in functions should use the ottlexemplar.TransformContext.
+// If a function named `drop` is not present in the function map it will be added automatically so that parsing works as expected
+func NewBoolExprForExemplar(conditions []string, functions map[string]interface{}, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottlexemplar.TransformContext], error) {
+	if _, ok := functions["drop"]; !ok {
+		functions["drop"] = drop[ottlexemplar.TransformContext]
+	}
+	statmentsStr := conditionsToStatements(conditions)
+	parser, err := ottlexemplar.NewParser(functions, set)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := parser.ParseStatements(statmentsStr)
+	if err != nil {
+		return nil, err
+	}
+	s := ottlexemplar.NewStatements(statements, set, ottlexemplar.WithErrorMode(errorMode))
+	return &s, nil
+}
+
 // NewBoolExprForLog creates a BoolExpr[ottllog.TransformContext] that will return true if any of the given OTTL conditions evaluate to true.
 // The passed in functions should use the ottllog.TransformContext.
 // If a function named `drop` is not present in the function map it will be added automatically so that parsing works as expected
@@ -145,6 +187,10 @@ func StandardSpanEventFuncs() map[string]interface{} {
 	return standardFuncs[ottlspanevent.TransformContext]()
 }
 
+func StandardSpanLinkFuncs() map[string]interface{} {
+	return standardFuncs[ottlspanlink.TransformContext]()
+}
+
 func StandardMetricFuncs() map[string]interface{} {
 	return standardFuncs[ottlmetric.TransformContext]()
 }
@@ -153,22 +199,18 @@ func StandardDataPointFuncs() map[string]interface{} {
 	return standardFuncs[ottldatapoint.TransformContext]()
 }
 
+func StandardExemplarFuncs() map[string]interface{} {
+	return standardFuncs[ottlexemplar.TransformContext]()
+}
+
 func StandardLogFuncs() map[string]interface{} {
 	return standardFuncs[ottllog.TransformContext]()
 }
 
 func standardFuncs[K any]() map[string]interface{} {
-	return map[string]interface{}{
-		"TraceID":     ottlfuncs.TraceID[K],
-		"SpanID":      ottlfuncs.SpanID[K],
-		"IsMatch":     ottlfuncs.IsMatch[K],
-		"Concat":      ottlfuncs.Concat[K],
-		"Split":       ottlfuncs.Split[K],
-		"Int":         ottlfuncs.Int[K],
-		"ConvertCase": ottlfuncs.ConvertCase[K],
-		"Substring":   ottlfuncs.Substring[K],
-		"drop":        drop[K],
-	}
+	functions := ottlfuncs.StandardConverters[K]()
+	functions["drop"] = drop[K]
+	return functions
 }
 
 func drop[K any]() (ottl.ExprFunc[K], error) {
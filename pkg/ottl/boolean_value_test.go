@@ -64,6 +64,11 @@ func valueFor(x any) value {
 	case nil:
 		var n isNil = true
 		val.IsNil = &n
+	case []any:
+		val.List = &list{}
+		for _, elem := range v {
+			val.List.Values = append(val.List.Values, valueFor(elem))
+		}
 	default:
 		panic("test error!")
 	}
@@ -117,6 +122,11 @@ func Test_newComparisonEvaluator(t *testing.T) {
 		{name: "[]byte('a') < []byte('b')", l: []byte("a"), r: []byte("b"), op: "<", want: true},
 		{name: "nil == nil", op: "==", want: true},
 		{name: "nil == []byte(nil)", r: []byte(nil), op: "==", want: true},
+		{name: "name in list", l: "NAME", r: []any{"cat", "bear"}, op: "in", item: "bear", want: true},
+		{name: "not name in list", l: "NAME", r: []any{"cat"}, op: "in", item: "bear"},
+		{name: "name not in list", l: "NAME", r: []any{"cat"}, op: "not in", item: "bear", want: true},
+		{name: "int in list", l: 2, r: []any{1.0, 2.0}, op: "in", want: true},
+		{name: "not nil in empty list", r: []any{}, op: "in"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -16,14 +16,17 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"bytes"
+	"time"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
 	"golang.org/x/exp/constraints"
 )
 
 // The functions in this file implement a general-purpose comparison of two
 // values of type any, which for the purposes of OTTL mean values that are one of
-// int, float, string, bool, or pointers to those, or []byte, or nil.
+// int, float, string, bool, or pointers to those, or []byte, time.Time, time.Duration, or nil.
+// The IN and NOTIN operators test whether the left value is an element of the right one, which must be a list or a map.
 
 // invalidComparison returns false for everything except NE (where it returns true to indicate that the
 // objects were definitely not equivalent).
@@ -92,6 +95,25 @@ func compareBytes(a []byte, b []byte, op compareOp) bool {
 	}
 }
 
+func compareTimes(a time.Time, b time.Time, op compareOp) bool {
+	switch op {
+	case EQ:
+		return a.Equal(b)
+	case NE:
+		return !a.Equal(b)
+	case LT:
+		return a.Before(b)
+	case LTE:
+		return !a.After(b)
+	case GTE:
+		return !a.Before(b)
+	case GT:
+		return a.After(b)
+	default:
+		return false
+	}
+}
+
 func (p *Parser[K]) compareBool(a bool, b any, op compareOp) bool {
 	switch v := b.(type) {
 	case bool:
@@ -146,9 +168,76 @@ func (p *Parser[K]) compareFloat64(a float64, b any, op compareOp) bool {
 	}
 }
 
+func (p *Parser[K]) compareTime(a time.Time, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Time:
+		return compareTimes(a, v, op)
+	default:
+		return p.invalidComparison("time to non-time value", op)
+	}
+}
+
+func (p *Parser[K]) compareDuration(a time.Duration, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Duration:
+		return comparePrimitives(a, v, op)
+	default:
+		return p.invalidComparison("duration to non-duration value", op)
+	}
+}
+
+// compareIn reports whether a is one of the elements of b, using the same equality as EQ.
+// If b is a map, a is looked up in its keys.
+func (p *Parser[K]) compareIn(a any, b any, op compareOp) bool {
+	var found bool
+	switch v := b.(type) {
+	case []any:
+		found = containsElem(p, a, v)
+	case []string:
+		found = containsElem(p, a, v)
+	case []bool:
+		found = containsElem(p, a, v)
+	case []int64:
+		found = containsElem(p, a, v)
+	case []float64:
+		found = containsElem(p, a, v)
+	case pcommon.Slice:
+		for i := 0; i < v.Len() && !found; i++ {
+			found = p.compare(a, pcommonValue(v.At(i)), EQ)
+		}
+	case pcommon.Map:
+		if key, ok := a.(string); ok {
+			_, found = v.Get(key)
+		}
+	case map[string]any:
+		if key, ok := a.(string); ok {
+			_, found = v[key]
+		}
+	default:
+		p.telemetrySettings.Logger.Debug("membership test on a value that is neither a list nor a map", zap.Any("op", op))
+	}
+	if op == NOTIN {
+		return !found
+	}
+	return found
+}
+
+func containsElem[K any, T any](p *Parser[K], a any, elems []T) bool {
+	for _, elem := range elems {
+		if p.compare(a, elem, EQ) {
+			return true
+		}
+	}
+	return false
+}
+
 // a and b are the return values from a Getter; we try to compare them
 // according to the given operator.
 func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
+	// Membership tests look b up rather than comparing both sides.
+	if op == IN || op == NOTIN {
+		return p.compareIn(a, b, op)
+	}
 	// nils are equal to each other and never equal to anything else,
 	// so if they're both nil, report equality.
 	if a == nil && b == nil {
@@ -173,6 +262,10 @@ func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
 			return p.compare(b, nil, op)
 		}
 		return p.compareByte(v, b, op)
+	case time.Time:
+		return p.compareTime(v, b, op)
+	case time.Duration:
+		return p.compareDuration(v, b, op)
 	default:
 		// If we don't know what type it is, we can't do inequalities yet. So we can fall back to the old behavior where we just
 		// use Go's standard equality.
@@ -17,8 +17,10 @@ package ottl
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // Our types are bool, int, float, string, Bytes, nil, so we compare all types in both directions.
@@ -35,6 +37,10 @@ var (
 	i64b = int64(2)
 	f64a = float64(1)
 	f64b = float64(2)
+	tma  = time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC)
+	tmb  = time.Date(2023, 3, 30, 13, 0, 0, 0, time.UTC)
+	da   = time.Second
+	db   = time.Minute
 )
 
 type testA struct {
@@ -106,6 +112,21 @@ func Test_compare(t *testing.T) {
 		{"float64 nil", f64a, nil, []bool{false, true, false, false, false, false}},
 		{"float64 int64", f64a, i64b, []bool{false, true, true, true, false, false}},
 
+		{"identity time", tma, tma, []bool{true, false, false, true, true, false}},
+		{"diff times", tma, tmb, []bool{false, true, true, true, false, false}},
+		{"same time in another location", tma, tma.In(time.FixedZone("UTC+1", 3600)), []bool{true, false, false, true, true, false}},
+		{"time int64", tma, i64a, []bool{false, true, false, false, false, false}},
+		{"time nil", tma, nil, []bool{false, true, false, false, false, false}},
+		{"time duration", tma, da, []bool{false, true, false, false, false, false}},
+		{"time string", tma, sa, []bool{false, true, false, false, false, false}},
+
+		{"identity duration", da, da, []bool{true, false, false, true, true, false}},
+		{"diff durations", db, da, []bool{false, true, false, false, true, true}},
+		{"duration int64", da, i64a, []bool{false, true, false, false, false, false}},
+		{"duration time", da, tma, []bool{false, true, false, false, false, false}},
+		{"duration float64", da, f64a, []bool{false, true, false, false, false, false}},
+		{"duration nil", da, nil, []bool{false, true, false, false, false, false}},
+
 		{"non-prim, same type, equal", testA{"hi"}, testA{"hi"}, []bool{true, false, false, false, false, false}},
 		{"non-prim, same type, not equal", testA{"hi"}, testA{"byte"}, []bool{false, true, false, false, false, false}},
 		{"non-prim, diff type", testA{"hi"}, testB{"hi"}, []bool{false, true, false, false, false, false}},
@@ -125,6 +146,52 @@ func Test_compare(t *testing.T) {
 	}
 }
 
+func Test_compareIn(t *testing.T) {
+	slice := pcommon.NewSlice()
+	slice.AppendEmpty().SetStr(sa)
+	slice.AppendEmpty().SetInt(i64b)
+	m := pcommon.NewMap()
+	m.PutStr(sa, sb)
+
+	tests := []struct {
+		name string
+		a    any
+		b    any
+		want bool
+	}{
+		{"string in list", sa, []any{sb, sa}, true},
+		{"string not in list", sn, []any{sb, sa}, false},
+		{"int64 in list of float64", i64a, []any{f64a}, true},
+		{"nil in list", nil, []any{sa, nil}, true},
+		{"nil not in list", nil, []any{sa}, false},
+		{"empty list", sa, []any{}, false},
+		{"string in string slice", sa, []string{sa, sb}, true},
+		{"int64 in int64 slice", i64b, []int64{i64a}, false},
+		{"float64 in float64 slice", f64b, []float64{f64a, f64b}, true},
+		{"bool in bool slice", tb, []bool{ta}, false},
+		{"string in pcommon slice", sa, slice, true},
+		{"int64 in pcommon slice", i64b, slice, true},
+		{"float64 not in pcommon slice", f64a, slice, false},
+		{"key in pcommon map", sa, m, true},
+		{"value not in pcommon map", sb, m, false},
+		{"key in map", sa, map[string]any{sa: i64a}, true},
+		{"int64 not in map", i64a, map[string]any{sa: i64a}, false},
+		{"string in string", sa, sa, false},
+		{"string in nil", sa, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewParser[interface{}](nil, nil, componenttest.NewNopTelemetrySettings())
+			if got := p.compare(tt.a, tt.b, IN); got != tt.want {
+				t.Errorf("compare(%v, %v, IN) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := p.compare(tt.a, tt.b, NOTIN); got == tt.want {
+				t.Errorf("compare(%v, %v, NOTIN) = %v, want %v", tt.a, tt.b, got, !tt.want)
+			}
+		})
+	}
+}
+
 // Benchmarks -- these benchmarks compare the performance of comparisons of a variety of data types.
 // It's not attempting to be exhaustive, but again, it hits most of the major types and combinations.
 // The summary is that they're pretty fast; all the calls to compare are 12 ns/op or less on a 2019 intel
@@ -15,18 +15,123 @@
 package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
-func GetMapValue(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+// GetMapValue returns the value found by following keys from attrs, or nil if one of the keys isn't set.
+func GetMapValue(attrs pcommon.Map, keys []ottl.Key) (interface{}, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("cannot get map value without key")
+	}
+	if keys[0].String == nil {
+		return nil, fmt.Errorf("map must be indexed by a string but got %d", *keys[0].Int)
+	}
+	val, ok := attrs.Get(*keys[0].String)
+	if !ok {
+		return nil, nil
+	}
+	return GetIndexedValue(val, keys[1:])
+}
+
+// GetIndexedValue returns the value found by following keys from val, or nil if one of the keys isn't set.
+// Maps are indexed by string keys and slices by int keys.
+func GetIndexedValue(val pcommon.Value, keys []ottl.Key) (interface{}, error) {
+	for _, key := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return nil, fmt.Errorf("map must be indexed by a string but got %d", *key.Int)
+			}
+			v, ok := val.Map().Get(*key.String)
+			if !ok {
+				return nil, nil
+			}
+			val = v
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return nil, fmt.Errorf("slice must be indexed by an int but got %q", *key.String)
+			}
+			idx := int(*key.Int)
+			if idx < 0 || idx >= val.Slice().Len() {
+				return nil, fmt.Errorf("index %d out of bounds for a slice of length %d", idx, val.Slice().Len())
+			}
+			val = val.Slice().At(idx)
+		case pcommon.ValueTypeEmpty:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("type %v cannot be indexed", val.Type())
+		}
+	}
+	return GetValue(val), nil
+}
+
+// SetMapValue sets val at the location found by following keys from attrs.
+// Missing maps and slices along the way are created.
+func SetMapValue(attrs pcommon.Map, keys []ottl.Key, val interface{}) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("cannot set map value without key")
+	}
+	if keys[0].String == nil {
+		return fmt.Errorf("map must be indexed by a string but got %d", *keys[0].Int)
+	}
+	current, ok := attrs.Get(*keys[0].String)
 	if !ok {
-		return nil
+		current = attrs.PutEmpty(*keys[0].String)
+	}
+	return SetIndexedValue(current, keys[1:], val)
+}
+
+// SetIndexedValue sets val at the location found by following keys from current.
+// Empty values along the way become maps or slices depending on the type of the key indexing them,
+// and slices are grown with empty values up to the index being set.
+func SetIndexedValue(current pcommon.Value, keys []ottl.Key, val interface{}) error {
+	for _, key := range keys {
+		switch current.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return fmt.Errorf("map must be indexed by a string but got %d", *key.Int)
+			}
+			next, ok := current.Map().Get(*key.String)
+			if !ok {
+				next = current.Map().PutEmpty(*key.String)
+			}
+			current = next
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return fmt.Errorf("slice must be indexed by an int but got %q", *key.String)
+			}
+			idx := int(*key.Int)
+			if idx < 0 || idx >= current.Slice().Len() {
+				return fmt.Errorf("index %d out of bounds for a slice of length %d", idx, current.Slice().Len())
+			}
+			current = current.Slice().At(idx)
+		case pcommon.ValueTypeEmpty:
+			if key.String != nil {
+				current = current.SetEmptyMap().PutEmpty(*key.String)
+				continue
+			}
+			idx := int(*key.Int)
+			if idx < 0 {
+				return fmt.Errorf("index %d out of bounds", idx)
+			}
+			slice := current.SetEmptySlice()
+			for slice.Len() <= idx {
+				slice.AppendEmpty()
+			}
+			current = slice.At(idx)
+		default:
+			return fmt.Errorf("type %v cannot be indexed", current.Type())
+		}
 	}
-	return GetValue(val)
+	newValue(val).CopyTo(current)
+	return nil
 }
 
-func SetMapValue(attrs pcommon.Map, mapKey string, val interface{}) {
+func newValue(val interface{}) pcommon.Value {
 	var value pcommon.Value
 	switch val.(type) {
 	case []string, []bool, []int64, []float64, [][]byte, []any:
@@ -36,5 +141,5 @@ func SetMapValue(attrs pcommon.Map, mapKey string, val interface{}) {
 	}
 
 	SetValue(value, val)
-	value.CopyTo(attrs.PutEmpty(mapKey))
+	return value
 }
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func createNestedMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.PutStr("str", "val")
+	http := m.PutEmptyMap("http")
+	http.PutStr("method", "GET")
+	items := m.PutEmptySlice("items")
+	items.AppendEmpty().SetStr("first")
+	items.AppendEmpty().SetEmptyMap().PutInt("id", 2)
+	return m
+}
+
+func Test_GetMapValue(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []ottl.Key
+		want interface{}
+	}{
+		{
+			name: "single key",
+			keys: []ottl.Key{{String: ottltest.Strp("str")}},
+			want: "val",
+		},
+		{
+			name: "nested map",
+			keys: []ottl.Key{{String: ottltest.Strp("http")}, {String: ottltest.Strp("method")}},
+			want: "GET",
+		},
+		{
+			name: "slice index",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(0)}},
+			want: "first",
+		},
+		{
+			name: "map in slice",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(1)}, {String: ottltest.Strp("id")}},
+			want: int64(2),
+		},
+		{
+			name: "missing key",
+			keys: []ottl.Key{{String: ottltest.Strp("missing")}},
+			want: nil,
+		},
+		{
+			name: "missing nested key",
+			keys: []ottl.Key{{String: ottltest.Strp("http")}, {String: ottltest.Strp("missing")}, {String: ottltest.Strp("other")}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetMapValue(createNestedMap(), tt.keys)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetMapValue_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []ottl.Key
+	}{
+		{
+			name: "no keys",
+			keys: nil,
+		},
+		{
+			name: "map indexed by int",
+			keys: []ottl.Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "slice indexed by string",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {String: ottltest.Strp("first")}},
+		},
+		{
+			name: "index out of bounds",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(2)}},
+		},
+		{
+			name: "string indexed",
+			keys: []ottl.Key{{String: ottltest.Strp("str")}, {String: ottltest.Strp("val")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetMapValue(createNestedMap(), tt.keys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_SetMapValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []ottl.Key
+		val      interface{}
+		expected func(m pcommon.Map)
+	}{
+		{
+			name: "single key",
+			keys: []ottl.Key{{String: ottltest.Strp("str")}},
+			val:  "new",
+			expected: func(m pcommon.Map) {
+				m.PutStr("str", "new")
+			},
+		},
+		{
+			name: "nested map",
+			keys: []ottl.Key{{String: ottltest.Strp("http")}, {String: ottltest.Strp("status")}},
+			val:  int64(200),
+			expected: func(m pcommon.Map) {
+				v, _ := m.Get("http")
+				v.Map().PutInt("status", 200)
+			},
+		},
+		{
+			name: "slice index",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(0)}},
+			val:  []string{"a", "b"},
+			expected: func(m pcommon.Map) {
+				v, _ := m.Get("items")
+				s := v.Slice().At(0).SetEmptySlice()
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
+		{
+			name: "missing maps are created",
+			keys: []ottl.Key{{String: ottltest.Strp("a")}, {String: ottltest.Strp("b")}},
+			val:  true,
+			expected: func(m pcommon.Map) {
+				m.PutEmptyMap("a").PutBool("b", true)
+			},
+		},
+		{
+			name: "missing slices are created",
+			keys: []ottl.Key{{String: ottltest.Strp("a")}, {Int: ottltest.Intp(1)}},
+			val:  1.5,
+			expected: func(m pcommon.Map) {
+				s := m.PutEmptySlice("a")
+				s.AppendEmpty()
+				s.AppendEmpty().SetDouble(1.5)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createNestedMap()
+			err := SetMapValue(m, tt.keys, tt.val)
+			assert.NoError(t, err)
+
+			expected := createNestedMap()
+			tt.expected(expected)
+			assert.Equal(t, expected, m)
+		})
+	}
+}
+
+func Test_SetMapValue_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []ottl.Key
+	}{
+		{
+			name: "no keys",
+			keys: nil,
+		},
+		{
+			name: "map indexed by int",
+			keys: []ottl.Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "nested map indexed by int",
+			keys: []ottl.Key{{String: ottltest.Strp("http")}, {Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "slice indexed by string",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {String: ottltest.Strp("first")}},
+		},
+		{
+			name: "index out of bounds",
+			keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(2)}},
+		},
+		{
+			name: "string indexed",
+			keys: []ottl.Key{{String: ottltest.Strp("str")}, {String: ottltest.Strp("val")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetMapValue(createNestedMap(), tt.keys, "new")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -39,6 +39,17 @@ var MetricSymbolTable = map[ottl.EnumSymbol]ottl.Enum{
 	"METRIC_DATA_TYPE_SUMMARY":               ottl.Enum(pmetric.MetricTypeSummary),
 }
 
+// MetricPaths are the paths supported by MetricPathGetSetter.
+var MetricPaths = []string{
+	"name",
+	"description",
+	"unit",
+	"type",
+	"aggregation_temporality",
+	"is_monotonic",
+	"data_points",
+}
+
 func MetricPathGetSetter[K MetricContext](path []ottl.Field) (ottl.GetSetter[K], error) {
 	if len(path) == 0 {
 		return accessMetric[K](), nil
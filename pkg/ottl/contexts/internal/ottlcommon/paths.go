@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
+
+// PrefixPaths returns prefix followed by each of the paths nested under it.
+// It is used by the contexts to build the list of path names they support.
+func PrefixPaths(prefix string, paths []string) []string {
+	prefixed := make([]string, 0, len(paths)+1)
+	prefixed = append(prefixed, prefix)
+	for _, path := range paths {
+		prefixed = append(prefixed, prefix+"."+path)
+	}
+	return prefixed
+}
+
+// ConcatPaths returns a single list holding all the given lists of paths.
+func ConcatPaths(paths ...[]string) []string {
+	var concatenated []string
+	for _, p := range paths {
+		concatenated = append(concatenated, p...)
+	}
+	return concatenated
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_PrefixPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		paths    []string
+		expected []string
+	}{
+		{
+			name:     "no paths",
+			prefix:   "resource",
+			expected: []string{"resource"},
+		},
+		{
+			name:     "paths",
+			prefix:   "resource",
+			paths:    []string{"attributes", "dropped_attributes_count"},
+			expected: []string{"resource", "resource.attributes", "resource.dropped_attributes_count"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PrefixPaths(tt.prefix, tt.paths))
+		})
+	}
+}
+
+func Test_ConcatPaths(t *testing.T) {
+	assert.Nil(t, ConcatPaths())
+	assert.Equal(t, []string{"cache", "name", "resource"}, ConcatPaths([]string{"cache"}, []string{"name"}, nil, []string{"resource"}))
+}
+
+func Test_Paths(t *testing.T) {
+	tests := []struct {
+		name      string
+		paths     []string
+		getSetter func([]ottl.Field) (interface{}, error)
+	}{
+		{
+			name:  "resource",
+			paths: ResourcePaths,
+			getSetter: func(path []ottl.Field) (interface{}, error) {
+				return ResourcePathGetSetter[*resourceContext](path)
+			},
+		},
+		{
+			name:  "scope",
+			paths: ScopePaths,
+			getSetter: func(path []ottl.Field) (interface{}, error) {
+				return ScopePathGetSetter[*instrumentationScopeContext](path)
+			},
+		},
+		{
+			name:  "span",
+			paths: SpanPaths,
+			getSetter: func(path []ottl.Field) (interface{}, error) {
+				return SpanPathGetSetter[*spanContext](path)
+			},
+		},
+		{
+			name:  "metric",
+			paths: MetricPaths,
+			getSetter: func(path []ottl.Field) (interface{}, error) {
+				return MetricPathGetSetter[*metricContext](path)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range tt.paths {
+				_, err := tt.getSetter(toFields(path))
+				assert.NoError(t, err, path)
+			}
+		})
+	}
+}
+
+func toFields(path string) []ottl.Field {
+	var fields []ottl.Field
+	for _, name := range strings.Split(path, ".") {
+		fields = append(fields, ottl.Field{Name: name})
+	}
+	return fields
+}
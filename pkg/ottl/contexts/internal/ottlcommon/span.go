@@ -43,6 +43,30 @@ var SpanSymbolTable = map[ottl.EnumSymbol]ottl.Enum{
 	"STATUS_CODE_ERROR":     ottl.Enum(ptrace.StatusCodeError),
 }
 
+// SpanPaths are the paths supported by SpanPathGetSetter.
+var SpanPaths = []string{
+	"trace_id",
+	"trace_id.string",
+	"span_id",
+	"span_id.string",
+	"trace_state",
+	"parent_span_id",
+	"parent_span_id.string",
+	"name",
+	"kind",
+	"start_time_unix_nano",
+	"end_time_unix_nano",
+	"attributes",
+	"dropped_attributes_count",
+	"events",
+	"dropped_events_count",
+	"links",
+	"dropped_links_count",
+	"status",
+	"status.code",
+	"status.message",
+}
+
 func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], error) {
 	if len(path) == 0 {
 		return accessSpan[K](), nil
@@ -64,11 +88,14 @@ func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], err
 			return accessStringSpanID[K](), nil
 		}
 	case "trace_state":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if keys == nil {
 			return accessTraceState[K](), nil
 		}
-		return accessTraceStateKey[K](mapKey), nil
+		if len(keys) != 1 || keys[0].String == nil {
+			return nil, fmt.Errorf("trace_state must be indexed by a single string key")
+		}
+		return accessTraceStateKey[K](keys[0].String), nil
 	case "parent_span_id":
 		if len(path) == 1 {
 			return accessParentSpanID[K](), nil
@@ -85,11 +112,11 @@ func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], err
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano[K](), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		keys := path[0].Keys
+		if keys == nil {
 			return accessAttributes[K](), nil
 		}
-		return accessAttributesKey[K](mapKey), nil
+		return accessAttributesKey[K](keys), nil
 	case "dropped_attributes_count":
 		return accessSpanDroppedAttributesCount[K](), nil
 	case "events":
@@ -333,14 +360,13 @@ func accessAttributes[K SpanContext]() ottl.StandardGetSetter[K] {
 	}
 }
 
-func accessAttributesKey[K SpanContext](mapKey *string) ottl.StandardGetSetter[K] {
+func accessAttributesKey[K SpanContext](keys []ottl.Key) ottl.StandardGetSetter[K] {
 	return ottl.StandardGetSetter[K]{
 		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
-			return GetMapValue(tCtx.GetSpan().Attributes(), *mapKey), nil
+			return GetMapValue(tCtx.GetSpan().Attributes(), keys)
 		},
 		Setter: func(ctx context.Context, tCtx K, val interface{}) error {
-			SetMapValue(tCtx.GetSpan().Attributes(), *mapKey, val)
-			return nil
+			return SetMapValue(tCtx.GetSpan().Attributes(), keys, val)
 		},
 	}
 }
@@ -132,8 +132,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "trace_state key",
 			path: []ottl.Field{
 				{
-					Name:   "trace_state",
-					MapKey: ottltest.Strp("key1"),
+					Name: "trace_state",
+					Keys: []ottl.Key{{String: ottltest.Strp("key1")}},
 				},
 			},
 			orig:   "val1",
@@ -240,8 +240,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("str")}},
 				},
 			},
 			orig:   "val",
@@ -254,8 +254,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bool")}},
 				},
 			},
 			orig:   true,
@@ -268,8 +268,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("int")}},
 				},
 			},
 			orig:   int64(10),
@@ -282,8 +282,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("double")}},
 				},
 			},
 			orig:   float64(1.2),
@@ -296,8 +296,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bytes")}},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -310,8 +310,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array empty",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_empty"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_empty")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -327,8 +327,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -344,8 +344,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -361,8 +361,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -378,8 +378,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -395,8 +395,8 @@ func TestSpanPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -547,6 +547,33 @@ func TestSpanPathGetSetter(t *testing.T) {
 	}
 }
 
+func TestSpanPathGetSetter_TraceStateInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []ottl.Key
+	}{
+		{
+			name: "int key",
+			keys: []ottl.Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "nested keys",
+			keys: []ottl.Key{{String: ottltest.Strp("key1")}, {String: ottltest.Strp("key2")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SpanPathGetSetter[*spanContext]([]ottl.Field{
+				{
+					Name: "trace_state",
+					Keys: tt.keys,
+				},
+			})
+			assert.Error(t, err)
+		})
+	}
+}
+
 func createSpan() ptrace.Span {
 	span := ptrace.NewSpan()
 	span.SetTraceID(traceID)
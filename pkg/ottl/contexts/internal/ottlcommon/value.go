@@ -86,7 +86,7 @@ func SetValue(value pcommon.Value, val interface{}) {
 	case map[string]interface{}:
 		value.SetEmptyMap()
 		for mk, mv := range v {
-			SetMapValue(value.Map(), mk, mv)
+			newValue(mv).CopyTo(value.Map().PutEmpty(mk))
 		}
 	}
 }
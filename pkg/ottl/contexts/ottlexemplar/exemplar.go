@@ -0,0 +1,463 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlexemplar // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
+)
+
+var _ ottlcommon.ResourceContext = TransformContext{}
+var _ ottlcommon.InstrumentationScopeContext = TransformContext{}
+var _ ottlcommon.MetricContext = TransformContext{}
+
+type TransformContext struct {
+	exemplar             pmetric.Exemplar
+	dataPoint            interface{}
+	metric               pmetric.Metric
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+	cache                pcommon.Map
+}
+
+// dataPoint is implemented by the pmetric data points that hold exemplars.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+var _ dataPoint = pmetric.NumberDataPoint{}
+var _ dataPoint = pmetric.HistogramDataPoint{}
+var _ dataPoint = pmetric.ExponentialHistogramDataPoint{}
+
+type Option func(*ottl.Parser[TransformContext])
+
+// NewTransformContext creates a TransformContext for an exemplar of dataPoint, which must be a
+// pmetric.NumberDataPoint, pmetric.HistogramDataPoint or pmetric.ExponentialHistogramDataPoint.
+func NewTransformContext(exemplar pmetric.Exemplar, dataPoint interface{}, metric pmetric.Metric, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		exemplar:             exemplar,
+		dataPoint:            dataPoint,
+		metric:               metric,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+		cache:                pcommon.NewMap(),
+	}
+}
+
+func (tCtx TransformContext) GetExemplar() pmetric.Exemplar {
+	return tCtx.exemplar
+}
+
+func (tCtx TransformContext) GetDataPoint() interface{} {
+	return tCtx.dataPoint
+}
+
+func (tCtx TransformContext) GetMetric() pmetric.Metric {
+	return tCtx.metric
+}
+
+func (tCtx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return tCtx.instrumentationScope
+}
+
+func (tCtx TransformContext) GetResource() pcommon.Resource {
+	return tCtx.resource
+}
+
+func (tCtx TransformContext) getCache() pcommon.Map {
+	return tCtx.cache
+}
+
+func NewParser(functions map[string]interface{}, telemetrySettings component.TelemetrySettings, options ...Option) (ottl.Parser[TransformContext], error) {
+	p, err := ottl.NewParser[TransformContext](
+		functions,
+		parsePath,
+		telemetrySettings,
+		ottl.WithEnumParser[TransformContext](parseEnum),
+		ottl.WithPathNames[TransformContext](pathNames),
+	)
+	if err != nil {
+		return ottl.Parser[TransformContext]{}, err
+	}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return p, nil
+}
+
+type StatementsOption func(*ottl.Statements[TransformContext])
+
+func WithErrorMode(errorMode ottl.ErrorMode) StatementsOption {
+	return func(s *ottl.Statements[TransformContext]) {
+		ottl.WithErrorMode[TransformContext](errorMode)(s)
+	}
+}
+
+func NewStatements(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementsOption) ottl.Statements[TransformContext] {
+	s := ottl.NewStatements(statements, telemetrySettings)
+	for _, op := range options {
+		op(&s)
+	}
+	return s
+}
+
+func parseEnum(val *ottl.EnumSymbol) (*ottl.Enum, error) {
+	if val != nil {
+		if enum, ok := ottlcommon.MetricSymbolTable[*val]; ok {
+			return &enum, nil
+		}
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, fmt.Errorf("enum symbol not provided")
+}
+
+// pathNames are the paths supported by the context, used to suggest a valid path when a statement uses an unknown one.
+var pathNames = ottlcommon.ConcatPaths(
+	[]string{"cache"},
+	ottlcommon.PrefixPaths("resource", ottlcommon.ResourcePaths),
+	ottlcommon.PrefixPaths("instrumentation_scope", ottlcommon.ScopePaths),
+	ottlcommon.PrefixPaths("metric", ottlcommon.MetricPaths),
+	[]string{
+		"datapoint.attributes",
+		"datapoint.start_time_unix_nano",
+		"datapoint.time_unix_nano",
+		"time_unix_nano",
+		"value_double",
+		"value_int",
+		"filtered_attributes",
+		"trace_id",
+		"trace_id.string",
+		"span_id",
+		"span_id.string",
+	},
+)
+
+func parsePath(val *ottl.Path) (ottl.GetSetter[TransformContext], error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []ottl.Field) (ottl.GetSetter[TransformContext], error) {
+	switch path[0].Name {
+	case "cache":
+		keys := path[0].Keys
+		if keys == nil {
+			return accessCache(), nil
+		}
+		return accessCacheKey(keys), nil
+	case "resource":
+		return ottlcommon.ResourcePathGetSetter[TransformContext](path[1:])
+	case "instrumentation_scope":
+		return ottlcommon.ScopePathGetSetter[TransformContext](path[1:])
+	case "metric":
+		return ottlcommon.MetricPathGetSetter[TransformContext](path[1:])
+	case "datapoint":
+		return newDataPointPathGetSetter(path[1:])
+	case "time_unix_nano":
+		return accessTimeUnixNano(), nil
+	case "value_double":
+		return accessDoubleValue(), nil
+	case "value_int":
+		return accessIntValue(), nil
+	case "filtered_attributes":
+		keys := path[0].Keys
+		if keys == nil {
+			return accessFilteredAttributes(), nil
+		}
+		return accessFilteredAttributesKey(keys), nil
+	case "trace_id":
+		if len(path) == 1 {
+			return accessTraceID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessStringTraceID(), nil
+		}
+	case "span_id":
+		if len(path) == 1 {
+			return accessSpanID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessStringSpanID(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid exemplar path expression %v", path)
+}
+
+// newDataPointPathGetSetter returns the GetSetter for a path of the data point holding the exemplar.
+func newDataPointPathGetSetter(path []ottl.Field) (ottl.GetSetter[TransformContext], error) {
+	if len(path) == 1 {
+		switch path[0].Name {
+		case "attributes":
+			keys := path[0].Keys
+			if keys == nil {
+				return accessDataPointAttributes(), nil
+			}
+			return accessDataPointAttributesKey(keys), nil
+		case "start_time_unix_nano":
+			return accessDataPointStartTimeUnixNano(), nil
+		case "time_unix_nano":
+			return accessDataPointTimeUnixNano(), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid datapoint path expression %v", path)
+}
+
+func accessCache() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.getCache(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if m, ok := val.(pcommon.Map); ok {
+				m.CopyTo(tCtx.getCache())
+			}
+			return nil
+		},
+	}
+}
+
+func accessCacheKey(keys []ottl.Key) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return ottlcommon.GetMapValue(tCtx.getCache(), keys)
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			return ottlcommon.SetMapValue(tCtx.getCache(), keys, val)
+		},
+	}
+}
+
+func accessDataPointAttributes() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+				return dp.Attributes(), nil
+			}
+			return nil, nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+					attrs.CopyTo(dp.Attributes())
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func accessDataPointAttributesKey(keys []ottl.Key) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+				return ottlcommon.GetMapValue(dp.Attributes(), keys)
+			}
+			return nil, nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+				return ottlcommon.SetMapValue(dp.Attributes(), keys, val)
+			}
+			return nil
+		},
+	}
+}
+
+func accessDataPointStartTimeUnixNano() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+				return dp.StartTimestamp().AsTime().UnixNano(), nil
+			}
+			return nil, nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newTime, ok := val.(int64); ok {
+				if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+					dp.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func accessDataPointTimeUnixNano() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+				return dp.Timestamp().AsTime().UnixNano(), nil
+			}
+			return nil, nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newTime, ok := val.(int64); ok {
+				if dp, ok := tCtx.GetDataPoint().(dataPoint); ok {
+					dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func accessTimeUnixNano() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().Timestamp().AsTime().UnixNano(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newTime, ok := val.(int64); ok {
+				tCtx.GetExemplar().SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDoubleValue() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().DoubleValue(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newDouble, ok := val.(float64); ok {
+				tCtx.GetExemplar().SetDoubleValue(newDouble)
+			}
+			return nil
+		},
+	}
+}
+
+func accessIntValue() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().IntValue(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newInt, ok := val.(int64); ok {
+				tCtx.GetExemplar().SetIntValue(newInt)
+			}
+			return nil
+		},
+	}
+}
+
+func accessFilteredAttributes() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().FilteredAttributes(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(tCtx.GetExemplar().FilteredAttributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessFilteredAttributesKey(keys []ottl.Key) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return ottlcommon.GetMapValue(tCtx.GetExemplar().FilteredAttributes(), keys)
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			return ottlcommon.SetMapValue(tCtx.GetExemplar().FilteredAttributes(), keys, val)
+		},
+	}
+}
+
+func accessTraceID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().TraceID(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newTraceID, ok := val.(pcommon.TraceID); ok {
+				tCtx.GetExemplar().SetTraceID(newTraceID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringTraceID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			id := tCtx.GetExemplar().TraceID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				id, err := ottlcommon.ParseTraceID(str)
+				if err != nil {
+					return err
+				}
+				tCtx.GetExemplar().SetTraceID(id)
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			return tCtx.GetExemplar().SpanID(), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if newSpanID, ok := val.(pcommon.SpanID); ok {
+				tCtx.GetExemplar().SetSpanID(newSpanID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringSpanID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
+			id := tCtx.GetExemplar().SpanID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				id, err := ottlcommon.ParseSpanID(str)
+				if err != nil {
+					return err
+				}
+				tCtx.GetExemplar().SetSpanID(id)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlexemplar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refExemplar, refDataPoint, _, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     []ottl.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: []ottl.Field{
+				{
+					Name: "cache",
+				},
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: []ottl.Field{
+				{
+					Name: "cache",
+					Keys: []ottl.Key{{String: ottltest.Strp("temp")}},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "time_unix_nano",
+			path: []ottl.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "value_double",
+			path: []ottl.Field{
+				{
+					Name: "value_double",
+				},
+			},
+			orig:   1.5,
+			newVal: 2.5,
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetDoubleValue(2.5)
+			},
+		},
+		{
+			name: "value_int",
+			path: []ottl.Field{
+				{
+					Name: "value_int",
+				},
+			},
+			orig:   int64(0),
+			newVal: int64(3),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetIntValue(3)
+			},
+		},
+		{
+			name: "filtered_attributes",
+			path: []ottl.Field{
+				{
+					Name: "filtered_attributes",
+				},
+			},
+			orig:   refExemplar.FilteredAttributes(),
+			newVal: newAttrs,
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				newAttrs.CopyTo(exemplar.FilteredAttributes())
+			},
+		},
+		{
+			name: "filtered_attributes string",
+			path: []ottl.Field{
+				{
+					Name: "filtered_attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("user.email")}},
+				},
+			},
+			orig:   "user@example.com",
+			newVal: "redacted",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.FilteredAttributes().PutStr("user.email", "redacted")
+			},
+		},
+		{
+			name: "trace_id",
+			path: []ottl.Field{
+				{
+					Name: "trace_id",
+				},
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: []ottl.Field{
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   "0102030405060708090a0b0c0d0e0f10",
+			newVal: "100f0e0d0c0b0a090807060504030201",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: []ottl.Field{
+				{
+					Name: "span_id",
+				},
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: []ottl.Field{
+				{
+					Name: "span_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   "0102030405060708",
+			newVal: "0807060504030201",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "datapoint attributes",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refDataPoint.Attributes(),
+			newVal: newAttrs,
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				newAttrs.CopyTo(dataPoint.Attributes())
+			},
+		},
+		{
+			name: "datapoint attributes string",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("http.method")}},
+				},
+			},
+			orig:   "GET",
+			newVal: "POST",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				dataPoint.Attributes().PutStr("http.method", "POST")
+			},
+		},
+		{
+			name: "datapoint start_time_unix_nano",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+				{
+					Name: "start_time_unix_nano",
+				},
+			},
+			orig:   int64(50_000_000),
+			newVal: int64(60_000_000),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				dataPoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(60)))
+			},
+		},
+		{
+			name: "datapoint time_unix_nano",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(150_000_000),
+			newVal: int64(160_000_000),
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				dataPoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(160)))
+			},
+		},
+		{
+			name: "metric name",
+			path: []ottl.Field{
+				{
+					Name: "metric",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "http.server.duration",
+			newVal: "http.client.duration",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				metric.SetName("http.client.duration")
+			},
+		},
+		{
+			name: "resource attributes",
+			path: []ottl.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("service.name")}},
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(exemplar pmetric.Exemplar, dataPoint pmetric.NumberDataPoint, metric pmetric.Metric, resource pcommon.Resource, cache pcommon.Map) {
+				resource.Attributes().PutStr("service.name", "cart")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			exemplar, dataPoint, metric, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(exemplar, dataPoint, metric, il, resource)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exExemplar, exDataPoint, exMetric, _, exRes := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exExemplar, exDataPoint, exMetric, exRes, exCache)
+
+			assert.Equal(t, exExemplar, exemplar)
+			assert.Equal(t, exDataPoint, dataPoint)
+			assert.Equal(t, exMetric, metric)
+			assert.Equal(t, exRes, resource)
+			assert.Equal(t, exCache, tCtx.getCache())
+		})
+	}
+}
+
+func Test_newPathGetSetter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []ottl.Field
+	}{
+		{
+			name: "unknown path",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+				},
+			},
+		},
+		{
+			name: "unknown datapoint path",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+				{
+					Name: "value_double",
+				},
+			},
+		},
+		{
+			name: "datapoint",
+			path: []ottl.Field{
+				{
+					Name: "datapoint",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
+	exemplar, _, metric, il, resource := createTelemetry()
+	dataPoint := pmetric.NewHistogramDataPoint()
+	dataPoint.Attributes().PutStr("http.method", "GET")
+
+	accessor, err := newPathGetSetter([]ottl.Field{{Name: "datapoint"}, {Name: "attributes", Keys: []ottl.Key{{String: ottltest.Strp("http.method")}}}})
+	assert.NoError(t, err)
+
+	tCtx := NewTransformContext(exemplar, dataPoint, metric, il, resource)
+	got, err := accessor.Get(context.Background(), tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "GET", got)
+
+	assert.NoError(t, accessor.Set(context.Background(), tCtx, "POST"))
+	method, _ := dataPoint.Attributes().Get("http.method")
+	assert.Equal(t, "POST", method.Str())
+}
+
+func createTelemetry() (pmetric.Exemplar, pmetric.NumberDataPoint, pmetric.Metric, pcommon.InstrumentationScope, pcommon.Resource) {
+	exemplar := pmetric.NewExemplar()
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	exemplar.SetDoubleValue(1.5)
+	exemplar.SetTraceID(traceID)
+	exemplar.SetSpanID(spanID)
+	exemplar.FilteredAttributes().PutStr("user.email", "user@example.com")
+
+	dataPoint := pmetric.NewNumberDataPoint()
+	dataPoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(50)))
+	dataPoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(150)))
+	dataPoint.Attributes().PutStr("http.method", "GET")
+
+	metric := pmetric.NewMetric()
+	metric.SetName("http.server.duration")
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	return exemplar, dataPoint, metric, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "AGGREGATION_TEMPORALITY_DELTA",
+			want: ottl.Enum(pmetric.AggregationTemporalityDelta),
+		},
+		{
+			name: "METRIC_DATA_TYPE_HISTOGRAM",
+			want: ottl.Enum(pmetric.MetricTypeHistogram),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, *actual, tt.want)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func Test_pathNames(t *testing.T) {
+	for _, name := range pathNames {
+		var path []ottl.Field
+		for _, field := range strings.Split(name, ".") {
+			path = append(path, ottl.Field{Name: field})
+		}
+		_, err := newPathGetSetter(path)
+		assert.NoError(t, err, name)
+	}
+}
@@ -17,6 +17,7 @@ package ottllog
 import (
 	"context"
 	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
@@ -86,6 +87,32 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 100_000_000, time.UTC),
+			newVal: time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				log.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC)))
+			},
+		},
+		{
+			name: "observed_time",
+			path: []ottl.Field{
+				{
+					Name: "observed_time",
+				},
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 500_000_000, time.UTC),
+			newVal: time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC),
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				log.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC)))
+			},
+		},
 		{
 			name: "severity_number",
 			path: []ottl.Field{
@@ -213,8 +240,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "cache access",
 			path: []ottl.Field{
 				{
-					Name:   "cache",
-					MapKey: ottltest.Strp("temp"),
+					Name: "cache",
+					Keys: []ottl.Key{{String: ottltest.Strp("temp")}},
 				},
 			},
 			orig:   nil,
@@ -240,8 +267,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("str")}},
 				},
 			},
 			orig:   "val",
@@ -254,8 +281,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bool")}},
 				},
 			},
 			orig:   true,
@@ -268,8 +295,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("int")}},
 				},
 			},
 			orig:   int64(10),
@@ -282,8 +309,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("double")}},
 				},
 			},
 			orig:   float64(1.2),
@@ -296,8 +323,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bytes")}},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -310,8 +337,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -327,8 +354,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -344,8 +371,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -361,8 +388,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -378,8 +405,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -395,8 +422,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes pcommon.Map",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("pMap"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("pMap")}},
 				},
 			},
 			orig: func() pcommon.Map {
@@ -414,8 +441,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes map[string]interface{}",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("map"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("map")}},
 				},
 			},
 			orig: func() pcommon.Map {
@@ -429,6 +456,36 @@ func Test_newPathGetSetter(t *testing.T) {
 				m2.PutStr("k1", "string")
 			},
 		},
+		{
+			name: "attributes nested map",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("pMap")}, {String: ottltest.Strp("original")}},
+				},
+			},
+			orig:   "map",
+			newVal: "new",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				val, _ := log.Attributes().Get("pMap")
+				val.Map().PutStr("original", "new")
+			},
+		},
+		{
+			name: "attributes slice index",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}, {Int: ottltest.Intp(1)}},
+				},
+			},
+			orig:   "two",
+			newVal: "three",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map) {
+				val, _ := log.Attributes().Get("arr_str")
+				val.Slice().At(1).SetStr("three")
+			},
+		},
 		{
 			name: "dropped_attributes_count",
 			path: []ottl.Field{
@@ -497,6 +554,24 @@ func Test_newPathGetSetter(t *testing.T) {
 	}
 }
 
+func Test_newPathGetSetter_TimeInvalidType(t *testing.T) {
+	for _, name := range []string{"time", "observed_time"} {
+		t.Run(name, func(t *testing.T) {
+			accessor, err := newPathGetSetter([]ottl.Field{{Name: name}})
+			assert.NoError(t, err)
+
+			log, il, resource := createTelemetry()
+			tCtx := NewTransformContext(log, il, resource)
+			err = accessor.Set(context.Background(), tCtx, int64(1))
+			assert.EqualError(t, err, name+" must be a time.Time but got int64")
+
+			// the log record is left unchanged
+			exLog, _, _ := createTelemetry()
+			assert.Equal(t, exLog, log)
+		})
+	}
+}
+
 func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Resource) {
 	log := plog.NewLogRecord()
 	log.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
@@ -553,6 +628,33 @@ func createTelemetry() (plog.LogRecord, pcommon.InstrumentationScope, pcommon.Re
 	return log, il, resource
 }
 
+func Test_newPathGetSetter_BodyKeys(t *testing.T) {
+	log := plog.NewLogRecord()
+	items := log.Body().SetEmptyMap().PutEmptySlice("items")
+	items.AppendEmpty().SetStr("first")
+
+	accessor, err := newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{{String: ottltest.Strp("items")}, {Int: ottltest.Intp(0)}},
+		},
+	})
+	assert.NoError(t, err)
+
+	tCtx := NewTransformContext(log, pcommon.NewInstrumentationScope(), pcommon.NewResource())
+	got, err := accessor.Get(context.Background(), tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got)
+
+	err = accessor.Set(context.Background(), tCtx, "second")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", items.At(0).Str())
+
+	log.Body().SetStr("body")
+	_, err = accessor.Get(context.Background(), tCtx)
+	assert.Error(t, err)
+}
+
 func Test_ParseEnum(t *testing.T) {
 	tests := []struct {
 		name string
@@ -690,3 +792,14 @@ func Test_ParseEnum_False(t *testing.T) {
 		})
 	}
 }
+
+func Test_pathNames(t *testing.T) {
+	for _, name := range pathNames {
+		var path []ottl.Field
+		for _, field := range strings.Split(name, ".") {
+			path = append(path, ottl.Field{Name: field})
+		}
+		_, err := newPathGetSetter(path)
+		assert.NoError(t, err, name)
+	}
+}
@@ -16,6 +16,7 @@ package ottlmetric
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,8 +155,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "cache access",
 			path: []ottl.Field{
 				{
-					Name:   "cache",
-					MapKey: ottltest.Strp("temp"),
+					Name: "cache",
+					Keys: []ottl.Key{{String: ottltest.Strp("temp")}},
 				},
 			},
 			orig:   nil,
@@ -172,7 +173,7 @@ func Test_newPathGetSetter(t *testing.T) {
 
 			metric := createMetricTelemetry()
 
-			ctx := NewTransformContext(metric, pcommon.NewInstrumentationScope(), pcommon.NewResource())
+			ctx := NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource())
 
 			got, err := accessor.Get(context.Background(), ctx)
 			assert.Nil(t, err)
@@ -274,3 +275,14 @@ func Test_ParseEnum_False(t *testing.T) {
 		})
 	}
 }
+
+func Test_pathNames(t *testing.T) {
+	for _, name := range pathNames {
+		var path []ottl.Field
+		for _, field := range strings.Split(name, ".") {
+			path = append(path, ottl.Field{Name: field})
+		}
+		_, err := newPathGetSetter(path)
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/participle/v2"
+)
+
+// StatementError is returned by the Parser when a statement cannot be parsed.
+// It points to the column and token of the statement that caused the error and,
+// when one could be found, suggests a valid path or function to use instead.
+type StatementError struct {
+	// Statement is the statement that failed to parse.
+	Statement string
+	// Column is the 1-based column of the offending token, or 0 if it is unknown.
+	Column int
+	// Token is the offending token, or empty if it is unknown.
+	Token string
+	// Suggestion is the closest valid path or function name to Token, or empty if there is none.
+	Suggestion string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *StatementError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "unable to parse OTTL statement %q: ", e.Statement)
+	if e.Column > 0 {
+		fmt.Fprintf(&b, "column %d near %q: ", e.Column, e.Token)
+	}
+	b.WriteString(e.Err.Error())
+	if e.Suggestion != "" {
+		fmt.Fprintf(&b, ", did you mean %q?", e.Suggestion)
+	}
+	return b.String()
+}
+
+func (e *StatementError) Unwrap() error {
+	return e.Err
+}
+
+// tokenError is an error caused by a single token of a statement, such as an
+// unknown function or path.
+type tokenError struct {
+	token      string
+	suggestion string
+	err        error
+}
+
+func (e *tokenError) Error() string {
+	return e.err.Error()
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
+
+// newStatementError wraps err, returned while parsing statement, into a StatementError.
+func newStatementError(statement string, err error) *StatementError {
+	se := &StatementError{
+		Statement: statement,
+		Err:       err,
+	}
+	var pErr participle.Error
+	var tErr *tokenError
+	switch {
+	case errors.As(err, &pErr):
+		pos := pErr.Position()
+		se.Column = pos.Column
+		se.Token = tokenAt(statement, pos.Offset)
+		se.Err = errors.New(pErr.Message())
+	case errors.As(err, &tErr):
+		se.Token = tErr.token
+		se.Suggestion = tErr.suggestion
+		if i := indexToken(statement, tErr.token); i >= 0 {
+			se.Column = i + 1
+		}
+	}
+	return se
+}
+
+// tokenAt returns the token of the statement starting at offset, or an empty string if there is none.
+func tokenAt(statement string, offset int) string {
+	lex, err := buildLexer().LexString("", statement)
+	if err != nil {
+		return ""
+	}
+	for {
+		tok, err := lex.Next()
+		if err != nil || tok.EOF() {
+			return ""
+		}
+		if tok.Pos.Offset == offset {
+			return tok.Value
+		}
+	}
+}
+
+// indexToken returns the index of the first occurrence of token in statement that is
+// not part of a longer identifier, or -1 if there is none.
+func indexToken(statement string, token string) int {
+	if token == "" {
+		return -1
+	}
+	for start := 0; start < len(statement); {
+		i := strings.Index(statement[start:], token)
+		if i < 0 {
+			return -1
+		}
+		i += start
+		end := i + len(token)
+		if (i == 0 || !isIdentRune(rune(statement[i-1]))) && (end == len(statement) || !isIdentRune(rune(statement[end]))) {
+			return i
+		}
+		start = i + 1
+	}
+	return -1
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// closest returns the candidate nearest to name by edit distance, or an empty string
+// if no candidate is close enough to be a likely typo.
+func closest(name string, candidates []string) string {
+	threshold := len(name) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+	best := ""
+	bestDistance := threshold + 1
+	for _, candidate := range candidates {
+		if d := levenshtein(name, candidate); d < bestDistance || (d == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func Test_ParseStatement_StatementError(t *testing.T) {
+	functions := map[string]interface{}{
+		"set":    functionWithGetSetter,
+		"Concat": functionWithGetter,
+	}
+	p, _ := NewParser[interface{}](
+		functions,
+		testParsePath,
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[interface{}](testParseEnum),
+		WithPathNames[interface{}]([]string{"name", "attributes"}),
+	)
+
+	tests := []struct {
+		name       string
+		statement  string
+		column     int
+		token      string
+		suggestion string
+		message    string
+	}{
+		{
+			name:      "syntax error",
+			statement: `set(name, "bear" where name == "x"`,
+			column:    18,
+			token:     "where",
+			message:   `unable to parse OTTL statement "set(name, \"bear\" where name == \"x\"": column 18 near "where": unexpected token "where" (expected ")")`,
+		},
+		{
+			name:       "misspelled editor",
+			statement:  `sett(name)`,
+			column:     1,
+			token:      "sett",
+			suggestion: "set",
+			message:    `unable to parse OTTL statement "sett(name)": column 1 near "sett": undefined function sett, did you mean "set"?`,
+		},
+		{
+			name:       "misspelled converter",
+			statement:  `set(name) where Concatt(name) == "x"`,
+			column:     17,
+			token:      "Concatt",
+			suggestion: "Concat",
+			message:    `unable to parse OTTL statement "set(name) where Concatt(name) == \"x\"": column 17 near "Concatt": undefined function Concatt, did you mean "Concat"?`,
+		},
+		{
+			name:      "unknown function",
+			statement: `xyz(name)`,
+			column:    1,
+			token:     "xyz",
+			message:   `unable to parse OTTL statement "xyz(name)": column 1 near "xyz": undefined function xyz`,
+		},
+		{
+			name:       "misspelled path",
+			statement:  `set(nme)`,
+			column:     5,
+			token:      "nme",
+			suggestion: "name",
+		},
+		{
+			name:      "lowercase converter",
+			statement: `set(name) where concat(name) == "x"`,
+			column:    17,
+			token:     "concat",
+			message:   `unable to parse OTTL statement "set(name) where concat(name) == \"x\"": column 17 near "concat": converter names must start with an uppercase letter but got 'concat'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatement(tt.statement)
+			require.Error(t, err)
+
+			var statementErr *StatementError
+			require.True(t, errors.As(err, &statementErr))
+			assert.Equal(t, tt.statement, statementErr.Statement)
+			assert.Equal(t, tt.column, statementErr.Column)
+			assert.Equal(t, tt.token, statementErr.Token)
+			assert.Equal(t, tt.suggestion, statementErr.Suggestion)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, err.Error())
+			}
+		})
+	}
+}
+
+func Test_StatementError_Error(t *testing.T) {
+	err := &StatementError{
+		Statement: "drop()",
+		Err:       errors.New("something went wrong"),
+	}
+	assert.Equal(t, `unable to parse OTTL statement "drop()": something went wrong`, err.Error())
+	assert.Equal(t, "something went wrong", errors.Unwrap(err).Error())
+}
+
+func Test_indexToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		token     string
+		expected  int
+	}{
+		{
+			name:      "first token",
+			statement: "set(name)",
+			token:     "set",
+			expected:  0,
+		},
+		{
+			name:      "skips longer identifiers",
+			statement: "set(attributes, name_old, name)",
+			token:     "name",
+			expected:  26,
+		},
+		{
+			name:      "dotted path",
+			statement: "set(resource.attributes)",
+			token:     "resource.attributes",
+			expected:  4,
+		},
+		{
+			name:      "missing",
+			statement: "set(name)",
+			token:     "attributes",
+			expected:  -1,
+		},
+		{
+			name:      "empty",
+			statement: "set(name)",
+			token:     "",
+			expected:  -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, indexToken(tt.statement, tt.token))
+		})
+	}
+}
+
+func Test_closest(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		expected   string
+	}{
+		{
+			name:       "typo",
+			input:      "atributes",
+			candidates: []string{"name", "attributes", "dropped_attributes_count"},
+			expected:   "attributes",
+		},
+		{
+			name:       "too far",
+			input:      "foo",
+			candidates: []string{"name", "attributes"},
+			expected:   "",
+		},
+		{
+			name:       "ties resolved alphabetically",
+			input:      "nam",
+			candidates: []string{"namf", "name"},
+			expected:   "name",
+		},
+		{
+			name:     "no candidates",
+			input:    "name",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, closest(tt.input, tt.candidates))
+		})
+	}
+}
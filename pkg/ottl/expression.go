@@ -17,6 +17,8 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 type ExprFunc[K any] func(ctx context.Context, tCtx K) (interface{}, error)
@@ -65,10 +67,85 @@ func (l literal[K]) Get(context.Context, K) (interface{}, error) {
 
 type exprGetter[K any] struct {
 	expr Expr[K]
+	keys []Key
 }
 
 func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
-	return g.expr.Eval(ctx, tCtx)
+	result, err := g.expr.Eval(ctx, tCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range g.keys {
+		if result == nil {
+			return nil, nil
+		}
+		result, err = indexValue(result, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// indexValue returns the element of val found at key. Maps are indexed by string keys and slices by int keys.
+// Missing map keys result in nil.
+func indexValue(val interface{}, key Key) (interface{}, error) {
+	switch v := val.(type) {
+	case pcommon.Map:
+		if key.String == nil {
+			return nil, fmt.Errorf("map must be indexed by a string but got %d", *key.Int)
+		}
+		elem, ok := v.Get(*key.String)
+		if !ok {
+			return nil, nil
+		}
+		return pcommonValue(elem), nil
+	case map[string]interface{}:
+		if key.String == nil {
+			return nil, fmt.Errorf("map must be indexed by a string but got %d", *key.Int)
+		}
+		return v[*key.String], nil
+	case pcommon.Slice:
+		if key.Int == nil {
+			return nil, fmt.Errorf("slice must be indexed by an int but got %q", *key.String)
+		}
+		idx := int(*key.Int)
+		if idx < 0 || idx >= v.Len() {
+			return nil, fmt.Errorf("index %d out of bounds for a slice of length %d", idx, v.Len())
+		}
+		return pcommonValue(v.At(idx)), nil
+	case []any:
+		if key.Int == nil {
+			return nil, fmt.Errorf("slice must be indexed by an int but got %q", *key.String)
+		}
+		idx := int(*key.Int)
+		if idx < 0 || idx >= len(v) {
+			return nil, fmt.Errorf("index %d out of bounds for a slice of length %d", idx, len(v))
+		}
+		return v[idx], nil
+	}
+	return nil, fmt.Errorf("type %T cannot be indexed", val)
+}
+
+// pcommonValue converts val to the type paths return for it.
+func pcommonValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeStr:
+		return val.Str()
+	case pcommon.ValueTypeBool:
+		return val.Bool()
+	case pcommon.ValueTypeInt:
+		return val.Int()
+	case pcommon.ValueTypeDouble:
+		return val.Double()
+	case pcommon.ValueTypeMap:
+		return val.Map()
+	case pcommon.ValueTypeSlice:
+		return val.Slice()
+	case pcommon.ValueTypeBytes:
+		return val.Bytes().AsRaw()
+	}
+	return nil
 }
 
 type listGetter[K any] struct {
@@ -97,6 +174,74 @@ type IntGetter[K any] interface {
 	Get(ctx context.Context, tCtx K) (int64, error)
 }
 
+type mapGetter[K any] struct {
+	keys   []string
+	values []Getter[K]
+}
+
+func (m *mapGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
+	result := pcommon.NewMap()
+	for i, key := range m.keys {
+		val, err := m.values[i].Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err = setPcommonValue(result.PutEmpty(key), val); err != nil {
+			return nil, fmt.Errorf("invalid value for map key %q: %w", key, err)
+		}
+	}
+	return result, nil
+}
+
+// setPcommonValue sets val, as returned by a Getter, on dst.
+func setPcommonValue(dst pcommon.Value, val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+	case string:
+		dst.SetStr(v)
+	case bool:
+		dst.SetBool(v)
+	case int64:
+		dst.SetInt(v)
+	case float64:
+		dst.SetDouble(v)
+	case []byte:
+		dst.SetEmptyBytes().FromRaw(v)
+	case pcommon.Map:
+		v.CopyTo(dst.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(dst.SetEmptySlice())
+	case map[string]interface{}:
+		return dst.SetEmptyMap().FromRaw(v)
+	case []any:
+		return setPcommonSlice(dst, v)
+	case []string:
+		return setPcommonSlice(dst, v)
+	case []bool:
+		return setPcommonSlice(dst, v)
+	case []int64:
+		return setPcommonSlice(dst, v)
+	case []float64:
+		return setPcommonSlice(dst, v)
+	case [][]byte:
+		return setPcommonSlice(dst, v)
+	default:
+		return fmt.Errorf("unsupported type %T", val)
+	}
+	return nil
+}
+
+func setPcommonSlice[T any](dst pcommon.Value, vals []T) error {
+	slice := dst.SetEmptySlice()
+	slice.EnsureCapacity(len(vals))
+	for _, val := range vals {
+		if err := setPcommonValue(slice.AppendEmpty(), val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type StandardTypeGetter[K any, T any] struct {
 	Getter func(ctx context.Context, tCtx K) (interface{}, error)
 }
@@ -148,7 +293,7 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
-			return p.pathParser(eL.Path)
+			return p.parsePath(eL.Path)
 		}
 		if eL.Converter != nil {
 			call, err := p.newFunctionCall(invocation{
@@ -160,6 +305,7 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			}
 			return &exprGetter[K]{
 				expr: call,
+				keys: eL.Converter.Keys,
 			}, nil
 		}
 	}
@@ -176,6 +322,22 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return &lg, nil
 	}
 
+	if val.Map != nil {
+		mg := mapGetter[K]{
+			keys:   make([]string, len(val.Map.Values)),
+			values: make([]Getter[K], len(val.Map.Values)),
+		}
+		for i, item := range val.Map.Values {
+			getter, err := p.newGetter(*item.Value)
+			if err != nil {
+				return nil, err
+			}
+			mg.keys[i] = *item.Key
+			mg.values[i] = getter
+		}
+		return &mg, nil
+	}
+
 	if val.MathExpression == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
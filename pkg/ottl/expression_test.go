@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -286,6 +287,57 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: []any{"test0", int64(1)},
 		},
+		{
+			name: "map",
+			val: value{
+				Map: &mapValue{
+					Values: []mapItem{
+						{
+							Key: ottltest.Strp("str"),
+							Value: &value{
+								String: ottltest.Strp("test0"),
+							},
+						},
+						{
+							Key: ottltest.Strp("list"),
+							Value: &value{
+								List: &list{
+									Values: []value{
+										{
+											Literal: &mathExprLiteral{
+												Int: ottltest.Intp(1),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("map"),
+							Value: &value{
+								Map: &mapValue{
+									Values: []mapItem{
+										{
+											Key: ottltest.Strp("bool"),
+											Value: &value{
+												Bool: booleanp(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("str", "test0")
+				m.PutEmptySlice("list").AppendEmpty().SetInt(1)
+				m.PutEmptyMap("map").PutBool("bool", true)
+				return m
+			}(),
+		},
 	}
 
 	functions := map[string]interface{}{"Hello": hello[interface{}]}
@@ -319,6 +371,113 @@ func Test_newGetter(t *testing.T) {
 	})
 }
 
+func Test_exprGetter_keys(t *testing.T) {
+	m := pcommon.NewMap()
+	user := m.PutEmptyMap("user")
+	user.PutStr("name", "alice")
+	roles := user.PutEmptySlice("roles")
+	roles.AppendEmpty().SetStr("admin")
+	roles.AppendEmpty().SetStr("dev")
+
+	tests := []struct {
+		name string
+		val  interface{}
+		keys []Key
+		want interface{}
+	}{
+		{
+			name: "no keys",
+			val:  "str",
+			want: "str",
+		},
+		{
+			name: "nested map",
+			val:  m,
+			keys: []Key{{String: ottltest.Strp("user")}, {String: ottltest.Strp("name")}},
+			want: "alice",
+		},
+		{
+			name: "slice in map",
+			val:  m,
+			keys: []Key{{String: ottltest.Strp("user")}, {String: ottltest.Strp("roles")}, {Int: ottltest.Intp(1)}},
+			want: "dev",
+		},
+		{
+			name: "missing key",
+			val:  m,
+			keys: []Key{{String: ottltest.Strp("group")}, {String: ottltest.Strp("name")}},
+			want: nil,
+		},
+		{
+			name: "go map",
+			val:  map[string]interface{}{"key": []any{"a", int64(1)}},
+			keys: []Key{{String: ottltest.Strp("key")}, {Int: ottltest.Intp(1)}},
+			want: int64(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := exprGetter[any]{
+				expr: Expr[any]{
+					exprFunc: func(ctx context.Context, tCtx any) (interface{}, error) {
+						return tt.val, nil
+					},
+				},
+				keys: tt.keys,
+			}
+			val, err := getter.Get(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
+
+func Test_exprGetter_keys_error(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutEmptySlice("slice").AppendEmpty().SetStr("a")
+
+	tests := []struct {
+		name string
+		val  interface{}
+		keys []Key
+	}{
+		{
+			name: "map indexed by int",
+			val:  m,
+			keys: []Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "slice indexed by string",
+			val:  m,
+			keys: []Key{{String: ottltest.Strp("slice")}, {String: ottltest.Strp("a")}},
+		},
+		{
+			name: "index out of bounds",
+			val:  m,
+			keys: []Key{{String: ottltest.Strp("slice")}, {Int: ottltest.Intp(1)}},
+		},
+		{
+			name: "not indexable",
+			val:  "str",
+			keys: []Key{{String: ottltest.Strp("a")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := exprGetter[any]{
+				expr: Expr[any]{
+					exprFunc: func(ctx context.Context, tCtx any) (interface{}, error) {
+						return tt.val, nil
+					},
+				},
+				keys: tt.keys,
+			}
+			_, err := getter.Get(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func Test_StandardTypeGetter(t *testing.T) {
 	tests := []struct {
 		name             string
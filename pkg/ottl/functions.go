@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unicode"
 )
 
 type PathExpressionParser[K any] func(*Path) (GetSetter[K], error)
@@ -30,7 +31,11 @@ type Enum int64
 func (p *Parser[K]) newFunctionCall(inv invocation) (Expr[K], error) {
 	f, ok := p.functions[inv.Function]
 	if !ok {
-		return Expr[K]{}, fmt.Errorf("undefined function %v", inv.Function)
+		return Expr[K]{}, &tokenError{
+			token:      inv.Function,
+			suggestion: p.closestFunction(inv.Function),
+			err:        fmt.Errorf("undefined function %v", inv.Function),
+		}
 	}
 	args, err := p.buildArgs(inv, reflect.TypeOf(f))
 	if err != nil {
@@ -48,6 +53,41 @@ func (p *Parser[K]) newFunctionCall(inv invocation) (Expr[K], error) {
 	return Expr[K]{exprFunc: returnVals[0].Interface().(ExprFunc[K])}, err
 }
 
+// closestFunction returns the name of the function nearest to name, only considering
+// functions of the same kind, i.e. Editors for lowercase names and Converters for uppercase ones.
+func (p *Parser[K]) closestFunction(name string) string {
+	var candidates []string
+	for candidate := range p.functions {
+		if name != "" && candidate != "" && unicode.IsUpper(rune(candidate[0])) == unicode.IsUpper(rune(name[0])) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return closest(name, candidates)
+}
+
+// parsePath wraps the context's PathExpressionParser so that its errors point to the path.
+func (p *Parser[K]) parsePath(path *Path) (GetSetter[K], error) {
+	getSetter, err := p.pathParser(path)
+	if err != nil {
+		names := make([]string, len(path.Fields))
+		for i, field := range path.Fields {
+			names[i] = field.Name
+		}
+		name := strings.Join(names, ".")
+		suggestion := closest(name, p.pathNames)
+		if suggestion == name {
+			// The path exists, so the error comes from how it is used rather than a typo.
+			suggestion = ""
+		}
+		return nil, &tokenError{
+			token:      name,
+			suggestion: suggestion,
+			err:        err,
+		}
+	}
+	return getSetter, nil
+}
+
 func (p *Parser[K]) buildArgs(inv invocation, fType reflect.Type) ([]reflect.Value, error) {
 	var args []reflect.Value
 	// Some function arguments may be intended to take values from the calling processor
@@ -140,7 +180,7 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 		if argVal.Literal == nil || argVal.Literal.Path == nil {
 			return nil, fmt.Errorf("must be a Path")
 		}
-		arg, err := p.pathParser(argVal.Literal.Path)
+		arg, err := p.parsePath(argVal.Literal.Path)
 		if err != nil {
 			return nil, err
 		}
@@ -17,6 +17,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/alecthomas/participle/v2/lexer"
 )
@@ -31,7 +32,10 @@ type parsedStatement struct {
 
 func (p *parsedStatement) checkForCustomError() error {
 	if p.Converter != nil {
-		return fmt.Errorf("invocation names must start with a lowercase letter but got '%v'", p.Converter.Function)
+		return &tokenError{
+			token: p.Converter.Function,
+			err:   fmt.Errorf("invocation names must start with a lowercase letter but got '%v'", p.Converter.Function),
+		}
 	}
 	err := p.Invocation.checkForCustomError()
 	if err != nil {
@@ -135,21 +139,25 @@ const (
 	LTE
 	GTE
 	GT
+	IN
+	NOTIN
 )
 
 // a fast way to get from a string to a compareOp
 var compareOpTable = map[string]compareOp{
-	"==": EQ,
-	"!=": NE,
-	"<":  LT,
-	"<=": LTE,
-	">":  GT,
-	">=": GTE,
+	"==":     EQ,
+	"!=":     NE,
+	"<":      LT,
+	"<=":     LTE,
+	">":      GT,
+	">=":     GTE,
+	"in":     IN,
+	"not in": NOTIN,
 }
 
 // Capture is how the parser converts an operator string to a compareOp.
 func (c *compareOp) Capture(values []string) error {
-	op, ok := compareOpTable[values[0]]
+	op, ok := compareOpTable[strings.Join(strings.Fields(values[0]), " ")]
 	if !ok {
 		return fmt.Errorf("'%s' is not a valid operator", values[0])
 	}
@@ -172,6 +180,10 @@ func (c *compareOp) String() string {
 		return "GTE"
 	case GT:
 		return "GT"
+	case IN:
+		return "IN"
+	case NOTIN:
+		return "NOTIN"
 	default:
 		return "UNKNOWN OP!"
 	}
@@ -210,10 +222,11 @@ func (i *invocation) checkForCustomError() error {
 	return nil
 }
 
-// converter represents a converter function call.
+// converter represents a converter function call. Its result can be indexed with keys.
 type converter struct {
 	Function  string  `parser:"@(Uppercase(Uppercase | Lowercase)*)"`
 	Arguments []value `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
+	Keys      []Key   `parser:"( @@ )*"`
 }
 
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
@@ -226,7 +239,8 @@ type value struct {
 	String         *string          `parser:"| @String"`
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase"`
-	List           *list            `parser:"| @@)"`
+	List           *list            `parser:"| @@"`
+	Map            *mapValue        `parser:"| @@)"`
 }
 
 func (v *value) checkForCustomError() error {
@@ -244,16 +258,32 @@ type Path struct {
 	Fields []Field `parser:"@@ ( '.' @@ )*"`
 }
 
-// Field is an item within a Path.
+// Field is an item within a Path, optionally followed by the keys indexing its value.
 type Field struct {
-	Name   string  `parser:"@Lowercase"`
-	MapKey *string `parser:"( '[' @String ']' )?"`
+	Name string `parser:"@Lowercase"`
+	Keys []Key  `parser:"( @@ )*"`
+}
+
+// Key indexes a map by a string, or a slice by an int.
+type Key struct {
+	String *string `parser:"'[' (@String "`
+	Int    *int64  `parser:"| @Int) ']'"`
 }
 
 type list struct {
 	Values []value `parser:"'[' (@@)* (',' @@)* ']'"`
 }
 
+// mapValue represents a map literal. Its keys are strings and its values can be any value.
+type mapValue struct {
+	Values []mapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+type mapItem struct {
+	Key   *string `parser:"@String ':'"`
+	Value *value  `parser:"@@"`
+}
+
 // byteSlice type for capturing byte slices
 type byteSlice []byte
 
@@ -294,7 +324,10 @@ type mathExprLiteral struct {
 
 func (m *mathExprLiteral) checkForCustomError() error {
 	if m.Invocation != nil {
-		return fmt.Errorf("converter names must start with an uppercase letter but got '%v'", m.Invocation.Function)
+		return &tokenError{
+			token: m.Invocation.Function,
+			err:   fmt.Errorf("converter names must start with an uppercase letter but got '%v'", m.Invocation.Function),
+		}
 	}
 	return nil
 }
@@ -418,16 +451,16 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
 		{Name: `Int`, Pattern: `[-+]?\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
+		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<|\b(not\s+in|in)\b`},
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
-		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:\[\]{}]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
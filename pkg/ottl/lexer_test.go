@@ -89,7 +89,31 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"parse_in", `name in ["a"]`, false, []result{
+			{"Lowercase", "name"},
+			{"OpComparison", "in"},
+			{"Punct", "["},
+			{"String", `"a"`},
+			{"Punct", "]"},
+		}},
+		{"parse_not_in", "name not in list", false, []result{
+			{"Lowercase", "name"},
+			{"OpComparison", "not in"},
+			{"Lowercase", "list"},
+		}},
+		{"name_containing_in", "not index info", false, []result{
+			{"OpNot", "not"},
+			{"Lowercase", "index"}, // should not parse "not in" or "in" as an operator
+			{"Lowercase", "info"},
+		}},
+		{"map_literal", `{"a": 1}`, false, []result{
+			{"Punct", "{"},
+			{"String", `"a"`},
+			{"Punct", ":"},
+			{"Int", "1"},
+			{"Punct", "}"},
+		}},
+		{"nothing_recognizable", "|", true, []result{
 			{"", ""},
 		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
@@ -17,6 +17,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 func (p *Parser[K]) evaluateMathExpression(expr *mathExpression) (Getter[K], error) {
@@ -109,8 +110,12 @@ func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter
 					default:
 						return nil, fmt.Errorf("%v must be int64 or float64", y)
 					}
+				case time.Time:
+					return performTimeOp(newX, y, op)
+				case time.Duration:
+					return performDurationOp(newX, y, op)
 				default:
-					return nil, fmt.Errorf("%v must be int64 or float64", x)
+					return nil, fmt.Errorf("%v must be int64, float64, time.Time or time.Duration", x)
 				}
 			},
 		},
@@ -133,3 +138,46 @@ func performOp[N int64 | float64](x N, y N, op mathOp) (N, error) {
 	}
 	return 0, fmt.Errorf("invalid operation %v", op)
 }
+
+// performTimeOp supports subtracting two times, which results in a duration,
+// and adding or subtracting a duration to a time.
+func performTimeOp(x time.Time, y interface{}, op mathOp) (interface{}, error) {
+	switch newY := y.(type) {
+	case time.Time:
+		if op != SUB {
+			return nil, fmt.Errorf("only subtraction is supported between times")
+		}
+		return x.Sub(newY), nil
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x.Add(newY), nil
+		case SUB:
+			return x.Add(-newY), nil
+		}
+		return nil, fmt.Errorf("only addition and subtraction are supported between a time and a duration")
+	default:
+		return nil, fmt.Errorf("%v must be time.Time or time.Duration", y)
+	}
+}
+
+// performDurationOp supports adding or subtracting two durations, and adding a duration to a time.
+func performDurationOp(x time.Duration, y interface{}, op mathOp) (interface{}, error) {
+	switch newY := y.(type) {
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x + newY, nil
+		case SUB:
+			return x - newY, nil
+		}
+		return nil, fmt.Errorf("only addition and subtraction are supported between durations")
+	case time.Time:
+		if op != ADD {
+			return nil, fmt.Errorf("only addition is supported between a duration and a time")
+		}
+		return newY.Add(x), nil
+	default:
+		return nil, fmt.Errorf("%v must be time.Time or time.Duration", y)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -67,6 +68,35 @@ func threePointOne[K any]() (ExprFunc[K], error) {
 	}, nil
 }
 
+var (
+	startTime = time.Date(2023, 3, 30, 12, 0, 0, 0, time.UTC)
+	endTime   = time.Date(2023, 3, 30, 12, 0, 5, 0, time.UTC)
+)
+
+func start[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return startTime, nil
+	}, nil
+}
+
+func end[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return endTime, nil
+	}, nil
+}
+
+func fiveSeconds[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return 5 * time.Second, nil
+	}, nil
+}
+
+func oneMinute[K any]() (ExprFunc[K], error) {
+	return func(context.Context, K) (interface{}, error) {
+		return time.Minute, nil
+	}, nil
+}
+
 //nolint:unparam
 func sum[K any](ints []int64) (ExprFunc[K], error) {
 	return func(context.Context, K) (interface{}, error) {
@@ -204,6 +234,41 @@ func Test_evaluateMathExpression(t *testing.T) {
 			input:    "4 / 2.0",
 			expected: 2.0,
 		},
+		{
+			name:     "time minus time",
+			input:    "End() - Start()",
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "time plus duration",
+			input:    "Start() + FiveSeconds()",
+			expected: endTime,
+		},
+		{
+			name:     "time minus duration",
+			input:    "End() - FiveSeconds()",
+			expected: startTime,
+		},
+		{
+			name:     "duration plus time",
+			input:    "FiveSeconds() + Start()",
+			expected: endTime,
+		},
+		{
+			name:     "duration plus duration",
+			input:    "FiveSeconds() + OneMinute()",
+			expected: 65 * time.Second,
+		},
+		{
+			name:     "duration minus duration",
+			input:    "OneMinute() - FiveSeconds()",
+			expected: 55 * time.Second,
+		},
+		{
+			name:     "durations and times",
+			input:    "Start() + (OneMinute() - (End() - Start()))",
+			expected: startTime.Add(55 * time.Second),
+		},
 	}
 
 	functions := map[string]interface{}{
@@ -211,6 +276,10 @@ func Test_evaluateMathExpression(t *testing.T) {
 		"Two":           two[any],
 		"ThreePointOne": threePointOne[any],
 		"Sum":           sum[any],
+		"Start":         start[any],
+		"End":           end[any],
+		"FiveSeconds":   fiveSeconds[any],
+		"OneMinute":     oneMinute[any],
 	}
 
 	p, _ := NewParser[any](
@@ -247,6 +316,30 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 			name:  "divide by 0 is gracefully handled",
 			input: "1 / 0",
 		},
+		{
+			name:  "time plus time",
+			input: "Start() + End()",
+		},
+		{
+			name:  "time multiplied by duration",
+			input: "Start() * FiveSeconds()",
+		},
+		{
+			name:  "duration minus time",
+			input: "FiveSeconds() - Start()",
+		},
+		{
+			name:  "time plus int",
+			input: "Start() + 1",
+		},
+		{
+			name:  "int plus duration",
+			input: "1 + FiveSeconds()",
+		},
+		{
+			name:  "duration divided by duration",
+			input: "OneMinute() / FiveSeconds()",
+		},
 	}
 
 	functions := map[string]interface{}{
@@ -254,6 +347,10 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 		"two":           two[any],
 		"threePointOne": threePointOne[any],
 		"sum":           sum[any],
+		"Start":         start[any],
+		"End":           end[any],
+		"FiveSeconds":   fiveSeconds[any],
+		"OneMinute":     oneMinute[any],
 	}
 
 	p, _ := NewParser[any](
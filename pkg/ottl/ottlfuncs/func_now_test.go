@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Now(t *testing.T) {
+	exprFunc, err := Now[interface{}]()
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	after := time.Now()
+
+	now, ok := result.(time.Time)
+	require.True(t, ok)
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(after))
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		format   string
+		expected time.Time
+	}{
+		{
+			name:     "date and time",
+			value:    "2023-03-30 12:34:56",
+			format:   "%Y-%m-%d %H:%M:%S",
+			expected: time.Date(2023, 3, 30, 12, 34, 56, 0, time.UTC),
+		},
+		{
+			name:     "milliseconds and offset",
+			value:    "2019-11-27T09:34:32.901-1000",
+			format:   "%Y-%m-%dT%H:%M:%S.%L%z",
+			expected: time.Date(2019, 11, 27, 19, 34, 32, 901*1000*1000, time.UTC),
+		},
+		{
+			name:     "month name",
+			value:    "05/Aug/2019:20:38:46 +0000",
+			format:   "%d/%b/%Y:%H:%M:%S %z",
+			expected: time.Date(2019, 8, 5, 20, 38, 46, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.format)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result.(time.Time)), "expected %v, got %v", tt.expected, result)
+		})
+	}
+}
+
+func Test_Time_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		format string
+	}{
+		{
+			name:   "not a string",
+			value:  int64(1),
+			format: "%Y",
+		},
+		{
+			name:   "value does not match the format",
+			value:  "yesterday",
+			format: "%Y-%m-%d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(context.Context, interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.format)
+			require.NoError(t, err)
+			_, err = exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_Time_invalid_format(t *testing.T) {
+	_, err := Time[interface{}](&ottl.StandardGetSetter[interface{}]{}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+// StandardConverters returns the Converters available to any component parsing OTTL, keyed by
+// their name in statements. Components add their own Editors and functions to the returned map.
+func StandardConverters[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID":      TraceID[K],
+		"SpanID":       SpanID[K],
+		"IsMatch":      IsMatch[K],
+		"Concat":       Concat[K],
+		"Split":        Split[K],
+		"Int":          Int[K],
+		"Double":       Double[K],
+		"String":       String[K],
+		"SHA1":         SHA1[K],
+		"SHA256":       SHA256[K],
+		"FNV":          FNV[K],
+		"Base64Encode": Base64Encode[K],
+		"Base64Decode": Base64Decode[K],
+		"Len":          Len[K],
+		"UUID":         UUID[K],
+		"IsString":     IsString[K],
+		"IsInt":        IsInt[K],
+		"IsDouble":     IsDouble[K],
+		"IsBool":       IsBool[K],
+		"IsMap":        IsMap[K],
+		"Time":         Time[K],
+		"FormatTime":   FormatTime[K],
+		"Now":          Now[K],
+		"Duration":     Duration[K],
+		"UnixSeconds":  UnixSeconds[K],
+		"UnixNano":     UnixNano[K],
+		"Hour":         Hour[K],
+		"Weekday":      Weekday[K],
+		"ConvertCase":  ConvertCase[K],
+		"ParseJSON":    ParseJSON[K],
+		"Substring":    Substring[K],
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_StandardConverters(t *testing.T) {
+	exprFuncType := reflect.TypeOf(ottl.ExprFunc[interface{}](nil))
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	for name, factory := range StandardConverters[interface{}]() {
+		t.Run(name, func(t *testing.T) {
+			factoryType := reflect.TypeOf(factory)
+			assert.Equal(t, reflect.Func, factoryType.Kind())
+			assert.Equal(t, 2, factoryType.NumOut())
+			assert.Equal(t, exprFuncType, factoryType.Out(0))
+			assert.Equal(t, errorType, factoryType.Out(1))
+		})
+	}
+}
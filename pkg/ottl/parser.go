@@ -46,6 +46,7 @@ type Parser[K any] struct {
 	functions         map[string]interface{}
 	pathParser        PathExpressionParser[K]
 	enumParser        EnumParser
+	pathNames         []string
 	telemetrySettings component.TelemetrySettings
 }
 
@@ -107,6 +108,14 @@ func WithEnumParser[K any](parser EnumParser) Option[K] {
 	}
 }
 
+// WithPathNames sets the names of the paths supported by the context, which are
+// used to suggest a valid path when a statement uses an unknown one.
+func WithPathNames[K any](names []string) Option[K] {
+	return func(p *Parser[K]) {
+		p.pathNames = names
+	}
+}
+
 func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error) {
 	var parsedStatements []*Statement[K]
 	for _, statement := range statements {
@@ -119,18 +128,20 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 	return parsedStatements, nil
 }
 
+// ParseStatement parses a single statement. Any error is returned as a *StatementError
+// pointing to the part of the statement that caused it.
 func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	parsed, err := parseStatement(statement)
 	if err != nil {
-		return nil, err
+		return nil, newStatementError(statement, err)
 	}
 	function, err := p.newFunctionCall(parsed.Invocation)
 	if err != nil {
-		return nil, err
+		return nil, newStatementError(statement, err)
 	}
 	expression, err := p.newBoolExpr(parsed.WhereClause)
 	if err != nil {
-		return nil, err
+		return nil, newStatementError(statement, err)
 	}
 	return &Statement[K]{
 		function:  function,
@@ -145,7 +156,7 @@ func parseStatement(raw string) (*parsedStatement, error) {
 	parsed, err := parser.ParseString("", raw)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse OTTL statement: %w", err)
+		return nil, err
 	}
 	err = parsed.checkForCustomError()
 	if err != nil {
@@ -140,8 +140,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -158,6 +158,111 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "nested keys",
+			statement: `set(attributes["http"]["request"][0], ParseJSON(body)["user"][1])`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+											Keys: []Key{
+												{String: ottltest.Strp("http")},
+												{String: ottltest.Strp("request")},
+												{Int: ottltest.Intp(0)},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Literal: &mathExprLiteral{
+								Converter: &converter{
+									Function: "ParseJSON",
+									Arguments: []value{
+										{
+											Literal: &mathExprLiteral{
+												Path: &Path{
+													Fields: []Field{
+														{
+															Name: "body",
+														},
+													},
+												},
+											},
+										},
+									},
+									Keys: []Key{
+										{String: ottltest.Strp("user")},
+										{Int: ottltest.Intp(1)},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "map literal",
+			statement: `set(attributes["x"], {"a": 1, "b": {"c": "d"}})`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("x")}},
+										},
+									},
+								},
+							},
+						},
+						{
+							Map: &mapValue{
+								Values: []mapItem{
+									{
+										Key: ottltest.Strp("a"),
+										Value: &value{
+											Literal: &mathExprLiteral{
+												Int: ottltest.Intp(1),
+											},
+										},
+									},
+									{
+										Key: ottltest.Strp("b"),
+										Value: &value{
+											Map: &mapValue{
+												Values: []mapItem{
+													{
+														Key: ottltest.Strp("c"),
+														Value: &value{
+															String: ottltest.Strp("d"),
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:      "where == clause",
 			statement: `set(foo.attributes["bar"].cat, "dog") where name == "fido"`,
@@ -173,8 +278,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -228,8 +333,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -283,8 +388,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -386,8 +491,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bytes"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bytes")}},
 										},
 									},
 								},
@@ -413,8 +518,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -440,8 +545,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -467,8 +572,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -496,8 +601,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -529,8 +634,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -565,8 +670,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -626,8 +731,8 @@ func Test_parse(t *testing.T) {
 											Path: &Path{
 												Fields: []Field{
 													{
-														Name:   "attributes",
-														MapKey: ottltest.Strp("test"),
+														Name: "attributes",
+														Keys: []Key{{String: ottltest.Strp("test")}},
 													},
 												},
 											},
@@ -653,8 +758,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -1132,6 +1237,41 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			statement: `name not in ["foo", "bar"]`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Comparison: &comparison{
+							Left: value{
+								Literal: &mathExprLiteral{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+							},
+							Op: NOTIN,
+							Right: value{
+								List: &list{
+									Values: []value{
+										{
+											String: ottltest.Strp("foo"),
+										},
+										{
+											String: ottltest.Strp("bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			statement: `not (true or false)`,
 			expected: setNameTest(&booleanExpression{
@@ -1244,6 +1384,16 @@ func Test_parseStatement(t *testing.T) {
 		{`test() where ==`, true},
 		{`test() where == animal`, true},
 		{`test() where attributes["path"] == "/healthcheck"`, false},
+		{`test() where attributes["env"] in ["prod", "staging"]`, false},
+		{`test() where attributes["env"] not in ["prod", "staging"]`, false},
+		{`test() where not attributes["env"] in []`, false},
+		{`test() where in ["prod"]`, true},
+		{`test() where attributes["env"] in`, true},
+		{`set(attributes["x"], {"a": 1, "b": ["c"], "d": {"e": true}})`, false},
+		{`set(attributes["x"], {})`, false},
+		{`set(attributes["x"], {1: "a"})`, true},
+		{`set(attributes["x"], {"a" 1})`, true},
+		{`set(attributes["x"], {"a": 1,})`, true},
 		{`test() where one() == 1`, true},
 		{`test(fail())`, true},
 		{`Test()`, true},
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s', must be one of '%s', '%s' or '%s'",
+			compression, compressionGzip, compressionZstd, compressionAuto)
+	}
+}
+
+// detectCompression returns the compression of a file. When configured as auto,
+// it is detected from the magic number at the start of the file.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != compressionAuto {
+		return compression, nil
+	}
+
+	buf := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic number: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(buf[:n], gzipMagic):
+		return compressionGzip, nil
+	case bytes.HasPrefix(buf[:n], zstdMagic):
+		return compressionZstd, nil
+	default:
+		return compressionNone, nil
+	}
+}
+
+// newDecompressor returns a reader of the decompressed content of a file, from its beginning.
+// It reads the file independently of its current position.
+func newDecompressor(file *os.File, compression string) (io.ReadCloser, error) {
+	src := io.NewSectionReader(file, 0, math.MaxInt64)
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(src)
+	case compressionZstd:
+		decoder, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// isIncomplete returns true if the error is caused by a compressed file that is not fully written yet
+func isIncomplete(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// newDecompressedFingerprint creates a fingerprint from the first bytes of the decompressed content of a file
+func newDecompressedFingerprint(file *os.File, compression string, size int) (*Fingerprint, error) {
+	decompressor, err := newDecompressor(file, compression)
+	if isIncomplete(err) {
+		// The compression header is not written yet, so the file is considered empty
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	defer decompressor.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(decompressor, buf)
+	if err != nil && !isIncomplete(err) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
+
+// decompressedSize returns the size of the decompressed content of a file,
+// or of the part of it that can be decompressed so far.
+func decompressedSize(file *os.File, compression string) (int64, error) {
+	decompressor, err := newDecompressor(file, compression)
+	if isIncomplete(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer decompressor.Close()
+
+	size, err := io.Copy(io.Discard, decompressor)
+	if err != nil && !isIncomplete(err) {
+		return 0, err
+	}
+	return size, nil
+}
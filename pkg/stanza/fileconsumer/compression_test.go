@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func compress(t testing.TB, compression string, content string) []byte {
+	var buf bytes.Buffer
+	switch compression {
+	case compressionGzip:
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case compressionZstd:
+		w, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		buf.WriteString(content)
+	}
+	return buf.Bytes()
+}
+
+func writeCompressed(t testing.TB, path string, compression string, content string) {
+	require.NoError(t, os.WriteFile(path, compress(t, compression, content), 0600))
+}
+
+func TestDetectCompression(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configured  string
+		content     []byte
+		compression string
+	}{
+		{
+			name:        "auto_gzip",
+			configured:  compressionAuto,
+			content:     compress(t, compressionGzip, "testlog\n"),
+			compression: compressionGzip,
+		},
+		{
+			name:        "auto_zstd",
+			configured:  compressionAuto,
+			content:     compress(t, compressionZstd, "testlog\n"),
+			compression: compressionZstd,
+		},
+		{
+			name:        "auto_plain",
+			configured:  compressionAuto,
+			content:     []byte("testlog\n"),
+			compression: compressionNone,
+		},
+		{
+			name:        "auto_empty",
+			configured:  compressionAuto,
+			content:     []byte{},
+			compression: compressionNone,
+		},
+		{
+			name:        "configured",
+			configured:  compressionGzip,
+			content:     []byte("testlog\n"),
+			compression: compressionGzip,
+		},
+		{
+			name:        "none",
+			configured:  compressionNone,
+			content:     compress(t, compressionGzip, "testlog\n"),
+			compression: compressionNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.log")
+			require.NoError(t, os.WriteFile(path, tc.content, 0600))
+			file := openFile(t, path)
+
+			compression, err := detectCompression(file, tc.configured)
+			require.NoError(t, err)
+			require.Equal(t, tc.compression, compression)
+		})
+	}
+}
+
+func TestNewDecompressedFingerprint(t *testing.T) {
+	content := "testlog1\ntestlog2\n"
+	gzipContent := compress(t, compressionGzip, content)
+	zstdContent := compress(t, compressionZstd, content)
+
+	testCases := []struct {
+		name        string
+		compression string
+		content     []byte
+		size        int
+		expected    []byte
+		expectErr   bool
+	}{
+		{
+			name:        "gzip",
+			compression: compressionGzip,
+			content:     gzipContent,
+			size:        DefaultFingerprintSize,
+			expected:    []byte(content),
+		},
+		{
+			name:        "zstd",
+			compression: compressionZstd,
+			content:     zstdContent,
+			size:        DefaultFingerprintSize,
+			expected:    []byte(content),
+		},
+		{
+			name:        "gzip_larger_than_fingerprint",
+			compression: compressionGzip,
+			content:     gzipContent,
+			size:        8,
+			expected:    []byte("testlog1"),
+		},
+		{
+			name:        "gzip_empty",
+			compression: compressionGzip,
+			content:     []byte{},
+			size:        DefaultFingerprintSize,
+			expected:    []byte{},
+		},
+		{
+			name:        "gzip_incomplete_header",
+			compression: compressionGzip,
+			content:     gzipContent[:4],
+			size:        DefaultFingerprintSize,
+			expected:    []byte{},
+		},
+		{
+			name:        "gzip_not_compressed",
+			compression: compressionGzip,
+			content:     []byte(content),
+			size:        DefaultFingerprintSize,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.log")
+			require.NoError(t, os.WriteFile(path, tc.content, 0600))
+			file := openFile(t, path)
+
+			fp, err := newDecompressedFingerprint(file, tc.compression, tc.size)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, fp.FirstBytes)
+		})
+	}
+}
+
+func TestReadCompressedLogs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configured  string
+		compression string
+	}{
+		{"gzip", compressionGzip, compressionGzip},
+		{"zstd", compressionZstd, compressionZstd},
+		{"auto_gzip", compressionAuto, compressionGzip},
+		{"auto_zstd", compressionAuto, compressionZstd},
+		{"auto_plain", compressionAuto, compressionNone},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.configured
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			writeCompressed(t, filepath.Join(tempDir, "test.log"), tc.compression, "testlog1\ntestlog2\n")
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			waitForToken(t, emitCalls, []byte("testlog2"))
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+// TestCompressedRotatedArchive tests that a file compressed on rotation is
+// only read from where the uncompressed file was read up to
+func TestCompressedRotatedArchive(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	logPath := filepath.Join(tempDir, "test.log")
+	writeCompressed(t, logPath, compressionNone, "testlog1\ntestlog2\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// Rotate the file into a compressed archive, with a line written just before the rotation
+	writeCompressed(t, logPath+".1.gz", compressionGzip, "testlog1\ntestlog2\ntestlog3\n")
+	require.NoError(t, os.Remove(logPath))
+
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedRestartOffsets(t *testing.T) {
+	for _, compression := range []string{compressionGzip, compressionZstd} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = compressionAuto
+
+			persister := testutil.NewMockPersister("test")
+			logPath := filepath.Join(tempDir, "test.log")
+			writeCompressed(t, logPath, compression, "testlog1\ntestlog2\n")
+
+			operatorOne, emitCallsOne := buildTestManager(t, cfg)
+			require.NoError(t, operatorOne.Start(persister))
+			waitForToken(t, emitCallsOne, []byte("testlog1"))
+			waitForToken(t, emitCallsOne, []byte("testlog2"))
+			require.NoError(t, operatorOne.Stop())
+
+			// The file is rewritten with an additional line while stopped
+			writeCompressed(t, logPath, compression, "testlog1\ntestlog2\ntestlog3\n")
+
+			operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+			require.NoError(t, operatorTwo.Start(persister))
+			waitForToken(t, emitCallsTwo, []byte("testlog3"))
+			expectNoTokens(t, emitCallsTwo)
+			require.NoError(t, operatorTwo.Stop())
+		})
+	}
+}
+
+func TestCompressedStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	logPath := filepath.Join(tempDir, "test.log.gz")
+	writeCompressed(t, logPath, compressionGzip, "testlog1\n")
+
+	// Expect no entries on the first poll
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	// Expect any entries after the end of the decompressed content on the first poll
+	writeCompressed(t, logPath, compressionGzip, "testlog1\ntestlog2\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
+
+func TestCompressedIncompleteFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	cfg.Splitter.Flusher.Period = 0
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	content := compress(t, compressionGzip, string(tokenWithLength(5000))+"\ntestlog2\n")
+	logPath := filepath.Join(tempDir, "test.log.gz")
+	require.NoError(t, os.WriteFile(logPath, content[:len(content)/2], 0600))
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	// Nothing is emitted until the file is fully written
+	expectNoTokens(t, emitCalls)
+
+	require.NoError(t, os.WriteFile(logPath, content, 0600))
+	waitForNTokens(t, emitCalls, 1)
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
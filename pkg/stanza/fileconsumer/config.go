@@ -77,6 +77,7 @@ type Config struct {
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
 	MaxBatches              int                   `mapstructure:"max_batches,omitempty"`
 	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
 }
@@ -159,6 +160,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				compression:     c.Compression,
 				emit:            emit,
 			},
 			fromBeginning:   startAtBeginning,
@@ -222,6 +224,14 @@ func (c Config) validate() error {
 		return errors.New("`max_batches` must not be negative")
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return fmt.Errorf("invalid config for `compression`: %w", err)
+	}
+
+	if err := c.OrderingCriteria.validate(); err != nil {
+		return fmt.Errorf("invalid config for `ordering_criteria`: %w", err)
+	}
+
 	_, err := c.Splitter.EncodingConfig.Build()
 	if err != nil {
 		return err
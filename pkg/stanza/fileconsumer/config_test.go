@@ -363,6 +363,33 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_gzip",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "gzip"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "ordering_criteria",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.OrderingCriteria = OrderingCriteria{
+						Regex: `app-(?P<date>\d{8})\.log`,
+						TopN:  2,
+						SortBy: []SortRule{
+							{
+								RegexKey: "date",
+								SortType: "timestamp",
+								Layout:   "%Y%m%d",
+								Location: "UTC",
+							},
+						},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -459,6 +486,49 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"Compression",
+			func(f *Config) {
+				f.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "zstd", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"OrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `app-(?P<rotation>\d+)\.log`,
+					TopN:   1,
+					SortBy: []SortRule{{RegexKey: "rotation", SortType: "numeric"}},
+				}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, 1, f.finder.OrderingCriteria.TopN)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `app-(?P<rotation>\d+)\.log`,
+					SortBy: []SortRule{{RegexKey: "rotation", SortType: "size"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
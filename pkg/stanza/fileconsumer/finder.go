@@ -21,6 +21,8 @@ import (
 type Finder struct {
 	Include []string `mapstructure:"include,omitempty"`
 	Exclude []string `mapstructure:"exclude,omitempty"`
+
+	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
 }
 
 // FindFiles gets a list of paths given an array of glob patterns to include and exclude
@@ -46,5 +48,8 @@ func (f Finder) FindFiles() []string {
 		}
 	}
 
+	if f.OrderingCriteria.Regex != "" {
+		return f.OrderingCriteria.apply(all)
+	}
 	return all
 }
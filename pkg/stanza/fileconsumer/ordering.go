@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+)
+
+// OrderingCriteria sorts the matched files by values extracted from their file names,
+// and optionally limits them to the first N
+type OrderingCriteria struct {
+	Regex  string     `mapstructure:"regex,omitempty"`
+	TopN   int        `mapstructure:"top_n,omitempty"`
+	SortBy []SortRule `mapstructure:"sort_by,omitempty"`
+}
+
+// SortRule sorts files by the value of a named capture group of the ordering regex
+type SortRule struct {
+	RegexKey  string `mapstructure:"regex_key,omitempty"`
+	SortType  string `mapstructure:"sort_type,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty"`
+}
+
+// sortRule is a SortRule resolved against the ordering regex
+type sortRule struct {
+	SortRule
+	group    int
+	layout   string
+	location *time.Location
+}
+
+// sortValue is the value of a file name for a sort rule
+type sortValue struct {
+	num int64
+	str string
+}
+
+func (c OrderingCriteria) validate() error {
+	if c.Regex == "" {
+		if c.TopN != 0 || len(c.SortBy) != 0 {
+			return errors.New("`regex` is required")
+		}
+		return nil
+	}
+
+	if c.TopN < 0 {
+		return errors.New("`top_n` must not be negative")
+	}
+
+	if len(c.SortBy) == 0 {
+		return errors.New("`sort_by` is required")
+	}
+
+	_, _, err := c.build()
+	return err
+}
+
+func (c OrderingCriteria) build() (*regexp.Regexp, []sortRule, error) {
+	regex, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compiling regex: %w", err)
+	}
+
+	rules := make([]sortRule, 0, len(c.SortBy))
+	for _, rule := range c.SortBy {
+		group := regex.SubexpIndex(rule.RegexKey)
+		if group < 0 {
+			return nil, nil, fmt.Errorf("`regex_key` '%s' is not a named capture group of the regex", rule.RegexKey)
+		}
+
+		resolved := sortRule{SortRule: rule, group: group}
+		switch rule.SortType {
+		case sortTypeNumeric, sortTypeAlphabetical:
+		case sortTypeTimestamp:
+			if rule.Layout == "" {
+				return nil, nil, fmt.Errorf("`layout` is required to sort by timestamp '%s'", rule.RegexKey)
+			}
+			resolved.layout, err = strptime.ToNative(rule.Layout)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing layout '%s': %w", rule.Layout, err)
+			}
+			resolved.location = time.UTC
+			if rule.Location != "" {
+				resolved.location, err = time.LoadLocation(rule.Location)
+				if err != nil {
+					return nil, nil, fmt.Errorf("loading location '%s': %w", rule.Location, err)
+				}
+			}
+		default:
+			return nil, nil, fmt.Errorf("invalid `sort_type` '%s', must be one of '%s', '%s' or '%s'",
+				rule.SortType, sortTypeNumeric, sortTypeTimestamp, sortTypeAlphabetical)
+		}
+		rules = append(rules, resolved)
+	}
+
+	return regex, rules, nil
+}
+
+// apply sorts the paths by the sort rules, in order of priority, and keeps the first `top_n` of them.
+// Paths whose file name doesn't match the regex, or has a value which can't be parsed, are dropped.
+func (c OrderingCriteria) apply(paths []string) []string {
+	regex, rules, err := c.build()
+	if err != nil {
+		return paths // error checked in build
+	}
+
+	type orderedPath struct {
+		path   string
+		values []sortValue
+	}
+
+	ordered := make([]orderedPath, 0, len(paths))
+PATHS:
+	for _, path := range paths {
+		matches := regex.FindStringSubmatch(filepath.Base(path))
+		if matches == nil {
+			continue
+		}
+
+		values := make([]sortValue, len(rules))
+		for i, rule := range rules {
+			value, err := rule.parse(matches[rule.group])
+			if err != nil {
+				continue PATHS
+			}
+			values[i] = value
+		}
+		ordered = append(ordered, orderedPath{path: path, values: values})
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		for k, rule := range rules {
+			cmp := rule.compare(ordered[i].values[k], ordered[j].values[k])
+			if cmp == 0 {
+				continue
+			}
+			if rule.Ascending {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+		return false
+	})
+
+	if c.TopN > 0 && len(ordered) > c.TopN {
+		ordered = ordered[:c.TopN]
+	}
+
+	result := make([]string, 0, len(ordered))
+	for _, o := range ordered {
+		result = append(result, o.path)
+	}
+	return result
+}
+
+func (r sortRule) parse(value string) (sortValue, error) {
+	switch r.SortType {
+	case sortTypeNumeric:
+		num, err := strconv.ParseInt(value, 10, 64)
+		return sortValue{num: num}, err
+	case sortTypeTimestamp:
+		t, err := time.ParseInLocation(r.layout, value, r.location)
+		return sortValue{num: t.UnixNano()}, err
+	default:
+		return sortValue{str: value}, nil
+	}
+}
+
+func (r sortRule) compare(a, b sortValue) int {
+	if r.SortType == sortTypeAlphabetical {
+		return strings.Compare(a.str, b.str)
+	}
+
+	switch {
+	case a.num < b.num:
+		return -1
+	case a.num > b.num:
+		return 1
+	default:
+		return 0
+	}
+}
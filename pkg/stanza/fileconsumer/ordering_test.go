@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderingCriteriaValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		criteria  OrderingCriteria
+		expectErr string
+	}{
+		{
+			name:     "Empty",
+			criteria: OrderingCriteria{},
+		},
+		{
+			name: "Numeric",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+		},
+		{
+			name: "Timestamp",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{{
+					RegexKey: "date",
+					SortType: sortTypeTimestamp,
+					Layout:   "%Y%m%d",
+					Location: "America/New_York",
+				}},
+			},
+		},
+		{
+			name: "MissingRegex",
+			criteria: OrderingCriteria{
+				TopN: 1,
+			},
+			expectErr: "`regex` is required",
+		},
+		{
+			name: "MissingSortBy",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<rotation>\d+)\.log`,
+			},
+			expectErr: "`sort_by` is required",
+		},
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				TopN:   -1,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			expectErr: "`top_n` must not be negative",
+		},
+		{
+			name: "InvalidRegex",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			expectErr: "compiling regex",
+		},
+		{
+			name: "UnknownRegexKey",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "date", SortType: sortTypeNumeric}},
+			},
+			expectErr: "`regex_key` 'date' is not a named capture group of the regex",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: "size"}},
+			},
+			expectErr: "invalid `sort_type` 'size'",
+		},
+		{
+			name: "MissingLayout",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{{RegexKey: "date", SortType: sortTypeTimestamp}},
+			},
+			expectErr: "`layout` is required to sort by timestamp 'date'",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<date>\d{8})\.log`,
+				SortBy: []SortRule{{
+					RegexKey: "date",
+					SortType: sortTypeTimestamp,
+					Layout:   "%Y%m%d",
+					Location: "Mars/Olympus_Mons",
+				}},
+			},
+			expectErr: "loading location 'Mars/Olympus_Mons'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.criteria.validate()
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestOrderingCriteriaApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		paths    []string
+		expected []string
+	}{
+		{
+			name: "NumericDescending",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"app-1.log", "app-10.log", "app-2.log"},
+			expected: []string{"app-10.log", "app-2.log", "app-1.log"},
+		},
+		{
+			name: "NumericAscending",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric, Ascending: true}},
+			},
+			paths:    []string{"app-1.log", "app-10.log", "app-2.log"},
+			expected: []string{"app-1.log", "app-2.log", "app-10.log"},
+		},
+		{
+			name: "TopN",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"app-1.log", "app-10.log", "app-2.log"},
+			expected: []string{"app-10.log", "app-2.log"},
+		},
+		{
+			name: "TopNLargerThanMatches",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				TopN:   5,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"app-1.log", "app-2.log"},
+			expected: []string{"app-2.log", "app-1.log"},
+		},
+		{
+			name: "Timestamp",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<date>\d{8}T\d{2})\.log`,
+				TopN:  1,
+				SortBy: []SortRule{{
+					RegexKey: "date",
+					SortType: sortTypeTimestamp,
+					Layout:   "%Y%m%dT%H",
+				}},
+			},
+			paths:    []string{"app-20230101T23.log", "app-20230102T01.log", "app-20221231T12.log"},
+			expected: []string{"app-20230102T01.log"},
+		},
+		{
+			name: "Alphabetical",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<name>[a-z]+)\.log`,
+				SortBy: []SortRule{{RegexKey: "name", SortType: sortTypeAlphabetical, Ascending: true}},
+			},
+			paths:    []string{"charlie.log", "alpha.log", "bravo.log"},
+			expected: []string{"alpha.log", "bravo.log", "charlie.log"},
+		},
+		{
+			name: "MultipleRules",
+			criteria: OrderingCriteria{
+				Regex: `(?P<service>[a-z]+)-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{
+					{RegexKey: "service", SortType: sortTypeAlphabetical, Ascending: true},
+					{RegexKey: "rotation", SortType: sortTypeNumeric},
+				},
+			},
+			paths:    []string{"db-1.log", "api-1.log", "db-3.log", "api-2.log"},
+			expected: []string{"api-2.log", "api-1.log", "db-3.log", "db-1.log"},
+		},
+		{
+			name: "TiesKeepFoundOrder",
+			criteria: OrderingCriteria{
+				Regex:  `[a-z]+-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"b-1.log", "a-1.log", "c-2.log"},
+			expected: []string{"c-2.log", "b-1.log", "a-1.log"},
+		},
+		{
+			name: "MatchesFileName",
+			criteria: OrderingCriteria{
+				Regex:  `^app-(?P<rotation>\d+)\.log$`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{filepath.Join("logs-9", "app-1.log"), filepath.Join("logs-1", "app-2.log")},
+			expected: []string{filepath.Join("logs-1", "app-2.log"), filepath.Join("logs-9", "app-1.log")},
+		},
+		{
+			name: "DropsUnmatched",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<rotation>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+			},
+			paths:    []string{"app-1.log", "other.log", "app-2.log"},
+			expected: []string{"app-2.log", "app-1.log"},
+		},
+		{
+			name: "DropsUnparsable",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<date>\d+)\.log`,
+				SortBy: []SortRule{{
+					RegexKey: "date",
+					SortType: sortTypeTimestamp,
+					Layout:   "%Y%m%d",
+				}},
+			},
+			paths:    []string{"app-20230101.log", "app-20231399.log", "app-20230102.log"},
+			expected: []string{"app-20230102.log", "app-20230101.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.criteria.validate())
+			require.Equal(t, tc.expected, tc.criteria.apply(tc.paths))
+		})
+	}
+}
+
+func TestFinderOrderingCriteria(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, f := range []string{"app-1.log", "app-2.log", "app-3.log", "other.log"} {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, f), []byte(f), 0600))
+	}
+
+	finder := Finder{
+		Include: []string{filepath.Join(tempDir, "*.log")},
+		OrderingCriteria: OrderingCriteria{
+			Regex:  `app-(?P<rotation>\d+)\.log`,
+			TopN:   2,
+			SortBy: []SortRule{{RegexKey: "rotation", SortType: sortTypeNumeric}},
+		},
+	}
+	require.Equal(t, absPath(tempDir, []string{"app-3.log", "app-2.log"}), finder.FindFiles())
+}
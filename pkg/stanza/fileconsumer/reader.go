@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -30,6 +31,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
 	emit            EmitFunc
 }
 
@@ -49,6 +51,10 @@ type Reader struct {
 	FileAttributes *FileAttributes
 	eof            bool
 
+	// compression of the file, in which case the offset is in its decompressed content
+	compression  string
+	decompressor io.ReadCloser
+
 	HeaderFinalized bool
 	recreateScanner bool
 
@@ -59,6 +65,15 @@ type Reader struct {
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.compression != compressionNone {
+		size, err := decompressedSize(r.file, r.compression)
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = size
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -67,9 +82,32 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
+// seek positions the file, or its decompressed content, at the offset
+func (r *Reader) seek() error {
+	if r.compression == compressionNone {
+		_, err := r.file.Seek(r.Offset, 0)
+		return err
+	}
+
+	r.closeDecompressor()
+	decompressor, err := newDecompressor(r.file, r.compression)
+	if err != nil {
+		return err
+	}
+	r.decompressor = decompressor
+
+	// The decompressed content can only be read sequentially, so skip it up to the offset
+	_, err = io.CopyN(io.Discard, decompressor, r.Offset)
+	return err
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	if err := r.seek(); err != nil {
+		if r.compression != compressionNone && isIncomplete(err) {
+			r.Debugw("Compressed file is not fully written yet", zap.Error(err))
+			return
+		}
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
@@ -90,7 +128,12 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			if err := scanner.getError(); err != nil {
 				// If Scan returned an error then we are not guaranteed to be at the end of the file
 				r.eof = false
-				r.Errorw("Failed during scan", zap.Error(err))
+				if r.compression != compressionNone && isIncomplete(err) {
+					// The rest is read once the compressed file is fully written
+					r.Debugw("Compressed file is not fully written yet", zap.Error(err))
+				} else {
+					r.Errorw("Failed during scan", zap.Error(err))
+				}
 			}
 			break
 		}
@@ -108,7 +151,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			// We do not use the updated offset from the scanner,
 			// as the log line we just read could be multiline, and would be
 			// split differently with the new splitter.
-			if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			if err := r.seek(); err != nil {
 				r.Errorw("Failed to seek post-header", zap.Error(err))
 				return
 			}
@@ -167,6 +210,7 @@ func (r *Reader) consumeHeaderLine(ctx context.Context, attrs *FileAttributes, t
 
 // Close will close the file
 func (r *Reader) Close() {
+	r.closeDecompressor()
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.Debugw("Problem closing reader", zap.Error(err))
@@ -180,14 +224,31 @@ func (r *Reader) Close() {
 	}
 }
 
+func (r *Reader) closeDecompressor() {
+	if r.decompressor != nil {
+		if err := r.decompressor.Close(); err != nil {
+			r.Debugw("Problem closing decompressor", zap.Error(err))
+		}
+		r.decompressor = nil
+	}
+}
+
+// readFile reads from the decompressed content of the file if it is compressed, or from the file otherwise
+func (r *Reader) readFile(dst []byte) (int, error) {
+	if r.decompressor != nil {
+		return r.decompressor.Read(dst)
+	}
+	return r.file.Read(dst)
+}
+
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.readFile(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.readFile(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
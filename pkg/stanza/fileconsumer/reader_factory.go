@@ -59,6 +59,13 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression, err := detectCompression(file, f.readerConfig.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression != compressionNone {
+		return newDecompressedFingerprint(file, compression, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
@@ -144,6 +151,11 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 			b.Errorf("resolve attributes: %w", err)
 		}
 
+		r.compression, err = detectCompression(b.file, b.readerConfig.compression)
+		if err != nil {
+			return nil, err
+		}
+
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
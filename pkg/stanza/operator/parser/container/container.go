@@ -0,0 +1,388 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "container"
+
+const (
+	formatAuto       = ""
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	criPartialTag = "P"
+
+	filePathAttribute = "log.file.path"
+	streamAttribute   = "log.iostream"
+
+	namespaceResource    = "k8s.namespace.name"
+	podNameResource      = "k8s.pod.name"
+	podUIDResource       = "k8s.pod.uid"
+	containerResource    = "k8s.container.name"
+	restartCountResource = "k8s.container.restart_count"
+)
+
+// podLogPathRegex matches the path of a container log file written by the kubelet, which is
+// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+var podLogPathRegex = regexp.MustCompile(`^.*[\\/](?P<namespace>[^_\\/]+)_(?P<pod_name>[^_\\/]+)_(?P<uid>[a-f0-9\-]+)[\\/](?P<container_name>[^\._\\/]+)[\\/](?P<restart_count>\d+)\.log$`)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		ParseFrom:               entry.NewBodyField(),
+		AddMetadataFromFilePath: true,
+		MaxLogSize:              helper.ByteSize(1024 * 1024),
+		ForceFlushTimeout:       5 * time.Second,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	ParseFrom                entry.Field     `mapstructure:"parse_from"`
+	Format                   string          `mapstructure:"format"`
+	AddMetadataFromFilePath  bool            `mapstructure:"add_metadata_from_filepath"`
+	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	default:
+		return nil, fmt.Errorf("invalid `format` '%s', must be one of '%s', '%s' or '%s'",
+			c.Format, formatDocker, formatCRIO, formatContainerd)
+	}
+
+	if c.MaxLogSize < 0 {
+		return nil, fmt.Errorf("`max_log_size` must not be negative")
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, fmt.Errorf("`force_flush_period` must be positive")
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		parseFrom:               c.ParseFrom,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		maxLogSize:              int(c.MaxLogSize),
+		forceFlushTimeout:       c.ForceFlushTimeout,
+		json:                    jsoniter.ConfigFastest,
+		ticker:                  time.NewTicker(c.ForceFlushTimeout),
+		chClose:                 make(chan struct{}),
+		partials:                make(map[partialKey]*partialLog),
+	}, nil
+}
+
+// Parser is an operator that parses the log lines written by container runtimes.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom               entry.Field
+	format                  string
+	addMetadataFromFilePath bool
+	maxLogSize              int
+	forceFlushTimeout       time.Duration
+	json                    jsoniter.API
+	ticker                  *time.Ticker
+	chClose                 chan struct{}
+
+	sync.Mutex
+	partials map[partialKey]*partialLog
+}
+
+// containerLog is a log line written by a container runtime
+type containerLog struct {
+	timestamp time.Time
+	stream    string
+	log       string
+	partial   bool
+}
+
+// dockerLog is a log line written by the Docker json-file logging driver
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// partialKey identifies the log lines which are split into partial lines together
+type partialKey struct {
+	path   string
+	stream string
+}
+
+// partialLog is a log line whose partial lines are being recombined
+type partialLog struct {
+	base       *entry.Entry
+	log        strings.Builder
+	lastUpdate time.Time
+}
+
+func (p *Parser) Start(_ operator.Persister) error {
+	go p.flushLoop()
+	return nil
+}
+
+func (p *Parser) Stop() error {
+	p.Lock()
+	defer p.Unlock()
+
+	for key := range p.partials {
+		p.flushPartial(context.Background(), key)
+	}
+	close(p.chClose)
+	return nil
+}
+
+// flushLoop flushes the partial lines which haven't been completed within the flush period
+func (p *Parser) flushLoop() {
+	for {
+		select {
+		case <-p.ticker.C:
+			p.Lock()
+			now := time.Now()
+			for key, partial := range p.partials {
+				if now.Sub(partial.lastUpdate) >= p.forceFlushTimeout {
+					p.flushPartial(context.Background(), key)
+				}
+			}
+			p.Unlock()
+		case <-p.chClose:
+			p.ticker.Stop()
+			return
+		}
+	}
+}
+
+// Process will parse an entry as a container log line.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	value, ok := e.Get(p.parseFrom)
+	if !ok {
+		err := errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.parseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	line, err := p.parse(value)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	e.Timestamp = line.timestamp
+	if err := e.Set(entry.NewAttributeField(streamAttribute), line.stream); err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err := addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	p.combine(ctx, e, line)
+	return nil
+}
+
+// parse will parse a value as a container log line.
+func (p *Parser) parse(value interface{}) (containerLog, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return containerLog{}, fmt.Errorf("type %T cannot be parsed as a container log", value)
+	}
+
+	format := p.format
+	if format == formatAuto {
+		format = detectFormat(raw)
+	}
+
+	if format == formatDocker {
+		return p.parseDocker(raw)
+	}
+	return parseCRI(raw)
+}
+
+// detectFormat returns the format of a log line, as Docker writes JSON objects and
+// CRI-O and containerd write the same plain text format
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return formatDocker
+	}
+	return formatCRIO
+}
+
+// parseDocker parses a line such as {"log":"message\n","stream":"stdout","time":"2023-03-20T15:04:05.123456789Z"}.
+// Docker splits long lines into partial lines, of which only the last ends with a newline.
+func (p *Parser) parseDocker(raw string) (containerLog, error) {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	log := strings.TrimSuffix(parsed.Log, "\n")
+	return containerLog{
+		timestamp: timestamp,
+		stream:    parsed.Stream,
+		log:       log,
+		partial:   len(log) == len(parsed.Log),
+	}, nil
+}
+
+// parseCRI parses a line such as "2023-03-20T15:04:05.123456789Z stdout F message", as written by
+// CRI-O and containerd. The tag is P for a partial line, and F for the last line of a log.
+func parseCRI(raw string) (containerLog, error) {
+	parts := strings.SplitN(raw, " ", 4)
+	if len(parts) < 3 {
+		return containerLog{}, fmt.Errorf("parse cri log: expected a timestamp, stream and tag")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return containerLog{}, fmt.Errorf("parse cri log time: %w", err)
+	}
+
+	stream := parts[1]
+	if stream != "stdout" && stream != "stderr" {
+		return containerLog{}, fmt.Errorf("parse cri log: invalid stream '%s'", stream)
+	}
+
+	var log string
+	if len(parts) == 4 {
+		log = parts[3]
+	}
+
+	tags := strings.Split(parts[2], ":")
+	return containerLog{
+		timestamp: timestamp,
+		stream:    stream,
+		log:       log,
+		partial:   tags[0] == criPartialTag,
+	}, nil
+}
+
+// addMetadataFromFilePath sets the Kubernetes resource attributes from the path of the log file
+func addMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(filePathAttribute), &path); err != nil {
+		return fmt.Errorf("read `%s` to add metadata from the file path: %w", filePathAttribute, err)
+	}
+
+	matches := podLogPathRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("file path '%s' is not a Kubernetes pod log path", path)
+	}
+
+	resources := map[string]string{
+		namespaceResource:    matches[podLogPathRegex.SubexpIndex("namespace")],
+		podNameResource:      matches[podLogPathRegex.SubexpIndex("pod_name")],
+		podUIDResource:       matches[podLogPathRegex.SubexpIndex("uid")],
+		containerResource:    matches[podLogPathRegex.SubexpIndex("container_name")],
+		restartCountResource: matches[podLogPathRegex.SubexpIndex("restart_count")],
+	}
+	for key, value := range resources {
+		if err := e.Set(entry.NewResourceField(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// combine writes the entry of a complete line, or adds it to the partial lines of its file and stream
+func (p *Parser) combine(ctx context.Context, e *entry.Entry, line containerLog) {
+	p.Lock()
+	defer p.Unlock()
+
+	var key partialKey
+	_ = e.Read(entry.NewAttributeField(filePathAttribute), &key.path)
+	key.stream = line.stream
+
+	partial, ok := p.partials[key]
+	if !ok {
+		if !line.partial {
+			e.Body = line.log
+			p.Write(ctx, e)
+			return
+		}
+		partial = &partialLog{base: e}
+		p.partials[key] = partial
+	}
+
+	partial.log.WriteString(line.log)
+	partial.lastUpdate = time.Now()
+
+	if !line.partial || (p.maxLogSize > 0 && partial.log.Len() >= p.maxLogSize) {
+		p.flushPartial(ctx, key)
+	}
+}
+
+// flushPartial writes the entry of the first partial line with the recombined log as its body
+func (p *Parser) flushPartial(ctx context.Context, key partialKey) {
+	partial := p.partials[key]
+	delete(p.partials, key)
+
+	partial.base.Body = partial.log.String()
+	p.Write(ctx, partial.base)
+}
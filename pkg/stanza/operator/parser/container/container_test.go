@@ -0,0 +1,408 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const podLogPath = "/var/log/pods/default_my-pod_49cc7c1fd3702c40b2686ea7486091d6/my-container/1.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = map[string]interface{}{
+		filePathAttribute: podLogPath,
+	}
+	return e
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr string
+	}{
+		{
+			"invalid_on_error",
+			func(cfg *Config) {
+				cfg.OnError = "invalid_on_error"
+			},
+			"invalid `on_error` field",
+		},
+		{
+			"invalid_format",
+			func(cfg *Config) {
+				cfg.Format = "podman"
+			},
+			"invalid `format` 'podman'",
+		},
+		{
+			"negative_max_log_size",
+			func(cfg *Config) {
+				cfg.MaxLogSize = -1
+			},
+			"`max_log_size` must not be negative",
+		},
+		{
+			"zero_force_flush_period",
+			func(cfg *Config) {
+				cfg.ForceFlushTimeout = 0
+			},
+			"`force_flush_period` must be positive",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestContainerImplementations(t *testing.T) {
+	require.Implements(t, (*operator.Operator)(nil), new(Parser))
+}
+
+func TestParser(t *testing.T) {
+	type expected struct {
+		timestamp time.Time
+		body      string
+	}
+
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     []string
+		stream    string
+		expect    []expected
+	}{
+		{
+			"docker",
+			func(cfg *Config) {
+				cfg.Format = formatDocker
+			},
+			[]string{`{"log":"message\n","stream":"stdout","time":"2023-03-20T15:04:05.123456789Z"}`},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 123456789, time.UTC), "message"},
+			},
+		},
+		{
+			"docker_partial",
+			func(cfg *Config) {},
+			[]string{
+				`{"log":"long ","stream":"stderr","time":"2023-03-20T15:04:05.123456789Z"}`,
+				`{"log":"message\n","stream":"stderr","time":"2023-03-20T15:04:06Z"}`,
+			},
+			"stderr",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 123456789, time.UTC), "long message"},
+			},
+		},
+		{
+			"crio",
+			func(cfg *Config) {
+				cfg.Format = formatCRIO
+			},
+			[]string{"2023-03-20T16:04:05.123456789+01:00 stdout F message"},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 123456789, time.UTC), "message"},
+			},
+		},
+		{
+			"containerd",
+			func(cfg *Config) {
+				cfg.Format = formatContainerd
+			},
+			[]string{"2023-03-20T15:04:05.123456789Z stderr F message with spaces"},
+			"stderr",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 123456789, time.UTC), "message with spaces"},
+			},
+		},
+		{
+			"cri_auto",
+			func(cfg *Config) {},
+			[]string{"2023-03-20T15:04:05Z stdout F message"},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC), "message"},
+			},
+		},
+		{
+			"cri_empty_line",
+			func(cfg *Config) {},
+			[]string{"2023-03-20T15:04:05Z stdout F"},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC), ""},
+			},
+		},
+		{
+			"cri_partial",
+			func(cfg *Config) {},
+			[]string{
+				"2023-03-20T15:04:05Z stdout P long ",
+				"2023-03-20T15:04:06Z stdout P partial ",
+				"2023-03-20T15:04:07Z stdout F message",
+				"2023-03-20T15:04:08Z stdout F next",
+			},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC), "long partial message"},
+				{time.Date(2023, 3, 20, 15, 4, 8, 0, time.UTC), "next"},
+			},
+		},
+		{
+			"cri_partial_extended_tag",
+			func(cfg *Config) {},
+			[]string{
+				"2023-03-20T15:04:05Z stdout P:extra long ",
+				"2023-03-20T15:04:06Z stdout F:extra message",
+			},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC), "long message"},
+			},
+		},
+		{
+			"cri_partial_max_log_size",
+			func(cfg *Config) {
+				cfg.MaxLogSize = 8
+			},
+			[]string{
+				"2023-03-20T15:04:05Z stdout P 1234",
+				"2023-03-20T15:04:06Z stdout P 5678",
+				"2023-03-20T15:04:07Z stdout F 9",
+			},
+			"stdout",
+			[]expected{
+				{time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC), "12345678"},
+				{time.Date(2023, 3, 20, 15, 4, 7, 0, time.UTC), "9"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, tc.configure)
+
+			for _, line := range tc.input {
+				require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+			}
+
+			for _, expect := range tc.expect {
+				select {
+				case e := <-fake.Received:
+					require.Equal(t, expect.body, e.Body)
+					require.True(t, expect.timestamp.Equal(e.Timestamp), "expected %s, got %s", expect.timestamp, e.Timestamp)
+					require.Equal(t, map[string]interface{}{
+						filePathAttribute: podLogPath,
+						streamAttribute:   tc.stream,
+					}, e.Attributes)
+					require.Equal(t, map[string]interface{}{
+						namespaceResource:    "default",
+						podNameResource:      "my-pod",
+						podUIDResource:       "49cc7c1fd3702c40b2686ea7486091d6",
+						containerResource:    "my-container",
+						restartCountResource: "1",
+					}, e.Resource)
+				case <-time.After(time.Second):
+					require.FailNow(t, "Timed out waiting for entry")
+				}
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestParserPartialStreams(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+
+	lines := []string{
+		"2023-03-20T15:04:05Z stdout P out ",
+		"2023-03-20T15:04:05Z stderr P err ",
+		"2023-03-20T15:04:05Z stderr F line",
+		"2023-03-20T15:04:05Z stdout F line",
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+	}
+
+	fake.ExpectBody(t, "err line")
+	fake.ExpectBody(t, "out line")
+}
+
+func TestParserWithoutMetadata(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.AddMetadataFromFilePath = false
+	})
+
+	e := entry.New()
+	e.Body = "2023-03-20T15:04:05Z stdout F message"
+	require.NoError(t, parser.Process(context.Background(), e))
+
+	fake.ExpectEntry(t, &entry.Entry{
+		ObservedTimestamp: e.ObservedTimestamp,
+		Timestamp:         time.Date(2023, 3, 20, 15, 4, 5, 0, time.UTC),
+		Body:              "message",
+		Attributes: map[string]interface{}{
+			streamAttribute: "stdout",
+		},
+	})
+}
+
+func TestParserFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		body      interface{}
+		path      string
+		expectErr string
+	}{
+		{
+			"invalid_type",
+			func(cfg *Config) {},
+			[]byte("message"),
+			podLogPath,
+			"type []uint8 cannot be parsed as a container log",
+		},
+		{
+			"invalid_docker",
+			func(cfg *Config) {},
+			`{"log":"message\n"`,
+			podLogPath,
+			"parse docker log",
+		},
+		{
+			"docker_format_with_cri_line",
+			func(cfg *Config) {
+				cfg.Format = formatDocker
+			},
+			"2023-03-20T15:04:05Z stdout F message",
+			podLogPath,
+			"parse docker log",
+		},
+		{
+			"invalid_cri_time",
+			func(cfg *Config) {},
+			"yesterday stdout F message",
+			podLogPath,
+			"parse cri log time",
+		},
+		{
+			"invalid_cri_stream",
+			func(cfg *Config) {},
+			"2023-03-20T15:04:05Z stdin F message",
+			podLogPath,
+			"parse cri log: invalid stream 'stdin'",
+		},
+		{
+			"missing_cri_tag",
+			func(cfg *Config) {},
+			"2023-03-20T15:04:05Z stdout",
+			podLogPath,
+			"parse cri log: expected a timestamp, stream and tag",
+		},
+		{
+			"invalid_path",
+			func(cfg *Config) {},
+			"2023-03-20T15:04:05Z stdout F message",
+			"/var/lib/docker/containers/0123456789abcdef/0123456789abcdef-json.log",
+			"is not a Kubernetes pod log path",
+		},
+		{
+			"missing_path",
+			func(cfg *Config) {},
+			"2023-03-20T15:04:05Z stdout F message",
+			"",
+			"to add metadata from the file path",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, tc.configure)
+
+			e := entry.New()
+			e.Body = tc.body
+			if tc.path != "" {
+				e.Attributes = map[string]interface{}{filePathAttribute: tc.path}
+			}
+
+			err := parser.Process(context.Background(), e)
+			require.ErrorContains(t, err, tc.expectErr)
+
+			// The entry is sent as it is with the default on_error
+			fake.ExpectBody(t, tc.body)
+		})
+	}
+}
+
+func TestParserForceFlush(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.ForceFlushTimeout = 100 * time.Millisecond
+	})
+	require.NoError(t, parser.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, parser.Stop())
+	}()
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-03-20T15:04:05Z stdout P incomplete")))
+	fake.ExpectBody(t, "incomplete")
+}
+
+func TestParserStopFlushes(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {})
+	require.NoError(t, parser.Start(testutil.NewMockPersister("test")))
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-03-20T15:04:05Z stdout P incomplete")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, parser.Stop())
+	fake.ExpectBody(t, "incomplete")
+}
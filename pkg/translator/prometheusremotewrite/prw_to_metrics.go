@@ -0,0 +1,679 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+)
+
+const totalSuffix = "_total"
+
+// seriesRole is the role of a time series in the metric it is converted to
+type seriesRole int
+
+const (
+	roleValue seriesRole = iota
+	roleBucket
+	roleQuantile
+	roleSum
+	roleCount
+	roleCreated
+	roleNativeHistogram
+)
+
+// componentSuffixes are the suffixes of the time series of histograms and summaries, other than their buckets and quantiles
+var componentSuffixes = []struct {
+	suffix string
+	role   seriesRole
+}{
+	{sumStr, roleSum},
+	{countStr, roleCount},
+	{createdSuffix, roleCreated},
+}
+
+// ToMetrics converts a Prometheus remote write request to pmetric.Metrics.
+//
+// The type of each metric is taken from the metadata of the request if it is sent, and is otherwise
+// reconstructed from the names and labels of the time series of its metric family: the `_bucket`,
+// `_sum`, `_count` and `_created` series of histograms and summaries are combined into a single metric,
+// and series named with a `_total` suffix are converted to monotonic sums. The `job` and `instance`
+// labels, and the labels of the `target_info` series, are converted to resource attributes.
+func ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	c := newPRWConverter(req)
+
+	var errs error
+	for i := range req.Timeseries {
+		errs = multierr.Append(errs, c.addTimeSeries(&req.Timeseries[i]))
+	}
+
+	return c.metrics(), errs
+}
+
+// prwConverter reconstructs metrics from the time series of a remote write request
+type prwConverter struct {
+	metadata   map[string]prompb.MetricMetadata
+	names      map[string]bool
+	histograms map[string]bool
+	summaries  map[string]bool
+
+	resources     map[string]*resourceBuilder
+	resourceOrder []*resourceBuilder
+}
+
+// resourceBuilder collects the metric families of the time series of a job and instance
+type resourceBuilder struct {
+	resource pcommon.Resource
+	families map[string]*familyBuilder
+	order    []*familyBuilder
+}
+
+// familyBuilder collects the data points of a metric family
+type familyBuilder struct {
+	name       string
+	metricType pmetric.MetricType
+	metadata   prompb.MetricMetadata
+	points     map[string]*pointBuilder
+	order      []*pointBuilder
+	created    map[string]float64
+}
+
+// pointBuilder collects the samples of a data point, which are spread over several time series for
+// histograms and summaries
+type pointBuilder struct {
+	signature string
+	labels    []prompb.Label
+	timestamp int64
+	stale     bool
+	exemplars []prompb.Exemplar
+
+	value     float64
+	buckets   []bucketSample
+	quantiles []quantileSample
+	sum       float64
+	hasSum    bool
+	count     float64
+	hasCount  bool
+	histogram *prompb.Histogram
+}
+
+type bucketSample struct {
+	bound float64
+	count float64
+}
+
+type quantileSample struct {
+	quantile float64
+	value    float64
+}
+
+func newPRWConverter(req *prompb.WriteRequest) *prwConverter {
+	c := &prwConverter{
+		metadata:   make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		names:      make(map[string]bool),
+		histograms: make(map[string]bool),
+		summaries:  make(map[string]bool),
+		resources:  make(map[string]*resourceBuilder),
+	}
+
+	for _, md := range req.Metadata {
+		c.metadata[md.MetricFamilyName] = md
+	}
+
+	// The metric families of histograms and summaries are identified from all the time series
+	// of the request, since their series can be sent in any order
+	for _, ts := range req.Timeseries {
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		c.names[name] = true
+		if base, ok := cutSuffix(name, bucketStr); ok && hasLabel(ts.Labels, model.BucketLabel) {
+			c.histograms[base] = true
+		}
+		if hasLabel(ts.Labels, model.QuantileLabel) {
+			c.summaries[name] = true
+		}
+	}
+
+	return c
+}
+
+func (c *prwConverter) addTimeSeries(ts *prompb.TimeSeries) error {
+	name := labelValue(ts.Labels, model.MetricNameLabel)
+	if name == "" {
+		return errors.New("time series without a metric name is dropped")
+	}
+
+	rb := c.resource(ts.Labels)
+	if name == targetMetricName {
+		addTargetInfo(rb.resource, ts.Labels)
+		return nil
+	}
+
+	familyName, metricType, role := c.classify(name, ts)
+	fb := rb.family(familyName, metricType, c.metadataOf(familyName, metricType))
+
+	labels := pointLabels(ts.Labels)
+	signature := labelsSignature(labels)
+
+	if role == roleCreated {
+		for _, sample := range ts.Samples {
+			if !value.IsStaleNaN(sample.Value) {
+				fb.created[signature] = sample.Value
+			}
+		}
+		return nil
+	}
+
+	var last *pointBuilder
+	if role == roleNativeHistogram {
+		for i := range ts.Histograms {
+			histogram := &ts.Histograms[i]
+			last = fb.point(signature, labels, histogram.Timestamp)
+			last.histogram = histogram
+		}
+		if last != nil {
+			last.exemplars = append(last.exemplars, ts.Exemplars...)
+		}
+		return nil
+	}
+
+	var bound float64
+	var err error
+	switch role {
+	case roleBucket:
+		bound, err = strconv.ParseFloat(labelValue(ts.Labels, model.BucketLabel), 64)
+	case roleQuantile:
+		bound, err = strconv.ParseFloat(labelValue(ts.Labels, model.QuantileLabel), 64)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid bound of time series %q: %w", name, err)
+	}
+
+	for _, sample := range ts.Samples {
+		last = fb.point(signature, labels, sample.Timestamp)
+		if value.IsStaleNaN(sample.Value) {
+			last.stale = true
+			continue
+		}
+
+		switch role {
+		case roleBucket:
+			last.buckets = append(last.buckets, bucketSample{bound: bound, count: sample.Value})
+		case roleQuantile:
+			last.quantiles = append(last.quantiles, quantileSample{quantile: bound, value: sample.Value})
+		case roleSum:
+			last.sum, last.hasSum = sample.Value, true
+		case roleCount:
+			last.count, last.hasCount = sample.Value, true
+		default:
+			last.value = sample.Value
+		}
+	}
+	if last != nil {
+		last.exemplars = append(last.exemplars, ts.Exemplars...)
+	}
+	return nil
+}
+
+// classify returns the metric family of a time series, its type, and the role of the time series in it
+func (c *prwConverter) classify(name string, ts *prompb.TimeSeries) (string, pmetric.MetricType, seriesRole) {
+	if len(ts.Histograms) > 0 {
+		return name, pmetric.MetricTypeExponentialHistogram, roleNativeHistogram
+	}
+
+	if base, ok := cutSuffix(name, bucketStr); ok && hasLabel(ts.Labels, model.BucketLabel) && c.isHistogram(base) {
+		return base, pmetric.MetricTypeHistogram, roleBucket
+	}
+	if hasLabel(ts.Labels, model.QuantileLabel) && c.isSummary(name) {
+		return name, pmetric.MetricTypeSummary, roleQuantile
+	}
+
+	for _, component := range componentSuffixes {
+		base, ok := cutSuffix(name, component.suffix)
+		if !ok {
+			continue
+		}
+		if c.isHistogram(base) {
+			return base, pmetric.MetricTypeHistogram, component.role
+		}
+		if c.isSummary(base) {
+			return base, pmetric.MetricTypeSummary, component.role
+		}
+	}
+
+	if base, ok := cutSuffix(name, createdSuffix); ok {
+		// The created series of a counter is named without its _total suffix
+		if c.names[base+totalSuffix] && c.isCounter(base+totalSuffix) {
+			return base + totalSuffix, pmetric.MetricTypeSum, roleCreated
+		}
+		if c.names[base] && c.isCounter(base) {
+			return base, pmetric.MetricTypeSum, roleCreated
+		}
+	}
+
+	if c.isCounter(name) {
+		return name, pmetric.MetricTypeSum, roleValue
+	}
+	return name, pmetric.MetricTypeGauge, roleValue
+}
+
+func (c *prwConverter) isHistogram(name string) bool {
+	if md, ok := c.metadata[name]; ok {
+		return md.Type == prompb.MetricMetadata_HISTOGRAM || md.Type == prompb.MetricMetadata_GAUGEHISTOGRAM
+	}
+	return c.histograms[name]
+}
+
+func (c *prwConverter) isSummary(name string) bool {
+	if md, ok := c.metadata[name]; ok {
+		return md.Type == prompb.MetricMetadata_SUMMARY
+	}
+	return c.summaries[name]
+}
+
+func (c *prwConverter) isCounter(name string) bool {
+	if md, ok := c.metadata[name]; ok {
+		return md.Type == prompb.MetricMetadata_COUNTER
+	}
+	base, hasTotal := cutSuffix(name, totalSuffix)
+	if md, ok := c.metadata[base]; hasTotal && ok {
+		return md.Type == prompb.MetricMetadata_COUNTER
+	}
+	return hasTotal
+}
+
+// metadataOf returns the metadata of a metric family, whose name in the metadata
+// doesn't have the _total suffix of the counter if it is scraped from OpenMetrics
+func (c *prwConverter) metadataOf(name string, metricType pmetric.MetricType) prompb.MetricMetadata {
+	if md, ok := c.metadata[name]; ok {
+		return md
+	}
+	if base, ok := cutSuffix(name, totalSuffix); ok && metricType == pmetric.MetricTypeSum {
+		return c.metadata[base]
+	}
+	return prompb.MetricMetadata{}
+}
+
+// resource returns the resource of the job and instance of a time series
+func (c *prwConverter) resource(labels []prompb.Label) *resourceBuilder {
+	job := labelValue(labels, model.JobLabel)
+	instance := labelValue(labels, model.InstanceLabel)
+	key := job + "\xff" + instance
+
+	rb, ok := c.resources[key]
+	if !ok {
+		rb = &resourceBuilder{
+			resource: pcommon.NewResource(),
+			families: make(map[string]*familyBuilder),
+		}
+		if job != "" {
+			rb.resource.Attributes().PutStr(conventions.AttributeServiceName, job)
+		}
+		if instance != "" {
+			rb.resource.Attributes().PutStr(conventions.AttributeServiceInstanceID, instance)
+		}
+		c.resources[key] = rb
+		c.resourceOrder = append(c.resourceOrder, rb)
+	}
+	return rb
+}
+
+// addTargetInfo adds the labels of the target_info series to the resource attributes
+func addTargetInfo(resource pcommon.Resource, labels []prompb.Label) {
+	for _, label := range labels {
+		switch label.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+		default:
+			resource.Attributes().PutStr(label.Name, label.Value)
+		}
+	}
+}
+
+func (rb *resourceBuilder) family(name string, metricType pmetric.MetricType, md prompb.MetricMetadata) *familyBuilder {
+	fb, ok := rb.families[name]
+	if !ok {
+		fb = &familyBuilder{
+			name:       name,
+			metricType: metricType,
+			metadata:   md,
+			points:     make(map[string]*pointBuilder),
+			created:    make(map[string]float64),
+		}
+		rb.families[name] = fb
+		rb.order = append(rb.order, fb)
+	}
+	return fb
+}
+
+func (fb *familyBuilder) point(signature string, labels []prompb.Label, timestamp int64) *pointBuilder {
+	key := signature + "\xff" + strconv.FormatInt(timestamp, 10)
+	pb, ok := fb.points[key]
+	if !ok {
+		pb = &pointBuilder{signature: signature, labels: labels, timestamp: timestamp}
+		fb.points[key] = pb
+		fb.order = append(fb.order, pb)
+	}
+	return pb
+}
+
+// metrics builds the metrics of the collected metric families
+func (c *prwConverter) metrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, rb := range c.resourceOrder {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rb.resource.CopyTo(rm.Resource())
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		for _, fb := range rb.order {
+			if len(fb.order) == 0 {
+				// Only the created series of the metric family was sent
+				continue
+			}
+			fb.appendTo(metrics.AppendEmpty())
+		}
+	}
+	return md
+}
+
+func (fb *familyBuilder) appendTo(metric pmetric.Metric) {
+	metric.SetName(fb.name)
+	metric.SetDescription(fb.metadata.Help)
+	metric.SetUnit(fb.metadata.Unit)
+
+	switch fb.metricType {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, pb := range fb.order {
+			dp := sum.DataPoints().AppendEmpty()
+			pb.fillNumberDataPoint(dp)
+			dp.SetStartTimestamp(fb.startTimestamp(pb))
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, pb := range fb.order {
+			dp := histogram.DataPoints().AppendEmpty()
+			pb.fillHistogramDataPoint(dp)
+			dp.SetStartTimestamp(fb.startTimestamp(pb))
+		}
+	case pmetric.MetricTypeSummary:
+		summary := metric.SetEmptySummary()
+		for _, pb := range fb.order {
+			dp := summary.DataPoints().AppendEmpty()
+			pb.fillSummaryDataPoint(dp)
+			dp.SetStartTimestamp(fb.startTimestamp(pb))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, pb := range fb.order {
+			pb.fillExponentialHistogramDataPoint(histogram.DataPoints().AppendEmpty())
+		}
+	default:
+		gauge := metric.SetEmptyGauge()
+		for _, pb := range fb.order {
+			pb.fillNumberDataPoint(gauge.DataPoints().AppendEmpty())
+		}
+	}
+}
+
+// startTimestamp returns the start timestamp of a data point from the created series, whose value is in seconds
+func (fb *familyBuilder) startTimestamp(pb *pointBuilder) pcommon.Timestamp {
+	created, ok := fb.created[pb.signature]
+	if !ok {
+		return 0
+	}
+	return pcommon.Timestamp(created * float64(time.Second))
+}
+
+func (pb *pointBuilder) fillNumberDataPoint(dp pmetric.NumberDataPoint) {
+	pb.fillAttributes(dp.Attributes())
+	dp.SetTimestamp(fromMillis(pb.timestamp))
+	if pb.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		dp.SetDoubleValue(pb.value)
+	}
+	fillExemplars(pb.exemplars, dp.Exemplars())
+}
+
+func (pb *pointBuilder) fillHistogramDataPoint(dp pmetric.HistogramDataPoint) {
+	pb.fillAttributes(dp.Attributes())
+	dp.SetTimestamp(fromMillis(pb.timestamp))
+	fillExemplars(pb.exemplars, dp.Exemplars())
+	if pb.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(pb.buckets, func(i, j int) bool { return pb.buckets[i].bound < pb.buckets[j].bound })
+
+	// The bucket counts are cumulative in Prometheus, and the +Inf bucket is the overflow bucket
+	var previous float64
+	for _, bucket := range pb.buckets {
+		if !math.IsInf(bucket.bound, 1) {
+			dp.ExplicitBounds().Append(bucket.bound)
+		}
+		dp.BucketCounts().Append(uint64(math.Max(bucket.count-previous, 0)))
+		previous = bucket.count
+	}
+
+	count := previous
+	if pb.hasCount {
+		count = pb.count
+	}
+	if len(pb.buckets) == 0 || !math.IsInf(pb.buckets[len(pb.buckets)-1].bound, 1) {
+		dp.BucketCounts().Append(uint64(math.Max(count-previous, 0)))
+	}
+
+	dp.SetCount(uint64(count))
+	if pb.hasSum {
+		dp.SetSum(pb.sum)
+	}
+}
+
+func (pb *pointBuilder) fillSummaryDataPoint(dp pmetric.SummaryDataPoint) {
+	pb.fillAttributes(dp.Attributes())
+	dp.SetTimestamp(fromMillis(pb.timestamp))
+	if pb.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(pb.quantiles, func(i, j int) bool { return pb.quantiles[i].quantile < pb.quantiles[j].quantile })
+	for _, q := range pb.quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q.quantile)
+		qv.SetValue(q.value)
+	}
+	dp.SetCount(uint64(pb.count))
+	dp.SetSum(pb.sum)
+}
+
+func (pb *pointBuilder) fillExponentialHistogramDataPoint(dp pmetric.ExponentialHistogramDataPoint) {
+	pb.fillAttributes(dp.Attributes())
+	dp.SetTimestamp(fromMillis(pb.timestamp))
+	fillExemplars(pb.exemplars, dp.Exemplars())
+
+	h := pb.histogram
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetScale(h.Schema)
+	dp.SetSum(h.Sum)
+	if _, ok := h.Count.(*prompb.Histogram_CountFloat); ok {
+		dp.SetCount(uint64(math.Round(h.GetCountFloat())))
+		dp.SetZeroCount(uint64(math.Round(h.GetZeroCountFloat())))
+	} else {
+		dp.SetCount(h.GetCountInt())
+		dp.SetZeroCount(h.GetZeroCountInt())
+	}
+	convertNativeBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive())
+	convertNativeBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative())
+}
+
+// convertNativeBuckets translates Prometheus Native Histogram sparse buckets to OTel Exponential
+// Histogram dense buckets. It is the inverse of convertBucketsLayout: the counts of integer histograms
+// are deltas to the previous bucket, and the OTel bucket index is the Prometheus bucket index minus 1.
+func convertNativeBuckets(spans []prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	values := counts
+	if len(values) == 0 {
+		values = make([]float64, 0, len(deltas))
+		var count int64
+		for _, delta := range deltas {
+			count += delta
+			values = append(values, float64(count))
+		}
+	}
+
+	var (
+		bucketIdx int32
+		pos       int
+	)
+	firstIdx := spans[0].Offset
+	for i, span := range spans {
+		if i == 0 {
+			bucketIdx = span.Offset
+		} else {
+			bucketIdx += span.Offset
+		}
+		for j := uint32(0); j < span.Length && pos < len(values); j++ {
+			for int(bucketIdx-firstIdx) > buckets.BucketCounts().Len() {
+				buckets.BucketCounts().Append(0)
+			}
+			buckets.BucketCounts().Append(uint64(math.Round(values[pos])))
+			bucketIdx++
+			pos++
+		}
+	}
+	buckets.SetOffset(firstIdx - 1)
+}
+
+func (pb *pointBuilder) fillAttributes(attributes pcommon.Map) {
+	attributes.EnsureCapacity(len(pb.labels))
+	for _, label := range pb.labels {
+		attributes.PutStr(label.Name, label.Value)
+	}
+}
+
+// fillExemplars converts exemplars, whose trace_id and span_id labels are the IDs of their span
+func fillExemplars(promExemplars []prompb.Exemplar, exemplars pmetric.ExemplarSlice) {
+	for _, promExemplar := range promExemplars {
+		exemplar := exemplars.AppendEmpty()
+		exemplar.SetTimestamp(fromMillis(promExemplar.Timestamp))
+		exemplar.SetDoubleValue(promExemplar.Value)
+		for _, label := range promExemplar.Labels {
+			switch label.Name {
+			case traceIDKey:
+				var traceID pcommon.TraceID
+				if decodeID(label.Value, traceID[:]) {
+					exemplar.SetTraceID(traceID)
+					continue
+				}
+			case spanIDKey:
+				var spanID pcommon.SpanID
+				if decodeID(label.Value, spanID[:]) {
+					exemplar.SetSpanID(spanID)
+					continue
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(label.Name, label.Value)
+		}
+	}
+}
+
+// decodeID decodes a hex encoded trace or span ID
+func decodeID(s string, id []byte) bool {
+	if hex.DecodedLen(len(s)) != len(id) {
+		return false
+	}
+	_, err := hex.Decode(id, []byte(s))
+	return err == nil
+}
+
+// pointLabels returns the labels of a time series which are converted to data point attributes
+func pointLabels(labels []prompb.Label) []prompb.Label {
+	result := make([]prompb.Label, 0, len(labels))
+	for _, label := range labels {
+		switch label.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.BucketLabel, model.QuantileLabel:
+		default:
+			result = append(result, label)
+		}
+	}
+	sort.Sort(ByLabelName(result))
+	return result
+}
+
+func labelsSignature(labels []prompb.Label) string {
+	var b strings.Builder
+	for _, label := range labels {
+		b.WriteString(label.Name)
+		b.WriteByte('\xff')
+		b.WriteString(label.Value)
+		b.WriteByte('\xff')
+	}
+	return b.String()
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, label := range labels {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func cutSuffix(s, suffix string) (string, bool) {
+	if !strings.HasSuffix(s, suffix) || len(s) == len(suffix) {
+		return s, false
+	}
+	return s[:len(s)-len(suffix)], true
+}
+
+// fromMillis converts a Prometheus timestamp in ms to an OTLP timestamp in ns
+func fromMillis(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * int64(time.Millisecond))
+}
@@ -0,0 +1,465 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	prwTimestamp = int64(1679324645000)
+	prwCreated   = 1679324000.5
+)
+
+// newExpectedMetrics returns metrics with a single resource of the job and instance, and a metric appended by fill
+func newExpectedMetrics(fill func(metric pmetric.Metric)) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "app")
+	rm.Resource().Attributes().PutStr("service.instance.id", "localhost:8080")
+	fill(rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty())
+	return md
+}
+
+func TestToMetrics(t *testing.T) {
+	ts := fromMillis(prwTimestamp)
+	start := pcommon.Timestamp(prwCreated * float64(time.Second))
+
+	tests := []struct {
+		name     string
+		request  *prompb.WriteRequest
+		expected pmetric.Metrics
+	}{
+		{
+			name: "gauge",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(
+						getPromLabels("__name__", "temperature", "job", "app", "instance", "localhost:8080", "room", "kitchen"),
+						getSample(21.5, prwTimestamp),
+					),
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("temperature")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("room", "kitchen")
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(21.5)
+			}),
+		},
+		{
+			name: "gauge_with_metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(
+						getPromLabels("__name__", "queue_total", "job", "app", "instance", "localhost:8080"),
+						getSample(3, prwTimestamp),
+					),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_total", Help: "Items in the queue", Unit: "items"},
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("queue_total")
+				metric.SetDescription("Items in the queue")
+				metric.SetUnit("items")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(3)
+			}),
+		},
+		{
+			name: "counter",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(
+						getPromLabels("__name__", "requests_created", "job", "app", "instance", "localhost:8080", "code", "200"),
+						getSample(prwCreated, prwTimestamp),
+					),
+					*getTimeSeries(
+						getPromLabels("__name__", "requests_total", "job", "app", "instance", "localhost:8080", "code", "200"),
+						getSample(10, prwTimestamp),
+					),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Requests"},
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("requests_total")
+				metric.SetDescription("Requests")
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("code", "200")
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(10)
+			}),
+		},
+		{
+			name: "histogram",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels("__name__", "latency_sum", "job", "app", "instance", "localhost:8080"), getSample(12.5, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_bucket", "job", "app", "instance", "localhost:8080", "le", "+Inf"), getSample(10, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_bucket", "job", "app", "instance", "localhost:8080", "le", "1"), getSample(4, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_bucket", "job", "app", "instance", "localhost:8080", "le", "0.5"), getSample(1, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_count", "job", "app", "instance", "localhost:8080"), getSample(10, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_created", "job", "app", "instance", "localhost:8080"), getSample(prwCreated, prwTimestamp)),
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("latency")
+				histogram := metric.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(ts)
+				dp.SetCount(10)
+				dp.SetSum(12.5)
+				dp.ExplicitBounds().FromRaw([]float64{0.5, 1})
+				dp.BucketCounts().FromRaw([]uint64{1, 3, 6})
+			}),
+		},
+		{
+			name: "histogram_without_inf_bucket",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels("__name__", "latency_bucket", "job", "app", "instance", "localhost:8080", "le", "1"), getSample(4, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "latency_count", "job", "app", "instance", "localhost:8080"), getSample(5, prwTimestamp)),
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("latency")
+				histogram := metric.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetCount(5)
+				dp.ExplicitBounds().FromRaw([]float64{1})
+				dp.BucketCounts().FromRaw([]uint64{4, 1})
+			}),
+		},
+		{
+			name: "summary",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels("__name__", "rpc", "job", "app", "instance", "localhost:8080", "quantile", "0.99"), getSample(3, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "rpc", "job", "app", "instance", "localhost:8080", "quantile", "0.5"), getSample(1, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "rpc_sum", "job", "app", "instance", "localhost:8080"), getSample(40, prwTimestamp)),
+					*getTimeSeries(getPromLabels("__name__", "rpc_count", "job", "app", "instance", "localhost:8080"), getSample(20, prwTimestamp)),
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("rpc")
+				dp := metric.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetCount(20)
+				dp.SetSum(40)
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(1)
+				q = dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.99)
+				q.SetValue(3)
+			}),
+		},
+		{
+			name: "native_histogram",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "size", "job", "app", "instance", "localhost:8080"),
+						Histograms: []prompb.Histogram{
+							{
+								Count:          &prompb.Histogram_CountInt{CountInt: 9},
+								Sum:            30,
+								Schema:         1,
+								ZeroThreshold:  defaultZeroThreshold,
+								ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+								PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 2}, {Offset: 1, Length: 1}},
+								PositiveDeltas: []int64{2, 1, -2},
+								NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+								NegativeDeltas: []int64{1},
+								Timestamp:      prwTimestamp,
+							},
+						},
+					},
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("size")
+				histogram := metric.SetEmptyExponentialHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetScale(1)
+				dp.SetCount(9)
+				dp.SetSum(30)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{2, 3, 0, 1})
+				dp.Negative().SetOffset(-1)
+				dp.Negative().BucketCounts().FromRaw([]uint64{1})
+			}),
+		},
+		{
+			name: "float_native_histogram",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "size", "job", "app", "instance", "localhost:8080"),
+						Histograms: []prompb.Histogram{
+							{
+								Count:          &prompb.Histogram_CountFloat{CountFloat: 3},
+								Sum:            5,
+								ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 0},
+								PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+								PositiveCounts: []float64{1, 2},
+								Timestamp:      prwTimestamp,
+							},
+						},
+					},
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("size")
+				histogram := metric.SetEmptyExponentialHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetCount(3)
+				dp.SetSum(5)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+			}),
+		},
+		{
+			name: "target_info",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(
+						getPromLabels("__name__", "target_info", "job", "app", "instance", "localhost:8080", "host_name", "node-1"),
+						getSample(1, prwTimestamp),
+					),
+					*getTimeSeries(
+						getPromLabels("__name__", "up", "job", "app", "instance", "localhost:8080"),
+						getSample(1, prwTimestamp),
+					),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := newExpectedMetrics(func(metric pmetric.Metric) {
+					metric.SetName("up")
+					dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+					dp.SetTimestamp(ts)
+					dp.SetDoubleValue(1)
+				})
+				md.ResourceMetrics().At(0).Resource().Attributes().PutStr("host_name", "node-1")
+				return md
+			}(),
+		},
+		{
+			name: "stale",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(
+						getPromLabels("__name__", "up", "job", "app", "instance", "localhost:8080"),
+						getSample(math.Float64frombits(value.StaleNaN), prwTimestamp),
+					),
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("up")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			}),
+		},
+		{
+			name: "exemplars",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  getPromLabels("__name__", "requests_total", "job", "app", "instance", "localhost:8080"),
+						Samples: []prompb.Sample{getSample(1, prwTimestamp-1000), getSample(2, prwTimestamp)},
+						Exemplars: []prompb.Exemplar{
+							{
+								Labels: getPromLabels(
+									traceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736",
+									spanIDKey, "00f067aa0ba902b7",
+									"user", "alice",
+								),
+								Value:     1,
+								Timestamp: prwTimestamp,
+							},
+						},
+					},
+				},
+			},
+			expected: newExpectedMetrics(func(metric pmetric.Metric) {
+				metric.SetName("requests_total")
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(prwTimestamp - 1000))
+				dp.SetDoubleValue(1)
+				dp = sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(2)
+				exemplar := dp.Exemplars().AppendEmpty()
+				exemplar.SetTimestamp(ts)
+				exemplar.SetDoubleValue(1)
+				exemplar.SetTraceID([16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36})
+				exemplar.SetSpanID([8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7})
+				exemplar.FilteredAttributes().PutStr("user", "alice")
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, err := ToMetrics(tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metrics)
+		})
+	}
+}
+
+func TestToMetricsResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "up", "job", "a", "instance", "1"), getSample(1, prwTimestamp)),
+			*getTimeSeries(getPromLabels("__name__", "up", "job", "b", "instance", "1"), getSample(0, prwTimestamp)),
+			*getTimeSeries(getPromLabels("__name__", "up"), getSample(1, prwTimestamp)),
+		},
+	}
+
+	metrics, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 3, metrics.ResourceMetrics().Len())
+
+	expected := []map[string]interface{}{
+		{"service.name": "a", "service.instance.id": "1"},
+		{"service.name": "b", "service.instance.id": "1"},
+		{},
+	}
+	for i, attributes := range expected {
+		rm := metrics.ResourceMetrics().At(i)
+		assert.Equal(t, attributes, rm.Resource().Attributes().AsRaw())
+		assert.Equal(t, 1, rm.ScopeMetrics().At(0).Metrics().Len())
+	}
+}
+
+func TestToMetricsErrors(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("job", "app"), getSample(1, prwTimestamp)),
+			*getTimeSeries(getPromLabels("__name__", "latency_bucket", "le", "fast"), getSample(1, prwTimestamp)),
+			*getTimeSeries(getPromLabels("__name__", "up"), getSample(1, prwTimestamp)),
+		},
+	}
+
+	metrics, err := ToMetrics(req)
+	require.ErrorContains(t, err, "time series without a metric name is dropped")
+	require.ErrorContains(t, err, `invalid bound of time series "latency_bucket"`)
+
+	// The valid time series are still converted
+	require.Equal(t, 1, metrics.MetricCount())
+}
+
+// TestToMetricsRoundTrip tests that ToMetrics is the inverse of FromMetrics
+func TestToMetricsRoundTrip(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "app")
+	rm.Resource().Attributes().PutStr("service.instance.id", "localhost:8080")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("requests_total")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("code", "200")
+	dp.SetTimestamp(fromMillis(prwTimestamp))
+	dp.SetDoubleValue(10)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	hist := histogram.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := hist.DataPoints().AppendEmpty()
+	hdp.SetTimestamp(fromMillis(prwTimestamp))
+	hdp.SetCount(10)
+	hdp.SetSum(12.5)
+	hdp.ExplicitBounds().FromRaw([]float64{0.5, 1})
+	hdp.BucketCounts().FromRaw([]uint64{1, 3, 6})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("size")
+	expHist := expHistogram.SetEmptyExponentialHistogram()
+	expHist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp := expHist.DataPoints().AppendEmpty()
+	edp.SetTimestamp(fromMillis(prwTimestamp))
+	edp.SetScale(2)
+	edp.SetCount(7)
+	edp.SetSum(20)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(3)
+	edp.Positive().BucketCounts().FromRaw([]uint64{2, 0, 0, 0, 4})
+
+	tsMap, err := FromMetrics(md, Settings{DisableTargetInfo: true})
+	require.NoError(t, err)
+
+	req := &prompb.WriteRequest{}
+	for _, ts := range tsMap {
+		req.Timeseries = append(req.Timeseries, *ts)
+	}
+
+	converted, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, converted.ResourceMetrics().Len())
+	require.Equal(t, rm.Resource().Attributes().AsRaw(), converted.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	// The metrics are converted in the order of their time series, which FromMetrics returns in a map
+	convertedMetrics := converted.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, metrics.Len(), convertedMetrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		expected := metrics.At(i)
+		var actual pmetric.Metric
+		for j := 0; j < convertedMetrics.Len(); j++ {
+			if convertedMetrics.At(j).Name() == expected.Name() {
+				actual = convertedMetrics.At(j)
+			}
+		}
+		assert.Equal(t, expected, actual, expected.Name())
+	}
+}
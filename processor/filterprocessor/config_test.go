@@ -863,6 +863,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					SpanEventConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					SpanLinkConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
 				},
 				Metrics: MetricFilters{
 					MetricConditions: []string{
@@ -871,6 +874,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					DataPointConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					ExemplarConditions: []string{
+						`filtered_attributes["test"] == "pass"`,
+					},
 				},
 				Logs: LogFilters{
 					LogConditions: []string{
@@ -905,23 +911,31 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_span"),
-			errorMessage: "unable to parse OTTL statement: 1:24: unexpected token \"[\" (expected <opcomparison> Value)",
+			errorMessage: "unable to parse OTTL statement \"drop() where attributes[test] == \\\"pass\\\"\": column 24 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_spanevent"),
-			errorMessage: "unable to parse OTTL statement: 1:24: unexpected token \"[\" (expected <opcomparison> Value)",
+			errorMessage: "unable to parse OTTL statement \"drop() where attributes[test] == \\\"pass\\\"\": column 24 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "bad_syntax_spanlink"),
+			errorMessage: "unable to parse OTTL statement \"drop() where attributes[test] == \\\"pass\\\"\": column 24 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_metric"),
-			errorMessage: "unable to parse OTTL statement: 1:33: unexpected token \"[\" (expected <opcomparison> Value)",
+			errorMessage: "unable to parse OTTL statement \"drop() where resource.attributes[test] == \\\"pass\\\"\": column 33 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_datapoint"),
-			errorMessage: "unable to parse OTTL statement: 1:24: unexpected token \"[\" (expected <opcomparison> Value)",
+			errorMessage: "unable to parse OTTL statement \"drop() where attributes[test] == \\\"pass\\\"\": column 24 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "bad_syntax_exemplar"),
+			errorMessage: "unable to parse OTTL statement \"drop() where filtered_attributes[test] == \\\"pass\\\"\": column 33 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_log"),
-			errorMessage: "unable to parse OTTL statement: 1:24: unexpected token \"[\" (expected <opcomparison> Value)",
+			errorMessage: "unable to parse OTTL statement \"drop() where attributes[test] == \\\"pass\\\"\": column 24 near \"[\": unexpected token \"[\" (expected <opcomparison> Value)",
 		},
 	}
 
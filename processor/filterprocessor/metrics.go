@@ -31,6 +31,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/common"
@@ -40,6 +41,7 @@ type filterMetricProcessor struct {
 	skipResourceExpr  expr.BoolExpr[ottlresource.TransformContext]
 	skipMetricExpr    expr.BoolExpr[ottlmetric.TransformContext]
 	skipDataPointExpr expr.BoolExpr[ottldatapoint.TransformContext]
+	skipExemplarExpr  expr.BoolExpr[ottlexemplar.TransformContext]
 	logger            *zap.Logger
 }
 
@@ -48,7 +50,7 @@ func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*fi
 	fsp := &filterMetricProcessor{
 		logger: set.Logger,
 	}
-	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
+	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil || cfg.Metrics.ExemplarConditions != nil {
 		if cfg.Metrics.MetricConditions != nil {
 			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, common.MetricFunctions(), cfg.ErrorMode, set)
 			if err != nil {
@@ -63,6 +65,13 @@ func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*fi
 			}
 		}
 
+		if cfg.Metrics.ExemplarConditions != nil {
+			fsp.skipExemplarExpr, err = filterottl.NewBoolExprForExemplar(cfg.Metrics.ExemplarConditions, filterottl.StandardExemplarFuncs(), cfg.ErrorMode, set)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return fsp, nil
 	}
 
@@ -115,7 +124,7 @@ func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*fi
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if fmp.skipResourceExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil {
+	if fmp.skipResourceExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil && fmp.skipExemplarExpr == nil {
 		return md, nil
 	}
 
@@ -136,7 +145,7 @@ func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric
 			scope := smetrics.Scope()
 			smetrics.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
 				if fmp.skipMetricExpr != nil {
-					skip, err := fmp.skipMetricExpr.Eval(ctx, ottlmetric.NewTransformContext(metric, scope, resource))
+					skip, err := fmp.skipMetricExpr.Eval(ctx, ottlmetric.NewTransformContext(metric, smetrics.Metrics(), scope, resource))
 					if err != nil {
 						errors = multierr.Append(errors, err)
 					}
@@ -148,23 +157,34 @@ func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric
 					switch metric.Type() {
 					case pmetric.MetricTypeSum:
 						errors = multierr.Append(errors, fmp.handleNumberDataPoints(ctx, metric.Sum().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Sum().DataPoints().Len() == 0
+						if metric.Sum().DataPoints().Len() == 0 {
+							return true
+						}
 					case pmetric.MetricTypeGauge:
 						errors = multierr.Append(errors, fmp.handleNumberDataPoints(ctx, metric.Gauge().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Gauge().DataPoints().Len() == 0
+						if metric.Gauge().DataPoints().Len() == 0 {
+							return true
+						}
 					case pmetric.MetricTypeHistogram:
 						errors = multierr.Append(errors, fmp.handleHistogramDataPoints(ctx, metric.Histogram().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Histogram().DataPoints().Len() == 0
+						if metric.Histogram().DataPoints().Len() == 0 {
+							return true
+						}
 					case pmetric.MetricTypeExponentialHistogram:
 						errors = multierr.Append(errors, fmp.handleExponetialHistogramDataPoints(ctx, metric.ExponentialHistogram().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.ExponentialHistogram().DataPoints().Len() == 0
+						if metric.ExponentialHistogram().DataPoints().Len() == 0 {
+							return true
+						}
 					case pmetric.MetricTypeSummary:
 						errors = multierr.Append(errors, fmp.handleSummaryDataPoints(ctx, metric.Summary().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Summary().DataPoints().Len() == 0
-					default:
-						return false
+						if metric.Summary().DataPoints().Len() == 0 {
+							return true
+						}
 					}
 				}
+				if fmp.skipExemplarExpr != nil {
+					errors = multierr.Append(errors, fmp.handleExemplars(ctx, metric, scope, resource))
+				}
 				return false
 			})
 			return smetrics.Metrics().Len() == 0
@@ -278,3 +298,44 @@ func (fmp *filterMetricProcessor) handleSummaryDataPoints(ctx context.Context, d
 	})
 	return errors
 }
+
+// handleExemplars removes the exemplars of the data points of metric for which skipExemplarExpr evaluates to true.
+func (fmp *filterMetricProcessor) handleExemplars(ctx context.Context, metric pmetric.Metric, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	var errors error
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dps.At(i).Exemplars(), dps.At(i), metric, is, resource))
+		}
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dps.At(i).Exemplars(), dps.At(i), metric, is, resource))
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dps.At(i).Exemplars(), dps.At(i), metric, is, resource))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dps.At(i).Exemplars(), dps.At(i), metric, is, resource))
+		}
+	}
+	return errors
+}
+
+func (fmp *filterMetricProcessor) removeExemplars(ctx context.Context, exemplars pmetric.ExemplarSlice, dataPoint interface{}, metric pmetric.Metric, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	var errors error
+	exemplars.RemoveIf(func(exemplar pmetric.Exemplar) bool {
+		skip, err := fmp.skipExemplarExpr.Eval(ctx, ottlexemplar.NewTransformContext(exemplar, dataPoint, metric, is, resource))
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			return false
+		}
+		return skip
+	})
+	return errors
+}
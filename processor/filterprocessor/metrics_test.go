@@ -693,6 +693,38 @@ func TestFilterMetricProcessorWithOTTL(t *testing.T) {
 			},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "drop exemplars",
+			conditions: MetricFilters{
+				ExemplarConditions: []string{
+					`filtered_attributes["user.email"] != nil`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					_, ok := exemplar.FilteredAttributes().Get("user.email")
+					return ok
+				})
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					return true
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop exemplars using the data point",
+			conditions: MetricFilters{
+				ExemplarConditions: []string{
+					`metric.type == METRIC_DATA_TYPE_HISTOGRAM and datapoint.attributes["flags"] == "C|D"`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					return true
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "multiple conditions",
 			conditions: MetricFilters{
@@ -761,6 +793,13 @@ func fillMetricOne(m pmetric.Metric) {
 	dataPoint0.Attributes().PutStr("attr3", "test3")
 	dataPoint0.Attributes().PutStr("flags", "A|B|C")
 
+	exemplar0 := dataPoint0.Exemplars().AppendEmpty()
+	exemplar0.SetDoubleValue(1.0)
+	exemplar0.FilteredAttributes().PutStr("user.email", "user@example.com")
+
+	exemplar1 := dataPoint0.Exemplars().AppendEmpty()
+	exemplar1.SetDoubleValue(1.0)
+
 	dataPoint1 := m.Sum().DataPoints().AppendEmpty()
 	dataPoint1.SetStartTimestamp(dataPointStartTimestamp)
 	dataPoint1.SetDoubleValue(3.7)
@@ -783,6 +822,10 @@ func fillMetricTwo(m pmetric.Metric) {
 	dataPoint0.Attributes().PutStr("flags", "C|D")
 	dataPoint0.SetCount(1)
 
+	exemplar0 := dataPoint0.Exemplars().AppendEmpty()
+	exemplar0.SetDoubleValue(2.5)
+	exemplar0.FilteredAttributes().PutStr("user.email", "user@example.com")
+
 	dataPoint1 := m.Histogram().DataPoints().AppendEmpty()
 	dataPoint1.SetStartTimestamp(dataPointStartTimestamp)
 	dataPoint1.Attributes().PutStr("attr1", "test1")
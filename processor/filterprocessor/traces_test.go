@@ -251,6 +251,42 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 			},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "drop span links",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`attributes["tenant"] == "external"`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().RemoveIf(func(link ptrace.SpanLink) bool {
+					_, ok := link.Attributes().Get("tenant")
+					return ok
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().At(1).Links().RemoveIf(func(link ptrace.SpanLink) bool {
+					_, ok := link.Attributes().Get("tenant")
+					return ok
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop span links using the parent span",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`span.name == "operationB" and dropped_attributes_count == 4`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().RemoveIf(func(link ptrace.SpanLink) bool {
+					return true
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().At(1).Links().RemoveIf(func(link ptrace.SpanLink) bool {
+					return true
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "multiple conditions",
 			conditions: TraceFilters{
@@ -340,6 +376,7 @@ func fillSpanTwo(span ptrace.Span) {
 	link0.SetDroppedAttributesCount(4)
 	link1 := span.Links().AppendEmpty()
 	link1.SetDroppedAttributesCount(4)
+	link1.Attributes().PutStr("tenant", "external")
 	span.SetDroppedLinksCount(3)
 	status := span.Status()
 	status.SetCode(ptrace.StatusCodeError)
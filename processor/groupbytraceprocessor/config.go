@@ -16,6 +16,8 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -43,5 +45,11 @@ type Config struct {
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
+	// Use Storage instead.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension used to keep the traces waiting to be released.
+	// When set, only the trace IDs are kept in memory, and pending traces are restored when the processor starts.
+	// Default: nil, keeping the traces in memory.
+	Storage *component.ID `mapstructure:"storage"`
 }
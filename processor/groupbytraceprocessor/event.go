@@ -41,6 +41,9 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traces restored from the storage on start
+	traceRestored
 )
 
 var (
@@ -70,6 +73,11 @@ type tracesWithID struct {
 	td ptrace.Traces
 }
 
+type restoredTrace struct {
+	id        pcommon.TraceID
+	releaseAt time.Time
+}
+
 // eventMachine is a machine that accepts events in a typically non-blocking manner,
 // processing the events serially per worker scope, to ensure that data at the consumer is consistent.
 // Just like the machine itself is non-blocking, consumers are expected to also not block
@@ -87,6 +95,7 @@ type eventMachine struct {
 	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 	onTraceReleased func(rss []ptrace.ResourceSpans) error
 	onTraceRemoved  func(traceID pcommon.TraceID) error
+	onTraceRestored func(trace restoredTrace, worker *eventMachineWorker) error
 
 	onError func(event)
 
@@ -216,6 +225,22 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRestored:
+		if em.onTraceRestored == nil {
+			em.logger.Debug("onTraceRestored not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(restoredTrace)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRestored", func() error {
+			return em.onTraceRestored(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -244,6 +269,20 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 	return nil
 }
 
+// restore routes a trace kept by the storage to the worker responsible for its trace ID,
+// so that it's released at the given time.
+func (em *eventMachine) restore(traceID pcommon.TraceID, releaseAt time.Time) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(traceID, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     traceRestored,
+		payload: restoredTrace{id: traceID, releaseAt: releaseAt},
+	})
+}
+
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
 	hash := hashPool.Get().(*maphash.Hash)
 	defer func() {
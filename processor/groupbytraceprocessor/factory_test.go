@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -77,3 +79,17 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 		assert.Nil(t, p)
 	}
 }
+
+func TestCreateTestProcessorWithStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := component.NewIDWithName("file_storage", "groupbytrace")
+	c.Storage = &storageID
+
+	// test
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), c, &mockProcessor{})
+
+	// verify
+	assert.NoError(t, err)
+	require.IsType(t, &groupByTraceProcessor{}, p)
+	assert.IsType(t, &persistentStorage{}, p.(*groupByTraceProcessor).st)
+}
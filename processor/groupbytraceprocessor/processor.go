@@ -77,6 +77,7 @@ func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consu
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRestored = sp.onTraceRestored
 
 	return sp
 }
@@ -94,14 +95,29 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	// traces kept by a persistent storage are released once their original deadline is reached
+	pending, err := sp.st.pending()
+	if err != nil {
+		return fmt.Errorf("couldn't restore pending traces from the storage: %w", err)
+	}
+	if len(pending) > 0 {
+		sp.logger.Info("restoring pending traces from the storage", zap.Int("num-traces", len(pending)))
+	}
+	for _, trace := range pending {
+		sp.eventMachine.restore(trace.id, trace.receivedAt.Add(sp.config.WaitDuration))
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -126,8 +142,37 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
+	sp.putInBuffer(traceID, worker)
+
+	// we have the traceID in the memory, place the spans in the storage too
+	if err := sp.addSpans(traceID, trace.td); err != nil {
+		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	}
 
-	// place the trace ID in the buffer, and check if an item had to be evicted
+	sp.scheduleRelease(traceID, sp.config.WaitDuration, worker)
+	return nil
+}
+
+func (sp *groupByTraceProcessor) onTraceRestored(trace restoredTrace, worker *eventMachineWorker) error {
+	if worker.buffer.contains(trace.id) {
+		// spans for this trace arrived before it could be restored, it's already scheduled
+		return nil
+	}
+
+	// the spans are already in the storage, we only need to track the trace ID again
+	sp.putInBuffer(trace.id, worker)
+
+	// a trace whose deadline has passed while the processor was down is released right away
+	delay := time.Until(trace.releaseAt)
+	if delay < 0 {
+		delay = 0
+	}
+	sp.scheduleRelease(trace.id, delay, worker)
+	return nil
+}
+
+// putInBuffer places the trace ID in the worker's buffer, removing the evicted trace, if any, from the storage.
+func (sp *groupByTraceProcessor) putInBuffer(traceID pcommon.TraceID, worker *eventMachineWorker) {
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
 		// delete from the storage
@@ -141,22 +186,18 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
 			zap.Stringer("traceID", evicted))
 	}
+}
 
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
-	}
-
-	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pcommon.TraceID, delay time.Duration, worker *eventMachineWorker) {
+	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", delay))
 
-	time.AfterFunc(sp.config.WaitDuration, func() {
+	time.AfterFunc(delay, func() {
 		// if the event machine has stopped, it will just discard the event
 		worker.fire(event{
 			typ:     traceExpired,
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
@@ -230,12 +271,12 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 }
 
 func (sp *groupByTraceProcessor) onTraceRemoved(traceID pcommon.TraceID) error {
-	trace, err := sp.st.delete(traceID)
+	found, err := sp.st.remove(traceID)
 	if err != nil {
 		return fmt.Errorf("couldn't delete trace %q from the storage: %w", traceID, err)
 	}
 
-	if trace == nil {
+	if !found {
 		return fmt.Errorf("trace %q not found at the storage", traceID)
 	}
 
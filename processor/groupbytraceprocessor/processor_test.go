@@ -56,9 +56,9 @@ func TestTraceIsDispatchedAfterDuration(t *testing.T) {
 	st := &mockStorage{
 		onCreateOrAppend: backing.createOrAppend,
 		onGet:            backing.get,
-		onDelete: func(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+		onRemove: func(traceID pcommon.TraceID) (bool, error) {
 			wgDeleted.Done()
-			return backing.delete(traceID)
+			return backing.remove(traceID)
 		},
 	}
 
@@ -404,8 +404,8 @@ func TestErrorFromStorageWhileRemovingTrace(t *testing.T) {
 	}
 	expectedError := errors.New("some unexpected error")
 	st := &mockStorage{
-		onDelete: func(pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
-			return nil, expectedError
+		onRemove: func(pcommon.TraceID) (bool, error) {
+			return false, expectedError
 		},
 	}
 	next := &mockProcessor{}
@@ -430,8 +430,8 @@ func TestTraceNotFoundWhileRemovingTrace(t *testing.T) {
 		NumWorkers:   4,
 	}
 	st := &mockStorage{
-		onDelete: func(pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
-			return nil, nil
+		onRemove: func(pcommon.TraceID) (bool, error) {
+			return false, nil
 		},
 	}
 	next := &mockProcessor{}
@@ -617,6 +617,8 @@ type mockStorage struct {
 	onCreateOrAppend func(pcommon.TraceID, ptrace.Traces) error
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
+	onRemove         func(pcommon.TraceID) (bool, error)
+	onPending        func() ([]pendingTrace, error)
 	onStart          func() error
 	onShutdown       func() error
 }
@@ -641,7 +643,19 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) remove(traceID pcommon.TraceID) (bool, error) {
+	if st.onRemove != nil {
+		return st.onRemove(traceID)
+	}
+	return false, nil
+}
+func (st *mockStorage) pending() ([]pendingTrace, error) {
+	if st.onPending != nil {
+		return st.onPending()
+	}
+	return nil, nil
+}
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
@@ -15,6 +15,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -35,9 +39,23 @@ type storage interface {
 	// or nil in case a trace cannot be found
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
+	// remove will remove the trace based on the given trace ID without retrieving it, returning
+	// whether the trace was found
+	remove(pcommon.TraceID) (bool, error)
+
+	// pending returns the traces that were kept by the storage before the processor started,
+	// along with the time they were first received
+	pending() ([]pendingTrace, error)
+
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// pendingTrace is a trace restored from the storage, waiting to be released
+type pendingTrace struct {
+	id         pcommon.TraceID
+	receivedAt time.Time
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -85,7 +86,21 @@ func (st *memoryStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans
 	return st.content[traceID], nil
 }
 
-func (st *memoryStorage) start() error {
+func (st *memoryStorage) remove(traceID pcommon.TraceID) (bool, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	_, ok := st.content[traceID]
+	delete(st.content, traceID)
+	return ok, nil
+}
+
+// pending always returns nil, as nothing survives a restart of the memory storage
+func (st *memoryStorage) pending() ([]pendingTrace, error) {
+	return nil, nil
+}
+
+func (st *memoryStorage) start(context.Context, component.Host) error {
 	go st.periodicMetrics()
 	return nil
 }
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// pendingTracesKey prefixes the keys holding the IDs of the traces in the storage, along with the time they were received.
+	// The index is split in shards, selected by the first byte of the trace ID, so that adding or removing a trace
+	// only rewrites a fraction of it.
+	pendingTracesKey = "pending_traces"
+	// traceKeyPrefix is prepended to the hex-encoded trace ID to build the key holding the trace's spans
+	traceKeyPrefix = "trace_"
+
+	// numIndexShards is the number of keys the index of pending traces is split into
+	numIndexShards = 64
+	// each entry of the index is made of the trace ID followed by the receive time, in nanoseconds since the epoch
+	indexEntrySize = 16 + 8
+)
+
+var (
+	errStorageNotFound  = errors.New("storage extension not found")
+	errNotStorage       = errors.New("extension is not a storage extension")
+	errCorruptedIndex   = errors.New("the index of pending traces is corrupted")
+	errStorageNotActive = errors.New("storage client not available, was the processor started?")
+)
+
+// persistentStorage keeps the spans of each trace in a storage extension, so that traces
+// waiting to be released survive a restart of the collector. Only the trace IDs and the time
+// they were first received are kept in memory. The shard of the index referencing a trace is
+// written in the same batch as the trace is created or removed, so the two never diverge, and
+// the release deadlines can be restored when the processor starts again.
+type persistentStorage struct {
+	storageID   component.ID
+	componentID component.ID
+	logger      *zap.Logger
+
+	client      extstorage.Client
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	sync.Mutex
+	index [numIndexShards]map[pcommon.TraceID]time.Time
+	// shardLocks serialize the writes of each shard of the index, so that an older
+	// version of a shard can't overwrite a newer one
+	shardLocks [numIndexShards]sync.Mutex
+
+	metricsInterval time.Duration
+	now             func() time.Time
+	stopCh          chan struct{}
+	stopped         sync.WaitGroup
+}
+
+var _ storage = (*persistentStorage)(nil)
+
+func newPersistentStorage(storageID component.ID, componentID component.ID, logger *zap.Logger) *persistentStorage {
+	st := &persistentStorage{
+		storageID:       storageID,
+		componentID:     componentID,
+		logger:          logger,
+		marshaler:       &ptrace.ProtoMarshaler{},
+		unmarshaler:     &ptrace.ProtoUnmarshaler{},
+		metricsInterval: time.Second,
+		now:             time.Now,
+		stopCh:          make(chan struct{}),
+	}
+	for i := range st.index {
+		st.index[i] = make(map[pcommon.TraceID]time.Time)
+	}
+	return st
+}
+
+// createOrAppend is only ever called by the worker owning the trace ID, so reading and writing
+// the same trace doesn't need to be synchronized.
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	if st.client == nil {
+		return errStorageNotActive
+	}
+
+	existing, err := st.read(traceID)
+	if err != nil {
+		return err
+	}
+
+	trace := ptrace.NewTraces()
+	if existing != nil {
+		existing.ResourceSpans().MoveAndAppendTo(trace.ResourceSpans())
+	}
+	newRss := ptrace.NewResourceSpansSlice()
+	td.ResourceSpans().CopyTo(newRss)
+	newRss.MoveAndAppendTo(trace.ResourceSpans())
+
+	buf, err := st.marshaler.MarshalTraces(trace)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal trace %q: %w", traceID, err)
+	}
+	if existing != nil {
+		return st.client.Set(context.Background(), traceKey(traceID), buf)
+	}
+
+	shard := shardOf(traceID)
+	st.shardLocks[shard].Lock()
+	defer st.shardLocks[shard].Unlock()
+
+	st.Lock()
+	st.index[shard][traceID] = st.now()
+	indexBuf := encodeIndex(st.index[shard])
+	st.Unlock()
+
+	err = st.client.Batch(context.Background(),
+		extstorage.SetOperation(traceKey(traceID), buf),
+		extstorage.SetOperation(shardKey(shard), indexBuf),
+	)
+	if err != nil {
+		st.Lock()
+		delete(st.index[shard], traceID)
+		st.Unlock()
+	}
+	return err
+}
+
+func (st *persistentStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotActive
+	}
+
+	trace, err := st.read(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	return resourceSpans(trace), nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotActive
+	}
+
+	trace, err := st.read(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	if _, err = st.remove(traceID); err != nil {
+		return nil, err
+	}
+	return resourceSpans(trace), nil
+}
+
+// remove deletes the trace along with its entry in the index, without reading the trace.
+func (st *persistentStorage) remove(traceID pcommon.TraceID) (bool, error) {
+	if st.client == nil {
+		return false, errStorageNotActive
+	}
+
+	shard := shardOf(traceID)
+	st.shardLocks[shard].Lock()
+	defer st.shardLocks[shard].Unlock()
+
+	st.Lock()
+	receivedAt, ok := st.index[shard][traceID]
+	if !ok {
+		st.Unlock()
+		return false, nil
+	}
+	delete(st.index[shard], traceID)
+	indexBuf := encodeIndex(st.index[shard])
+	st.Unlock()
+
+	err := st.client.Batch(context.Background(),
+		extstorage.DeleteOperation(traceKey(traceID)),
+		extstorage.SetOperation(shardKey(shard), indexBuf),
+	)
+	if err != nil {
+		st.Lock()
+		st.index[shard][traceID] = receivedAt
+		st.Unlock()
+		return false, err
+	}
+	return true, nil
+}
+
+func (st *persistentStorage) pending() ([]pendingTrace, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	var result []pendingTrace
+	for _, shard := range st.index {
+		for traceID, receivedAt := range shard {
+			result = append(result, pendingTrace{id: traceID, receivedAt: receivedAt})
+		}
+	}
+	return result, nil
+}
+
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, st.storageID, st.componentID)
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	if err = st.loadIndex(ctx); err != nil {
+		return err
+	}
+
+	st.stopped.Add(1)
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *persistentStorage) shutdown() error {
+	if st.client == nil {
+		return nil
+	}
+
+	close(st.stopCh)
+	st.stopped.Wait()
+
+	return st.client.Close(context.Background())
+}
+
+// periodicMetrics records the number of traces kept by the storage.
+func (st *persistentStorage) periodicMetrics() {
+	defer st.stopped.Done()
+
+	ticker := time.NewTicker(st.metricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			stats.Record(context.Background(), mNumTracesInMemory.M(int64(st.count())))
+		case <-st.stopCh:
+			return
+		}
+	}
+}
+
+func (st *persistentStorage) loadIndex(ctx context.Context) error {
+	ops := make([]extstorage.Operation, numIndexShards)
+	for shard := range ops {
+		ops[shard] = extstorage.GetOperation(shardKey(shard))
+	}
+	if err := st.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("couldn't read the index of pending traces: %w", err)
+	}
+
+	var index [numIndexShards]map[pcommon.TraceID]time.Time
+	for shard, op := range ops {
+		entries, err := decodeIndex(op.Value)
+		if err != nil {
+			return err
+		}
+		index[shard] = entries
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	st.index = index
+	return nil
+}
+
+func (st *persistentStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	count := 0
+	for _, shard := range st.index {
+		count += len(shard)
+	}
+	return count
+}
+
+// read returns the trace stored for the given ID, or nil if it doesn't exist.
+func (st *persistentStorage) read(traceID pcommon.TraceID) (*ptrace.Traces, error) {
+	buf, err := st.client.Get(context.Background(), traceKey(traceID))
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, nil
+	}
+
+	trace, err := st.unmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal trace %q: %w", traceID, err)
+	}
+	return &trace, nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (extstorage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, fmt.Errorf("%q: %w", storageID, errStorageNotFound)
+	}
+
+	storageExt, ok := ext.(extstorage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", storageID, errNotStorage)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func traceKey(traceID pcommon.TraceID) string {
+	return traceKeyPrefix + traceID.String()
+}
+
+func shardOf(traceID pcommon.TraceID) int {
+	return int(traceID[0]) % numIndexShards
+}
+
+func shardKey(shard int) string {
+	return fmt.Sprintf("%s_%d", pendingTracesKey, shard)
+}
+
+func resourceSpans(trace *ptrace.Traces) []ptrace.ResourceSpans {
+	result := make([]ptrace.ResourceSpans, 0, trace.ResourceSpans().Len())
+	for i := 0; i < trace.ResourceSpans().Len(); i++ {
+		result = append(result, trace.ResourceSpans().At(i))
+	}
+	return result
+}
+
+func encodeIndex(index map[pcommon.TraceID]time.Time) []byte {
+	buf := make([]byte, 0, len(index)*indexEntrySize)
+	for traceID, receivedAt := range index {
+		buf = append(buf, traceID[:]...)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(receivedAt.UnixNano()))
+	}
+	return buf
+}
+
+func decodeIndex(buf []byte) (map[pcommon.TraceID]time.Time, error) {
+	if len(buf)%indexEntrySize != 0 {
+		return nil, errCorruptedIndex
+	}
+
+	index := make(map[pcommon.TraceID]time.Time, len(buf)/indexEntrySize)
+	for i := 0; i < len(buf); i += indexEntrySize {
+		var traceID pcommon.TraceID
+		copy(traceID[:], buf[i:i+16])
+		index[traceID] = time.Unix(0, int64(binary.BigEndian.Uint64(buf[i+16:i+indexEntrySize])))
+	}
+	return index, nil
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+var processorID = component.NewID(typeStr)
+
+func TestPersistentCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	assert.Equal(t, 1, st.count())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	for _, rs := range retrieved {
+		assert.Equal(t, traceID, rs.ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+
+	missing, err := st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestPersistentDeleteTrace(t *testing.T) {
+	// prepare
+	st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestPersistentRemoveTrace(t *testing.T) {
+	// prepare
+	st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	removed, err := st.remove(traceID)
+	require.NoError(t, err)
+	removedAgain, err := st.remove(traceID)
+	require.NoError(t, err)
+
+	// verify
+	assert.True(t, removed)
+	assert.False(t, removedAgain)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestPersistentIndexIsWrittenWithTheTrace(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	received := time.Unix(1_000_000, 0)
+
+	st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	st.now = func() time.Time { return received }
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	released := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	pending := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(released, simpleTracesWithID(released)))
+	require.NoError(t, st.createOrAppend(pending, simpleTracesWithID(pending)))
+	_, err := st.remove(released)
+	require.NoError(t, err)
+
+	// test
+	// the index is read back from the same client, as if the collector crashed without shutting down
+	restarted := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	restarted.client = st.client
+	require.NoError(t, restarted.loadIndex(context.Background()))
+
+	// verify
+	restored, err := restarted.pending()
+	require.NoError(t, err)
+	assert.Equal(t, []pendingTrace{{id: pending, receivedAt: received}}, restored)
+}
+
+func TestPersistentRestorePendingTraces(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	received := time.Unix(1_000_000, 0)
+
+	st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	st.now = func() time.Time { return received }
+	require.NoError(t, st.start(context.Background(), host))
+
+	released := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	pending := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(released, simpleTracesWithID(released)))
+	require.NoError(t, st.createOrAppend(pending, simpleTracesWithID(pending)))
+	_, err := st.delete(released)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+	require.NoError(t, st.start(context.Background(), host))
+	defer func() { assert.NoError(t, st.shutdown()) }()
+
+	// verify
+	restored, err := st.pending()
+	require.NoError(t, err)
+	assert.Equal(t, []pendingTrace{{id: pending, receivedAt: received}}, restored)
+
+	retrieved, err := st.get(pending)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestPersistentStorageNotFound(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		host component.Host
+	}{
+		{
+			name: "missing",
+			host: componenttest.NewNopHost(),
+		},
+		{
+			name: "not a storage",
+			host: storagetest.NewStorageHost().WithNonStorageExtension("test"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newPersistentStorage(storagetest.NewStorageID("test"), processorID, zap.NewNop())
+			assert.Error(t, st.start(context.Background(), tt.host))
+			assert.NoError(t, st.shutdown())
+		})
+	}
+}
+
+func TestProcessorReleasesRestoredTraces(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		Storage:      &storageID,
+	}
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// the first processor shuts down before the trace can be released
+	st := newPersistentStorage(storageID, processorID, zap.NewNop())
+	st.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+	require.NoError(t, p.Start(context.Background(), host))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.Eventually(t, func() bool { return st.count() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// test
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	var receivedTraces []ptrace.Traces
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			receivedTraces = append(receivedTraces, td)
+			wg.Done()
+			return nil
+		},
+	}
+	st = newPersistentStorage(storageID, processorID, zap.NewNop())
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+
+	// verify
+	// the trace was received more than the wait duration ago, so it's released right away
+	wg.Wait()
+	require.Len(t, receivedTraces, 1)
+	assert.Equal(t, traceID, receivedTraces[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
@@ -10,6 +10,8 @@ require (
 	go.opentelemetry.io/collector/consumer v0.73.0
 	go.opentelemetry.io/collector/pdata v1.0.0-rc7
 	go.uber.org/zap v1.24.0
+	golang.org/x/sync v0.1.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -48,7 +50,6 @@ require (
 	google.golang.org/genproto v0.0.0-20230110181048-76db0878b65f // indirect
 	google.golang.org/grpc v1.53.0 // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 retract v0.65.0
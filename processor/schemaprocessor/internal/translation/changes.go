@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// renames maps an old name to a new name.
+type renames map[string]string
+
+// invert returns the mapping required to undo the renames.
+func (r renames) invert() renames {
+	inv := make(renames, len(r))
+	for from, to := range r {
+		inv[to] = from
+	}
+	return inv
+}
+
+// applyToAttributes renames the matching keys of attrs.
+// All matches are collected before any key is written so that chained
+// renames (a -> b, b -> c) within the same mapping do not cascade.
+func (r renames) applyToAttributes(attrs pcommon.Map) {
+	type move struct {
+		to  string
+		val pcommon.Value
+	}
+	var moves []move
+	for from, to := range r {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		moves = append(moves, move{to: to, val: val})
+		attrs.Remove(from)
+	}
+	for _, m := range moves {
+		m.val.CopyTo(attrs.PutEmpty(m.to))
+	}
+}
+
+// applyToName renames the signal if its name matches.
+func (r renames) applyToName(s alias.Signal) {
+	if to, ok := r[s.Name()]; ok {
+		s.SetName(to)
+	}
+}
+
+// selector restricts a change to signals with a matching name.
+// An empty selector matches every name.
+type selector map[string]struct{}
+
+func newSelector(names []string) selector {
+	s := make(selector, len(names))
+	for _, n := range names {
+		s[n] = struct{}{}
+	}
+	return s
+}
+
+func (s selector) matches(name string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	_, ok := s[name]
+	return ok
+}
+
+type scopedRenames struct {
+	renames renames
+	// targets restricts which signals the renames apply to,
+	// it is used as spans, events or metrics depending on the section.
+	targets selector
+	// spans is only used by span event changes that are scoped to spans.
+	spans selector
+}
+
+func (s scopedRenames) invert() scopedRenames {
+	return scopedRenames{renames: s.renames.invert(), targets: s.targets, spans: s.spans}
+}
+
+// namedChange is a single change of a section where both the names of the signals
+// and their attributes can be changed. Exactly one of the fields is set.
+type namedChange struct {
+	names      renames
+	attributes *scopedRenames
+}
+
+func (c namedChange) invert() namedChange {
+	if c.attributes != nil {
+		inv := c.attributes.invert()
+		return namedChange{attributes: &inv}
+	}
+	return namedChange{names: c.names.invert()}
+}
+
+// changeSet holds every modification a single schema version introduces
+// compared to its predecessor. Each slice keeps the order defined in the file,
+// since a change can depend on the result of the previous ones, e.g. an attribute
+// change selecting metrics by the name a previous change gave them.
+type changeSet struct {
+	all        []renames
+	resources  []renames
+	spans      []scopedRenames
+	spanEvents []namedChange
+	metrics    []namedChange
+	logs       []renames
+}
+
+func newChangeSet(def versionDefinition) *changeSet {
+	cs := &changeSet{}
+	for _, c := range def.All.Changes {
+		if len(c.RenameAttributes) > 0 {
+			cs.all = append(cs.all, c.RenameAttributes)
+		}
+	}
+	for _, c := range def.Resources.Changes {
+		if len(c.RenameAttributes) > 0 {
+			cs.resources = append(cs.resources, c.RenameAttributes)
+		}
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes != nil {
+			cs.spans = append(cs.spans, scopedRenames{
+				renames: c.RenameAttributes.AttributeMap,
+				targets: newSelector(c.RenameAttributes.ApplyToSpans),
+			})
+		}
+	}
+	for _, c := range def.SpanEvents.Changes {
+		if c.RenameEvents != nil {
+			cs.spanEvents = append(cs.spanEvents, namedChange{names: c.RenameEvents.NameMap})
+		}
+		if c.RenameAttributes != nil {
+			cs.spanEvents = append(cs.spanEvents, namedChange{attributes: &scopedRenames{
+				renames: c.RenameAttributes.AttributeMap,
+				targets: newSelector(c.RenameAttributes.ApplyToEvents),
+				spans:   newSelector(c.RenameAttributes.ApplyToSpans),
+			}})
+		}
+	}
+	for _, c := range def.Metrics.Changes {
+		if len(c.RenameMetrics) > 0 {
+			cs.metrics = append(cs.metrics, namedChange{names: c.RenameMetrics})
+		}
+		if c.RenameAttributes != nil {
+			cs.metrics = append(cs.metrics, namedChange{attributes: &scopedRenames{
+				renames: c.RenameAttributes.AttributeMap,
+				targets: newSelector(c.RenameAttributes.ApplyToMetrics),
+			}})
+		}
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			cs.logs = append(cs.logs, c.RenameAttributes.AttributeMap)
+		}
+	}
+	return cs
+}
+
+// invert returns the change set that undoes cs, used when downgrading.
+// The order of the changes is reversed and every mapping inverted.
+func (cs *changeSet) invert() *changeSet {
+	return &changeSet{
+		all:        invertAll(cs.all),
+		resources:  invertAll(cs.resources),
+		spans:      invertAllScoped(cs.spans),
+		spanEvents: invertAllNamed(cs.spanEvents),
+		metrics:    invertAllNamed(cs.metrics),
+		logs:       invertAll(cs.logs),
+	}
+}
+
+func invertAll(in []renames) []renames {
+	out := make([]renames, 0, len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		out = append(out, in[i].invert())
+	}
+	return out
+}
+
+func invertAllScoped(in []scopedRenames) []scopedRenames {
+	out := make([]scopedRenames, 0, len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		out = append(out, in[i].invert())
+	}
+	return out
+}
+
+func invertAllNamed(in []namedChange) []namedChange {
+	out := make([]namedChange, 0, len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		out = append(out, in[i].invert())
+	}
+	return out
+}
+
+// applyAll applies the changes that are defined for every signal.
+func (cs *changeSet) applyAll(attrs pcommon.Map) {
+	for _, r := range cs.all {
+		r.applyToAttributes(attrs)
+	}
+}
+
+func (cs *changeSet) applyResource(res pcommon.Resource) {
+	cs.applyAll(res.Attributes())
+	for _, r := range cs.resources {
+		r.applyToAttributes(res.Attributes())
+	}
+}
+
+func (cs *changeSet) applySpans(spans ptrace.SpanSlice) {
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		cs.applyAll(span.Attributes())
+		for _, r := range cs.spans {
+			if r.targets.matches(span.Name()) {
+				r.renames.applyToAttributes(span.Attributes())
+			}
+		}
+		for j := 0; j < span.Events().Len(); j++ {
+			cs.applySpanEvent(span, span.Events().At(j))
+		}
+	}
+}
+
+func (cs *changeSet) applySpanEvent(span ptrace.Span, event ptrace.SpanEvent) {
+	cs.applyAll(event.Attributes())
+	for _, c := range cs.spanEvents {
+		if c.attributes == nil {
+			c.names.applyToName(event)
+			continue
+		}
+		if c.attributes.spans.matches(span.Name()) && c.attributes.targets.matches(event.Name()) {
+			c.attributes.renames.applyToAttributes(event.Attributes())
+		}
+	}
+}
+
+func (cs *changeSet) applyMetrics(metrics pmetric.MetricSlice) {
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		rangeDataPointAttributes(metric, cs.applyAll)
+		for _, c := range cs.metrics {
+			if c.attributes == nil {
+				c.names.applyToName(metric)
+				continue
+			}
+			if c.attributes.targets.matches(metric.Name()) {
+				rangeDataPointAttributes(metric, c.attributes.renames.applyToAttributes)
+			}
+		}
+	}
+}
+
+func (cs *changeSet) applyLogs(logs plog.LogRecordSlice) {
+	for i := 0; i < logs.Len(); i++ {
+		attrs := logs.At(i).Attributes()
+		cs.applyAll(attrs)
+		for _, r := range cs.logs {
+			r.applyToAttributes(attrs)
+		}
+	}
+}
+
+// rangeDataPointAttributes calls fn with the attributes of every data point of metric.
+func rangeDataPointAttributes(metric pmetric.Metric, fn func(pcommon.Map)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
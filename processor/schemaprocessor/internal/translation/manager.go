@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// retryInterval is how long a failed lookup is remembered before
+// the schema file is requested again, to avoid fetching it for every batch.
+const retryInterval = time.Minute
+
+// Manager is responsible for ensuring that schema files are fetched only once
+// and that the resulting translations are shared between the pipelines.
+type Manager interface {
+	// RequestTranslation returns the translation parsed from the
+	// schema file published at schemaURL, fetching it if not cached.
+	RequestTranslation(ctx context.Context, schemaURL string) (*Translation, error)
+}
+
+type cacheEntry struct {
+	translation *Translation
+	err         error
+	fetchedAt   time.Time
+}
+
+type manager struct {
+	log      *zap.Logger
+	provider Provider
+	now      func() time.Time
+
+	rw    sync.RWMutex
+	cache map[string]*cacheEntry
+	// fetches makes concurrent requests for a schema url that isn't cached
+	// yet wait for a single lookup instead of each fetching the file.
+	fetches singleflight.Group
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a Manager that uses provider to look up schema files.
+func NewManager(log *zap.Logger, provider Provider) Manager {
+	return &manager{
+		log:      log,
+		provider: provider,
+		now:      time.Now,
+		cache:    make(map[string]*cacheEntry),
+	}
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) (*Translation, error) {
+	if entry, ok := m.cached(schemaURL); ok {
+		return entry.translation, entry.err
+	}
+
+	tr, err, _ := m.fetches.Do(schemaURL, func() (interface{}, error) {
+		// a lookup that completed since the cache was checked doesn't need to be repeated
+		if entry, ok := m.cached(schemaURL); ok {
+			return entry.translation, entry.err
+		}
+		m.log.Debug("Fetching schema translation", zap.String("schema-url", schemaURL))
+		tr, err := m.fetch(ctx, schemaURL)
+		if err != nil {
+			m.log.Warn("Unable to fetch schema translation", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
+
+		m.rw.Lock()
+		defer m.rw.Unlock()
+		m.cache[schemaURL] = &cacheEntry{translation: tr, err: err, fetchedAt: m.now()}
+		return tr, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tr.(*Translation), nil
+}
+
+// cached returns the cache entry of schemaURL, if it's still valid.
+func (m *manager) cached(schemaURL string) (*cacheEntry, bool) {
+	m.rw.RLock()
+	defer m.rw.RUnlock()
+	entry, ok := m.cache[schemaURL]
+	if !ok || (entry.err != nil && m.now().Sub(entry.fetchedAt) >= retryInterval) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (m *manager) fetch(ctx context.Context, schemaURL string) (*Translation, error) {
+	content, err := m.provider.Lookup(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	tr, err := NewTranslation(content)
+	if err != nil {
+		return nil, err
+	}
+	if family, version, err := GetFamilyAndVersion(schemaURL); err == nil && !tr.SupportedVersion(version) {
+		return nil, fmt.Errorf("schema file for %s/%s: %w", family, version, ErrUnsupportedVersion)
+	}
+	return tr, nil
+}
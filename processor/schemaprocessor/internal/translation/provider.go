@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+var ErrUnsupportedScheme = errors.New("unsupported schema url scheme")
+
+// Provider allows for collector extensions to be used to look up schema definitions.
+type Provider interface {
+	// Lookup returns the content of the schema file published at schemaURL.
+	// The caller is responsible for closing the returned reader.
+	Lookup(ctx context.Context, schemaURL string) (io.ReadCloser, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider creates a Provider that fetches schema files over http(s).
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Lookup(ctx context.Context, schemaURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("invalid status code returned %d for %q", resp.StatusCode, schemaURL)
+	}
+	return resp.Body, nil
+}
+
+type fileProvider struct{}
+
+var _ Provider = (*fileProvider)(nil)
+
+// NewFileProvider creates a Provider that reads schema files referenced by file URLs.
+func NewFileProvider() Provider {
+	return fileProvider{}
+}
+
+func (fileProvider) Lookup(_ context.Context, schemaURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("%q: %w", schemaURL, ErrUnsupportedScheme)
+	}
+	return os.Open(u.Path)
+}
+
+type schemeProvider map[string]Provider
+
+var _ Provider = (schemeProvider)(nil)
+
+// NewSchemeProvider dispatches lookups to the http(s) or file
+// provider depending on the scheme of the schema url.
+func NewSchemeProvider(client *http.Client) Provider {
+	hp := NewHTTPProvider(client)
+	return schemeProvider{
+		"http":  hp,
+		"https": hp,
+		"file":  NewFileProvider(),
+	}
+}
+
+func (sp schemeProvider) Lookup(ctx context.Context, schemaURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := sp[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", schemaURL, ErrUnsupportedScheme)
+	}
+	return p.Lookup(ctx, schemaURL)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// supportedFileFormat is the only schema file format
+// that the processor currently understands.
+const supportedFileFormat = "1.0.0"
+
+var ErrUnsupportedFileFormat = errors.New("unsupported schema file format")
+
+// The types below mirror the schema file format described in
+// https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.0.0/
+
+type schemaFile struct {
+	FileFormat string                       `yaml:"file_format"`
+	SchemaURL  string                       `yaml:"schema_url"`
+	Versions   map[string]versionDefinition `yaml:"versions"`
+}
+
+type versionDefinition struct {
+	All        attributeSection `yaml:"all"`
+	Resources  attributeSection `yaml:"resources"`
+	Spans      spanSection      `yaml:"spans"`
+	SpanEvents spanEventSection `yaml:"span_events"`
+	Metrics    metricSection    `yaml:"metrics"`
+	Logs       logSection       `yaml:"logs"`
+}
+
+type attributeSection struct {
+	Changes []struct {
+		RenameAttributes map[string]string `yaml:"rename_attributes"`
+	} `yaml:"changes"`
+}
+
+type spanSection struct {
+	Changes []struct {
+		RenameAttributes *struct {
+			AttributeMap map[string]string `yaml:"attribute_map"`
+			ApplyToSpans []string          `yaml:"apply_to_spans"`
+		} `yaml:"rename_attributes"`
+	} `yaml:"changes"`
+}
+
+type spanEventSection struct {
+	Changes []struct {
+		RenameEvents *struct {
+			NameMap map[string]string `yaml:"name_map"`
+		} `yaml:"rename_events"`
+		RenameAttributes *struct {
+			AttributeMap  map[string]string `yaml:"attribute_map"`
+			ApplyToSpans  []string          `yaml:"apply_to_spans"`
+			ApplyToEvents []string          `yaml:"apply_to_events"`
+		} `yaml:"rename_attributes"`
+	} `yaml:"changes"`
+}
+
+type metricSection struct {
+	Changes []struct {
+		RenameMetrics    map[string]string `yaml:"rename_metrics"`
+		RenameAttributes *struct {
+			AttributeMap   map[string]string `yaml:"attribute_map"`
+			ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+		} `yaml:"rename_attributes"`
+	} `yaml:"changes"`
+}
+
+type logSection struct {
+	Changes []struct {
+		RenameAttributes *struct {
+			AttributeMap map[string]string `yaml:"attribute_map"`
+		} `yaml:"rename_attributes"`
+	} `yaml:"changes"`
+}
+
+// parseSchemaFile reads and validates a schema file.
+func parseSchemaFile(r io.Reader) (*schemaFile, error) {
+	var sf schemaFile
+	if err := yaml.NewDecoder(r).Decode(&sf); err != nil {
+		return nil, fmt.Errorf("unable to decode schema file: %w", err)
+	}
+	if sf.FileFormat != supportedFileFormat {
+		return nil, fmt.Errorf("file format %q: %w", sf.FileFormat, ErrUnsupportedFileFormat)
+	}
+	if _, _, err := GetFamilyAndVersion(sf.SchemaURL); err != nil {
+		return nil, fmt.Errorf("invalid schema url %q: %w", sf.SchemaURL, err)
+	}
+	return &sf, nil
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var ErrUnsupportedVersion = errors.New("version not defined by schema")
+
+// Translation holds the parsed changes of a schema family up to
+// the version of the schema file it was created from.
+type Translation struct {
+	family   string
+	latest   *Version
+	versions []*Version
+	upgrades map[Version]*changeSet
+	reverts  map[Version]*changeSet
+}
+
+// NewTranslation parses the schema file read from r.
+func NewTranslation(r io.Reader) (*Translation, error) {
+	sf, err := parseSchemaFile(r)
+	if err != nil {
+		return nil, err
+	}
+	family, latest, err := GetFamilyAndVersion(sf.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	t := &Translation{
+		family:   family,
+		latest:   latest,
+		upgrades: make(map[Version]*changeSet, len(sf.Versions)),
+		reverts:  make(map[Version]*changeSet, len(sf.Versions)),
+	}
+	for s, def := range sf.Versions {
+		v, err := NewVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		cs := newChangeSet(def)
+		t.versions = append(t.versions, v)
+		t.upgrades[*v] = cs
+		t.reverts[*v] = cs.invert()
+	}
+	sort.Slice(t.versions, func(i, j int) bool {
+		return t.versions[i].LessThan(t.versions[j])
+	})
+	if len(t.versions) == 0 || !t.versions[len(t.versions)-1].Equal(latest) {
+		return nil, fmt.Errorf("schema url version %s must be the highest defined version: %w", latest, ErrInvalidVersion)
+	}
+	return t, nil
+}
+
+// Family returns the schema family the translation belongs to.
+func (t *Translation) Family() string {
+	return t.family
+}
+
+// SupportedVersion checks if v is defined as part of the translation.
+func (t *Translation) SupportedVersion(v *Version) bool {
+	_, ok := t.upgrades[*v]
+	return ok
+}
+
+// ChangesBetween returns the changes that convert signals
+// published with the from version to the to version.
+// Upgrading applies every version after from up to and including to,
+// downgrading reverts those versions in reverse order.
+func (t *Translation) ChangesBetween(from, to *Version) (ChangeList, error) {
+	for _, v := range []*Version{from, to} {
+		if !t.SupportedVersion(v) {
+			return nil, fmt.Errorf("%s/%s: %w", t.family, v, ErrUnsupportedVersion)
+		}
+	}
+	var changes ChangeList
+	switch {
+	case from.LessThan(to):
+		for _, v := range t.versions {
+			if v.GreaterThan(from) && !v.GreaterThan(to) {
+				changes = append(changes, t.upgrades[*v])
+			}
+		}
+	case from.GreaterThan(to):
+		for i := len(t.versions) - 1; i >= 0; i-- {
+			if v := t.versions[i]; v.GreaterThan(to) && !v.GreaterThan(from) {
+				changes = append(changes, t.reverts[*v])
+			}
+		}
+	}
+	return changes, nil
+}
+
+// ChangeList is an ordered set of version changes.
+type ChangeList []*changeSet
+
+// ApplyResource updates the resource attributes.
+func (cl ChangeList) ApplyResource(res pcommon.Resource) {
+	for _, cs := range cl {
+		cs.applyResource(res)
+	}
+}
+
+// ApplySpans updates the span attributes as well as
+// the names and attributes of their events.
+func (cl ChangeList) ApplySpans(spans ptrace.SpanSlice) {
+	for _, cs := range cl {
+		cs.applySpans(spans)
+	}
+}
+
+// ApplyMetrics updates the metric names and their data point attributes.
+func (cl ChangeList) ApplyMetrics(metrics pmetric.MetricSlice) {
+	for _, cs := range cl {
+		cs.applyMetrics(metrics)
+	}
+}
+
+// ApplyLogs updates the log record attributes.
+func (cl ChangeList) ApplyLogs(logs plog.LogRecordSlice) {
+	for _, cs := range cl {
+		cs.applyLogs(logs)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap/zaptest"
+)
+
+const multiVersionSchema = `
+file_format: 1.0.0
+schema_url: https://example.com/schemas/1.2.0
+versions:
+  1.2.0:
+    spans:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              db.name: db.namespace
+    span_events:
+      changes:
+        - rename_events:
+            name_map: {exception: error}
+        - rename_attributes:
+            attribute_map:
+              message: error.message
+            apply_to_events:
+              - error
+  1.1.0:
+    all:
+      changes:
+        - rename_attributes:
+            db: db.name
+  1.0.0:
+`
+
+func mustVersion(t *testing.T, s string) *Version {
+	v, err := NewVersion(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestNewTranslation(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	tr, err := NewTranslation(f)
+	require.NoError(t, err, "Must be able to parse the example schema file")
+	assert.Equal(t, "https://opentelemetry.io/schemas", tr.Family())
+	assert.True(t, tr.SupportedVersion(mustVersion(t, "1.0.0")))
+	assert.True(t, tr.SupportedVersion(mustVersion(t, "1.1.0")))
+	assert.False(t, tr.SupportedVersion(mustVersion(t, "1.2.0")))
+
+	_, err = NewTranslation(strings.NewReader("file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\n"))
+	assert.ErrorIs(t, err, ErrUnsupportedFileFormat)
+
+	_, err = NewTranslation(strings.NewReader("file_format: 1.0.0\nschema_url: https://example.com/schemas/1.1.0\nversions:\n  1.0.0:\n"))
+	assert.ErrorIs(t, err, ErrInvalidVersion, "Must reject files where the url version is not the latest")
+}
+
+func TestChangesBetween(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewTranslation(strings.NewReader(multiVersionSchema))
+	require.NoError(t, err)
+
+	newSpans := func() ptrace.SpanSlice {
+		spans := ptrace.NewSpanSlice()
+		span := spans.AppendEmpty()
+		span.Attributes().PutStr("db", "orders")
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr("message", "boom")
+		return spans
+	}
+
+	spans := newSpans()
+	changes, err := tr.ChangesBetween(mustVersion(t, "1.0.0"), mustVersion(t, "1.2.0"))
+	require.NoError(t, err)
+	changes.ApplySpans(spans)
+	assert.Equal(t, map[string]any{"db.namespace": "orders"}, spans.At(0).Attributes().AsRaw())
+	assert.Equal(t, "error", spans.At(0).Events().At(0).Name())
+	assert.Equal(t, map[string]any{"error.message": "boom"}, spans.At(0).Events().At(0).Attributes().AsRaw())
+
+	changes, err = tr.ChangesBetween(mustVersion(t, "1.2.0"), mustVersion(t, "1.0.0"))
+	require.NoError(t, err)
+	changes.ApplySpans(spans)
+	assert.Equal(t, newSpans(), spans, "Must restore the original signal when downgrading")
+
+	changes, err = tr.ChangesBetween(mustVersion(t, "1.1.0"), mustVersion(t, "1.1.0"))
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = tr.ChangesBetween(mustVersion(t, "0.9.0"), mustVersion(t, "1.1.0"))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+const interleavedSchema = `
+file_format: 1.0.0
+schema_url: https://example.com/schemas/1.1.0
+versions:
+  1.1.0:
+    metrics:
+      changes:
+        - rename_metrics:
+            http.duration: http.server.duration
+        - rename_attributes:
+            attribute_map:
+              status: http.status_code
+            apply_to_metrics:
+              - http.server.duration
+        - rename_metrics:
+            http.server.duration: http.server.request.duration
+        - rename_attributes:
+            attribute_map:
+              http.status_code: http.response.status_code
+            apply_to_metrics:
+              - http.server.request.duration
+  1.0.0:
+`
+
+func TestChangesAreAppliedInOrder(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewTranslation(strings.NewReader(interleavedSchema))
+	require.NoError(t, err)
+
+	newMetrics := func() pmetric.MetricSlice {
+		metrics := pmetric.NewMetricSlice()
+		m := metrics.AppendEmpty()
+		m.SetName("http.duration")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutInt("status", 200)
+		return metrics
+	}
+
+	metrics := newMetrics()
+	changes, err := tr.ChangesBetween(mustVersion(t, "1.0.0"), mustVersion(t, "1.1.0"))
+	require.NoError(t, err)
+	changes.ApplyMetrics(metrics)
+	assert.Equal(t, "http.server.request.duration", metrics.At(0).Name())
+	assert.Equal(t, map[string]any{"http.response.status_code": int64(200)}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	changes, err = tr.ChangesBetween(mustVersion(t, "1.1.0"), mustVersion(t, "1.0.0"))
+	require.NoError(t, err)
+	changes.ApplyMetrics(metrics)
+	assert.Equal(t, newMetrics(), metrics, "Must restore the original signal when downgrading")
+}
+
+func TestRenamesDoNotCascade(t *testing.T) {
+	t.Parallel()
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("a", "1")
+	attrs.PutStr("b", "2")
+	renames{"a": "b", "b": "c"}.applyToAttributes(attrs)
+	assert.Equal(t, map[string]any{"b": "1", "c": "2"}, attrs.AsRaw())
+}
+
+type countingProvider struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (p *countingProvider) Lookup(context.Context, string) (io.ReadCloser, error) {
+	p.calls.Add(1)
+	if p.release != nil {
+		<-p.release
+	}
+	return io.NopCloser(strings.NewReader(multiVersionSchema)), nil
+}
+
+func TestManagerCachesTranslations(t *testing.T) {
+	t.Parallel()
+
+	p := &countingProvider{}
+	m := NewManager(zaptest.NewLogger(t), p)
+
+	for i := 0; i < 3; i++ {
+		tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.2.0")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/schemas", tr.Family())
+	}
+	assert.Equal(t, int32(1), p.calls.Load(), "Must only fetch the schema file once")
+
+	_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.3.0")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion, "Must reject schema files that do not define the requested version")
+}
+
+func TestManagerDeduplicatesConcurrentFetches(t *testing.T) {
+	t.Parallel()
+
+	p := &countingProvider{release: make(chan struct{})}
+	m := NewManager(zaptest.NewLogger(t), p)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas/1.2.0")
+			assert.NoError(t, err)
+			assert.NotNil(t, tr)
+		}()
+	}
+	require.Eventually(t, func() bool { return p.calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	// give the other requests the time to wait on the lookup in progress
+	time.Sleep(50 * time.Millisecond)
+	close(p.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), p.calls.Load(), "Must only fetch the schema file once")
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	path, err := filepath.Abs(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err)
+
+	content, err := NewSchemeProvider(nil).Lookup(context.Background(), "file://"+filepath.ToSlash(path))
+	require.NoError(t, err)
+	defer content.Close()
+	_, err = NewTranslation(content)
+	assert.NoError(t, err)
+
+	_, err = NewSchemeProvider(nil).Lookup(context.Background(), "ftp://example.com/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrUnsupportedScheme)
+}
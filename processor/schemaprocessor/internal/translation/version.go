@@ -51,6 +51,7 @@ func ReadVersionFromPath(p string) (*Version, error) {
 }
 
 // GetFamilyAndVersion takes a schemaURL and separates the family from the identifier.
+// The schemaURL must either be an http(s) URL or a file URL.
 func GetFamilyAndVersion(schemaURL string) (family string, version *Version, err error) {
 	u, err := url.Parse(schemaURL)
 	if err != nil {
@@ -62,11 +63,15 @@ func GetFamilyAndVersion(schemaURL string) (family string, version *Version, err
 	}
 
 	u.Path = path.Dir(u.Path)
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return "", nil, fmt.Errorf("must use http(s): %w", ErrInvalidFamily)
-	}
-	if u.Host == "" {
-		return "", nil, fmt.Errorf("must have a host name: %w", ErrInvalidFamily)
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host == "" {
+			return "", nil, fmt.Errorf("must have a host name: %w", ErrInvalidFamily)
+		}
+	case "file":
+		// Local schema files are identified by their path only.
+	default:
+		return "", nil, fmt.Errorf("must use http(s) or file: %w", ErrInvalidFamily)
 	}
 
 	return u.String(), version, err
@@ -88,6 +88,33 @@ func TestParsingVersionFromPath(t *testing.T) {
 	}
 }
 
+func TestGetFamilyAndVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario  string
+		schemaURL string
+		family    string
+		ident     *Version
+		err       error
+	}{
+		{scenario: "https schema url", schemaURL: "https://opentelemetry.io/schemas/1.9.0", family: "https://opentelemetry.io/schemas", ident: &Version{Major: 1, Minor: 9, Patch: 0}},
+		{scenario: "file schema url", schemaURL: "file:///etc/otel/schemas/1.2.0", family: "file:///etc/otel/schemas", ident: &Version{Major: 1, Minor: 2, Patch: 0}},
+		{scenario: "unsupported scheme", schemaURL: "ftp://opentelemetry.io/schemas/1.9.0", err: ErrInvalidFamily},
+		{scenario: "missing host", schemaURL: "https:///schemas/1.9.0", err: ErrInvalidFamily},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scenario, func(t *testing.T) {
+			family, ident, err := GetFamilyAndVersion(tc.schemaURL)
+
+			assert.ErrorIs(t, err, tc.err, "Must be the expected error")
+			assert.Equal(t, tc.family, family)
+			assert.Equal(t, tc.ident, ident)
+		})
+	}
+}
+
 func TestVersionDifferences(t *testing.T) {
 	t.Parallel()
 
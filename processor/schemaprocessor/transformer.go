@@ -17,18 +17,28 @@ package schemaprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	// targets maps a schema family to the version signals are translated to.
+	targets  map[string]*translation.Version
+	prefetch []string
+	log      *zap.Logger
+
+	httpSettings confighttp.HTTPClientSettings
+	telemetry    component.TelemetrySettings
+	manager      translation.Manager
 }
 
 func newTransformer(
@@ -40,29 +50,147 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	targets := make(map[string]*translation.Version, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		family, version, err := translation.GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		targets[family] = version
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:          set.Logger,
+		targets:      targets,
+		prefetch:     append(append([]string{}, cfg.Prefetch...), cfg.Targets...),
+		httpSettings: cfg.HTTPClientSettings,
+		telemetry:    set.TelemetrySettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		changes, target, ok := t.changesFor(ctx, rl.SchemaUrl())
+		if ok {
+			changes.ApplyResource(rl.Resource())
+			rl.SetSchemaUrl(target)
+		}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			scopeChanges, scopeOK := changes, ok
+			if sl.SchemaUrl() != "" {
+				var scopeTarget string
+				scopeChanges, scopeTarget, scopeOK = t.changesFor(ctx, sl.SchemaUrl())
+				if scopeOK {
+					sl.SetSchemaUrl(scopeTarget)
+				}
+			}
+			if scopeOK {
+				scopeChanges.ApplyLogs(sl.LogRecords())
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		changes, target, ok := t.changesFor(ctx, rm.SchemaUrl())
+		if ok {
+			changes.ApplyResource(rm.Resource())
+			rm.SetSchemaUrl(target)
+		}
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			scopeChanges, scopeOK := changes, ok
+			if sm.SchemaUrl() != "" {
+				var scopeTarget string
+				scopeChanges, scopeTarget, scopeOK = t.changesFor(ctx, sm.SchemaUrl())
+				if scopeOK {
+					sm.SetSchemaUrl(scopeTarget)
+				}
+			}
+			if scopeOK {
+				scopeChanges.ApplyMetrics(sm.Metrics())
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		changes, target, ok := t.changesFor(ctx, rs.SchemaUrl())
+		if ok {
+			changes.ApplyResource(rs.Resource())
+			rs.SetSchemaUrl(target)
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scopeChanges, scopeOK := changes, ok
+			if ss.SchemaUrl() != "" {
+				var scopeTarget string
+				scopeChanges, scopeTarget, scopeOK = t.changesFor(ctx, ss.SchemaUrl())
+				if scopeOK {
+					ss.SetSchemaUrl(scopeTarget)
+				}
+			}
+			if scopeOK {
+				scopeChanges.ApplySpans(ss.Spans())
+			}
+		}
+	}
 	return td, nil
 }
 
-// start will load the remote file definition if it isn't already cached
-// and resolve the schema translation file
+// changesFor returns the changes required to translate signals published with
+// schemaURL to the configured target of its family, along with the target schema URL.
+// Signals without a schema URL, of a family that is not targeted, or whose
+// schema file can not be resolved are passed through unchanged.
+func (t *transformer) changesFor(ctx context.Context, schemaURL string) (translation.ChangeList, string, bool) {
+	if schemaURL == "" || len(t.targets) == 0 {
+		return nil, "", false
+	}
+	family, version, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, "", false
+	}
+	target, ok := t.targets[family]
+	if !ok || version.Equal(target) {
+		return nil, "", false
+	}
+
+	// The schema file of the newer version contains the changes of all older ones.
+	latest := version
+	if target.GreaterThan(version) {
+		latest = target
+	}
+	tr, err := t.manager.RequestTranslation(ctx, fmt.Sprint(family, "/", latest))
+	if err != nil {
+		return nil, "", false
+	}
+	changes, err := tr.ChangesBetween(version, target)
+	if err != nil {
+		t.log.Debug("Unable to translate schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil, "", false
+	}
+	return changes, fmt.Sprint(family, "/", target), true
+}
+
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.httpSettings.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	t.manager = translation.NewManager(t.log, translation.NewSchemeProvider(client))
+
+	for _, schemaURL := range t.prefetch {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		// Failures are logged by the manager and retried once signals require the translation.
+		_, _ = t.manager.RequestTranslation(ctx, schemaURL)
 	}
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -109,3 +110,100 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func newTargetedTransformer(t *testing.T, target string) *transformer {
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{target}
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), nil))
+	return trans
+}
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(srv.Close)
+
+	t.Run("upgrade traces", func(t *testing.T) {
+		trans := newTargetedTransformer(t, srv.URL+"/1.1.0")
+
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(srv.URL + "/1.0.0")
+		rs.Resource().Attributes().PutStr("k8s.pod.name", "checkout-0")
+		s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().PutStr("peer.service", "cart")
+		s.Events().AppendEmpty().SetName("stacktrace")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err)
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, srv.URL+"/1.1.0", rs.SchemaUrl())
+		assert.Equal(t, map[string]any{"kubernetes.pod.name": "checkout-0"}, rs.Resource().Attributes().AsRaw())
+		s = rs.ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, map[string]any{"peer.service.name": "cart"}, s.Attributes().AsRaw())
+		assert.Equal(t, "stack_trace", s.Events().At(0).Name())
+	})
+
+	t.Run("downgrade metrics", func(t *testing.T) {
+		trans := newTargetedTransformer(t, srv.URL+"/1.0.0")
+
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl(srv.URL + "/1.1.0")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("kubernetes.node.name", "node-1")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err)
+
+		rm = out.ResourceMetrics().At(0)
+		assert.Equal(t, srv.URL+"/1.0.0", rm.SchemaUrl())
+		m = rm.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "container.cpu.usage.total", m.Name())
+		assert.Equal(t, map[string]any{"k8s.node.name": "node-1"}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("scope schema url takes precedence", func(t *testing.T) {
+		trans := newTargetedTransformer(t, srv.URL+"/1.1.0")
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.SetSchemaUrl(srv.URL + "/1.0.0")
+		sl.LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "java")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err)
+
+		rl = out.ResourceLogs().At(0)
+		assert.Empty(t, rl.SchemaUrl(), "Must not set a schema url on the resource")
+		sl = rl.ScopeLogs().At(0)
+		assert.Equal(t, srv.URL+"/1.1.0", sl.SchemaUrl())
+		assert.Equal(t, map[string]any{"process.executable.name": "java"}, sl.LogRecords().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("untargeted family", func(t *testing.T) {
+		trans := newTargetedTransformer(t, srv.URL+"/1.1.0")
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "java")
+		expect := plog.NewLogs()
+		in.CopyTo(expect)
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err)
+		assert.Equal(t, expect, out, "Must not modify signals of other schema families")
+	})
+}
@@ -16,6 +16,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // PolicyType indicates the type of sampling policy.
@@ -49,6 +51,11 @@ const (
 	// BooleanAttribute sample traces having an attribute, of type bool, that matches
 	// the specified boolean value [true|false].
 	BooleanAttribute PolicyType = "boolean_attribute"
+	// OTTLCondition sample traces having spans or span events matching the given OTTL conditions.
+	OTTLCondition PolicyType = "ottl_condition"
+	// Drop allows defining a Drop policy, combining the other policies in one. Traces matching every
+	// sub-policy are never sampled, regardless of the decisions of the other policies.
+	Drop PolicyType = "drop"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -76,6 +83,8 @@ type sharedPolicyCfg struct {
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
 	// Configs for boolean attribute filter sampling policy evaluator.
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
+	// Configs for OTTL condition filter sampling policy evaluator.
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -102,6 +111,12 @@ type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
 
+// DropCfg holds the configurable settings to create a drop sampling policy evaluator.
+// The sub-policies are combined like for the and policy.
+type DropCfg struct {
+	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"drop_sub_policy"`
+}
+
 // CompositeCfg holds the configurable settings to create a composite
 // sampling policy evaluator.
 type CompositeCfg struct {
@@ -125,6 +140,8 @@ type PolicyCfg struct {
 	CompositeCfg CompositeCfg `mapstructure:"composite"`
 	// Configs for defining and policy
 	AndCfg AndCfg `mapstructure:"and"`
+	// Configs for defining drop policy
+	DropCfg DropCfg `mapstructure:"drop"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -208,6 +225,33 @@ type BooleanAttributeCfg struct {
 	Value bool `mapstructure:"value"`
 }
 
+// OTTLConditionCfg holds the configurable settings to create an OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// ErrorMode determines how errors returned while evaluating the conditions are handled,
+	// either "propagate" (default) or "ignore".
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+	// SpanConditions are the OTTL conditions evaluated against each span, using the ottlspan context.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are the OTTL conditions evaluated against each span event, using the ottlspanevent context.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+	// Match indicates how many of the conditions must be met for the trace to be sampled.
+	// With "any" (default), the trace is sampled as soon as one condition is met by a span or span event.
+	// With "all", every condition must be met by at least one span or span event of the trace.
+	Match string `mapstructure:"match"`
+}
+
+// DecisionCacheCfg holds the configurable settings of the caches remembering the trace IDs
+// for which a sampling decision has been taken.
+type DecisionCacheCfg struct {
+	// SampledCacheSize is the number of trace IDs of sampled traces to remember, so that
+	// spans arriving after the trace left the memory are still sampled. Zero disables the cache.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the number of trace IDs of traces that weren't sampled to remember, so that
+	// spans arriving after the trace left the memory are still dropped. Zero disables the cache.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -219,6 +263,9 @@ type Config struct {
 	// ExpectedNewTracesPerSec sets the expected number of new traces sending to the tail sampling processor
 	// per second. This helps with allocating data structures with closer to actual usage size.
 	ExpectedNewTracesPerSec uint64 `mapstructure:"expected_new_traces_per_sec"`
+	// DecisionCache configures the caches of trace IDs for which a decision has been taken.
+	// Late spans of a trace found in one of the caches get the decision originally taken for the trace.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache remembers the trace IDs for which a sampling decision has been taken,
+// so that spans arriving after the trace left the memory are handled consistently.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Cache is a set of trace IDs. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Contains returns true if the trace ID is in the cache.
+	Contains(id pcommon.TraceID) bool
+	// Put adds the trace ID to the cache, possibly evicting the least recently used one.
+	Put(id pcommon.TraceID)
+}
+
+// New returns a cache keeping at most size trace IDs, or a cache that doesn't keep anything
+// if size isn't positive.
+func New(size int) Cache {
+	if size <= 0 {
+		return NewNop()
+	}
+	return NewLRU(size)
+}
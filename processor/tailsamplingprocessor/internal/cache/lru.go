@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type lruCache struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+var _ Cache = (*lruCache)(nil)
+
+// NewLRU returns a cache keeping the size most recently used trace IDs.
+func NewLRU(size int) Cache {
+	return &lruCache{cache: lru.New(size)}
+}
+
+func (c *lruCache) Contains(id pcommon.TraceID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.cache.Get(id)
+	return ok
+}
+
+func (c *lruCache) Put(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(id, struct{}{})
+}
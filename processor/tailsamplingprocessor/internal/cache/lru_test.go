@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := New(2)
+
+	id1 := pcommon.TraceID([16]byte{1})
+	id2 := pcommon.TraceID([16]byte{2})
+	id3 := pcommon.TraceID([16]byte{3})
+
+	c.Put(id1)
+	c.Put(id2)
+	assert.True(t, c.Contains(id1))
+	assert.True(t, c.Contains(id2))
+
+	// id1 was used more recently than id2, which gets evicted
+	assert.True(t, c.Contains(id1))
+	c.Put(id3)
+	assert.True(t, c.Contains(id1))
+	assert.False(t, c.Contains(id2))
+	assert.True(t, c.Contains(id3))
+}
+
+func TestNopCache(t *testing.T) {
+	c := New(0)
+
+	id := pcommon.TraceID([16]byte{1})
+	c.Put(id)
+	assert.False(t, c.Contains(id))
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+const (
+	// MatchAny samples a trace as soon as one of the conditions is met.
+	MatchAny = "any"
+	// MatchAll samples a trace only when every condition is met by at least one span or span event.
+	MatchAll = "all"
+)
+
+var errNoOTTLConditions = errors.New("expected at least one OTTL condition to filter on")
+
+type ottlConditionFilter struct {
+	spanConditions      []expr.BoolExpr[ottlspan.TransformContext]
+	spanEventConditions []expr.BoolExpr[ottlspanevent.TransformContext]
+	matchAll            bool
+	logger              *zap.Logger
+}
+
+var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
+
+// NewOTTLConditionFilter creates a policy evaluator that samples all traces with spans or span events
+// matching the given OTTL conditions. When match is "all", every condition must be met by at least one
+// span or span event of the trace, otherwise meeting any of them is enough.
+func NewOTTLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string, match string, errMode ottl.ErrorMode) (PolicyEvaluator, error) {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errNoOTTLConditions
+	}
+	if errMode == "" {
+		errMode = ottl.PropagateError
+	}
+
+	filter := &ottlConditionFilter{logger: logger}
+	switch match {
+	case "", MatchAny:
+	case MatchAll:
+		filter.matchAll = true
+	default:
+		return nil, fmt.Errorf("unknown match %q, expected %q or %q", match, MatchAny, MatchAll)
+	}
+
+	set := component.TelemetrySettings{Logger: logger}
+	// each condition is parsed on its own so that the conditions met by the trace can be tracked
+	for _, condition := range spanConditions {
+		e, err := filterottl.NewBoolExprForSpan([]string{condition}, filterottl.StandardSpanFuncs(), errMode, set)
+		if err != nil {
+			return nil, err
+		}
+		filter.spanConditions = append(filter.spanConditions, e)
+	}
+	for _, condition := range spanEventConditions {
+		e, err := filterottl.NewBoolExprForSpanEvent([]string{condition}, filterottl.StandardSpanEventFuncs(), errMode, set)
+		if err != nil {
+			return nil, err
+		}
+		filter.spanEventConditions = append(filter.spanEventConditions, e)
+	}
+	return filter, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ocf *ottlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	ocf.logger.Debug("Evaluating with OTTL conditions filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	ctx := context.Background()
+	numSpanConditions := len(ocf.spanConditions)
+	matched := make([]bool, numSpanConditions+len(ocf.spanEventConditions))
+	remaining := len(matched)
+
+	// record returns whether the decision to sample the trace can already be taken
+	record := func(i int) bool {
+		if !matched[i] {
+			matched[i] = true
+			remaining--
+		}
+		return !ocf.matchAll || remaining == 0
+	}
+
+	for i := 0; i < batches.ResourceSpans().Len(); i++ {
+		rs := batches.ResourceSpans().At(i)
+		resource := rs.Resource()
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scope := ss.Scope()
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+
+				for c, condition := range ocf.spanConditions {
+					if matched[c] {
+						continue
+					}
+					ok, err := condition.Eval(ctx, ottlspan.NewTransformContext(span, scope, resource))
+					if err != nil {
+						return Error, err
+					}
+					if ok && record(c) {
+						return Sampled, nil
+					}
+				}
+
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					for c, condition := range ocf.spanEventConditions {
+						if matched[numSpanConditions+c] {
+							continue
+						}
+						ok, err := condition.Eval(ctx, ottlspanevent.NewTransformContext(event, span, scope, resource))
+						if err != nil {
+							return Error, err
+						}
+						if ok && record(numSpanConditions+c) {
+							return Sampled, nil
+						}
+					}
+				}
+			}
+		}
+	}
+	return NotSampled, nil
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestOTTLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Match               string
+		ErrorMode           ottl.ErrorMode
+		Trace               *TraceData
+		Decision            Decision
+		ExpectError         bool
+	}{
+		{
+			Desc:           "matching span",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500 and resource.attributes["service.name"] == "checkout"`},
+			Trace:          newTraceWithSpans(spanDef{service: "checkout", statusCode: 503}),
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "conditions of a single expression must be met by the same span",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500 and resource.attributes["service.name"] == "checkout"`},
+			Trace:          newTraceWithSpans(spanDef{service: "checkout", statusCode: 200}, spanDef{service: "cart", statusCode: 503}),
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "any condition",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`, `name == "unknown"`},
+			Trace:          newTraceWithSpans(spanDef{service: "checkout", statusCode: 503}),
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "matching span event",
+			SpanEventConditions: []string{`name == "exception"`},
+			Trace:               newTraceWithSpans(spanDef{service: "checkout", event: "exception"}),
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "all conditions met across the trace",
+			SpanConditions:      []string{`resource.attributes["service.name"] == "checkout"`, `attributes["http.status_code"] >= 500`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Match:               MatchAll,
+			Trace:               newTraceWithSpans(spanDef{service: "checkout", statusCode: 200}, spanDef{service: "cart", statusCode: 503, event: "exception"}),
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "all conditions with one not met",
+			SpanConditions:      []string{`resource.attributes["service.name"] == "checkout"`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Match:               MatchAll,
+			Trace:               newTraceWithSpans(spanDef{service: "checkout", statusCode: 503}),
+			Decision:            NotSampled,
+		},
+		{
+			Desc:           "propagated error",
+			SpanConditions: []string{`Substring(name, 0, 100) == "operation"`, `attributes["http.status_code"] >= 500`},
+			Trace:          newTraceWithSpans(spanDef{service: "checkout", statusCode: 503}),
+			Decision:       Error,
+			ExpectError:    true,
+		},
+		{
+			Desc:           "ignored error",
+			SpanConditions: []string{`Substring(name, 0, 100) == "operation"`, `attributes["http.status_code"] >= 500`},
+			ErrorMode:      ottl.IgnoreError,
+			Trace:          newTraceWithSpans(spanDef{service: "checkout", statusCode: 503}),
+			Decision:       Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewOTTLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions, c.Match, c.ErrorMode)
+			require.NoError(t, err)
+
+			u, _ := uuid.NewRandom()
+			decision, err := filter.Evaluate(pcommon.TraceID(u), c.Trace)
+			if c.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestOTTLConditionFilterInvalidConfig(t *testing.T) {
+	_, err := NewOTTLConditionFilter(zap.NewNop(), nil, nil, "", ottl.PropagateError)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, "most", ottl.PropagateError)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name ==`}, nil, "", ottl.PropagateError)
+	assert.Error(t, err)
+}
+
+type spanDef struct {
+	service    string
+	statusCode int64
+	event      string
+}
+
+func newTraceWithSpans(spans ...spanDef) *TraceData {
+	traces := ptrace.NewTraces()
+	for _, s := range spans {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		span.SetName("operation")
+		if s.statusCode != 0 {
+			span.Attributes().PutInt("http.status_code", s.statusCode)
+		}
+		if s.event != "" {
+			span.Events().AppendEmpty().SetName(s.event)
+		}
+	}
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
@@ -54,8 +54,8 @@ const (
 	// NotSampled is used to indicate that the decision was already taken
 	// to not sample the data.
 	NotSampled
-	// Dropped is used when data needs to be purged before the sampling policy
-	// had a chance to evaluate it.
+	// Dropped is used by the drop policy to indicate that the trace must not
+	// be sampled, taking precedence over the decisions of every other policy.
 	Dropped
 	// Error is used to indicate that policy evaluation was not succeeded.
 	Error
@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -61,6 +62,11 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// caches of the trace IDs for which a decision was taken, used for spans arriving
+	// after the trace has been removed from idToTrace
+	sampledIDCache    cache.Cache
+	nonSampledIDCache cache.Cache
 }
 
 const (
@@ -109,6 +115,9 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  &atomic.Uint64{},
+
+		sampledIDCache:    cache.New(cfg.DecisionCache.SampledCacheSize),
+		nonSampledIDCache: cache.New(cfg.DecisionCache.NonSampledCacheSize),
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -123,6 +132,8 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 		return getNewCompositePolicy(logger, &cfg.CompositeCfg)
 	case And:
 		return getNewAndPolicy(logger, &cfg.AndCfg)
+	case Drop:
+		return getNewDropPolicy(logger, &cfg.DropCfg)
 	default:
 		return getSharedPolicyEvaluator(logger, &cfg.sharedPolicyCfg)
 	}
@@ -159,6 +170,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case BooleanAttribute:
 		bafCfg := cfg.BooleanAttributeCfg
 		return sampling.NewBooleanAttributeFilter(logger, bafCfg.Key, bafCfg.Value), nil
+	case OTTLCondition:
+		ocfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(logger, ocfCfg.SpanConditions, ocfCfg.SpanEventConditions, ocfCfg.Match, ocfCfg.ErrorMode)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -194,7 +208,10 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.Unlock()
 
 		if decision == sampling.Sampled {
+			tsp.sampledIDCache.Put(id)
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
+		} else {
+			tsp.nonSampledIDCache.Put(id)
 		}
 	}
 
@@ -222,6 +239,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.NotSampled:       false,
 		sampling.InvertSampled:    false,
 		sampling.InvertNotSampled: false,
+		sampling.Dropped:          false,
 	}
 
 	// Check all policies before making a final decision
@@ -254,12 +272,23 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 			case sampling.InvertNotSampled:
 				samplingDecision[sampling.InvertNotSampled] = true
 				trace.Decisions[i] = sampling.NotSampled
+
+			case sampling.Dropped:
+				samplingDecision[sampling.Dropped] = true
+				trace.Decisions[i] = sampling.NotSampled
 			}
 		}
 	}
 
-	// InvertNotSampled takes precedence over any other decision
+	// The final decision is taken in the following order of precedence:
+	// 1. a Dropped decision, from a drop policy, never samples the trace
+	// 2. an InvertNotSampled decision doesn't sample the trace
+	// 3. a Sampled decision samples the trace
+	// 4. an InvertSampled decision samples the trace, unless there's a NotSampled decision
+	// 5. otherwise the trace is not sampled
 	switch {
+	case samplingDecision[sampling.Dropped]:
+		finalDecision = sampling.NotSampled
 	case samplingDecision[sampling.InvertNotSampled]:
 		finalDecision = sampling.NotSampled
 	case samplingDecision[sampling.Sampled]:
@@ -326,6 +355,32 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		// Spans of a trace that has already been decided on, and possibly removed from
+		// the memory, get the original decision
+		if tsp.sampledIDCache.Contains(id) {
+			_ = stats.RecordWithTags(
+				tsp.ctx,
+				[]tag.Mutator{tag.Upsert(tagSampledKey, "true")},
+				statDecisionCacheHitCount.M(int64(len(spans))),
+			)
+			traceTd := ptrace.NewTraces()
+			appendToTraces(traceTd, resourceSpans, spans)
+			if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+				tsp.logger.Warn(
+					"Error sending late arrived spans to destination",
+					zap.Error(err))
+			}
+			continue
+		}
+		if tsp.nonSampledIDCache.Contains(id) {
+			_ = stats.RecordWithTags(
+				tsp.ctx,
+				[]tag.Mutator{tag.Upsert(tagSampledKey, "false")},
+				statDecisionCacheHitCount.M(int64(len(spans))),
+			)
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -402,6 +457,13 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 
 // Start is invoked during service startup.
 func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+	// the decision caches are optional, a processor without them behaves as if they were disabled
+	if tsp.sampledIDCache == nil {
+		tsp.sampledIDCache = cache.NewNop()
+	}
+	if tsp.nonSampledIDCache == nil {
+		tsp.nonSampledIDCache = cache.NewNop()
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
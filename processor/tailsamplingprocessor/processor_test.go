@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -497,6 +498,105 @@ func TestSamplingPolicyDecisionInvertNotSampled(t *testing.T) {
 	require.Equal(t, 0, msp.SpanCount())
 }
 
+func TestSamplingPolicyDecisionDropped(t *testing.T) {
+	const maxSize = 100
+	const decisionWaitSeconds = 5
+	// For this test explicitly control the timer calls and batcher, and set mock
+	// sampling policy evaluators: one always sampling, the other dropping the traces.
+	msp := new(consumertest.TracesSink)
+	sampled := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	dropped := &mockPolicyEvaluator{NextDecision: sampling.Dropped}
+	mtt := &manualTTicker{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
+		policies: []*policy{
+			{name: "mock-policy-sampled", evaluator: sampled, ctx: context.TODO()},
+			{name: "mock-policy-dropped", evaluator: dropped, ctx: context.TODO()},
+		},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    mtt,
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  &atomic.Uint64{},
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	_, batches := generateIdsAndBatches(210)
+	currItem := 0
+	numSpansPerBatchWindow := 10
+	// First evaluations shouldn't have anything to evaluate, until decision wait time passed.
+	for evalNum := 0; evalNum < decisionWaitSeconds; evalNum++ {
+		for ; currItem < numSpansPerBatchWindow*(evalNum+1); currItem++ {
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[currItem]))
+			require.True(t, mtt.Started, "Time ticker was expected to have started")
+		}
+		tsp.samplingPolicyOnTick()
+		require.False(
+			t,
+			msp.SpanCount() != 0 || sampled.EvaluationCount != 0 || dropped.EvaluationCount != 0,
+			"policy for initial items was evaluated before decision wait period",
+		)
+	}
+
+	// Now the first batch that waited the decision period, the drop policy takes precedence.
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 0, msp.SpanCount(), "exporter should have received zero spans")
+	require.EqualValues(t, 4, sampled.EvaluationCount, "policy should have been evaluated 4 times")
+	require.EqualValues(t, 4, dropped.EvaluationCount, "policy should have been evaluated 4 times")
+
+	// Late span of a dropped trace should be ignored
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	require.Equal(t, 0, msp.SpanCount())
+}
+
+func TestSamplingPolicyInvertSampledWithNotMatchingDrop(t *testing.T) {
+	const maxSize = 100
+	// For this test explicitly control the timer calls and batcher, and set mock
+	// sampling policy evaluators: one inverting a non-match, and a drop policy that doesn't match.
+	msp := new(consumertest.TracesSink)
+	inverted := &mockPolicyEvaluator{NextDecision: sampling.InvertSampled}
+	notMatching := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	mtt := &manualTTicker{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies: []*policy{
+			{name: "mock-policy-inverted", evaluator: inverted, ctx: context.TODO()},
+			{name: "mock-policy-drop", evaluator: sampling.NewDrop(zap.NewNop(), []sampling.PolicyEvaluator{notMatching}), ctx: context.TODO()},
+		},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    mtt,
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  &atomic.Uint64{},
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	_, batches := generateIdsAndBatches(1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+
+	// The first tick won't do anything
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 0, inverted.EvaluationCount)
+
+	// The drop policy doesn't match, so it must not veto the inverted decision
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, inverted.EvaluationCount)
+	require.EqualValues(t, 1, notMatching.EvaluationCount)
+	require.EqualValues(t, batches[0].SpanCount(), msp.SpanCount(), "trace should have been sampled")
+}
+
 func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	const maxSize = 100
 	nextConsumer := new(consumertest.TracesSink)
@@ -564,6 +664,67 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
 
+func TestLateSpansUseDecisionCache(t *testing.T) {
+	const maxSize = 100
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:               context.Background(),
+		nextConsumer:      nextConsumer,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		sampledIDCache:    cache.New(maxSize),
+		nonSampledIDCache: cache.New(maxSize),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+	spanToTraces := func(traceID pcommon.TraceID, spanIndex uint64) ptrace.Traces {
+		traces := ptrace.NewTraces()
+		span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(uInt64ToSpanID(spanIndex))
+		return traces
+	}
+
+	// Decide on both traces
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), spanToTraces(sampledID, 1)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), spanToTraces(notSampledID, 2)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+	require.EqualValues(t, 1, nextConsumer.SpanCount())
+
+	// Remove the traces from the memory, as if they had been evicted
+	tsp.dropTrace(sampledID, time.Now())
+	tsp.dropTrace(notSampledID, time.Now())
+
+	// Late spans get the original decision without evaluating the policies again
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), spanToTraces(sampledID, 3)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), spanToTraces(notSampledID, 4)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+	require.EqualValues(t, 2, nextConsumer.SpanCount())
+	_, found := tsp.idToTrace.Load(notSampledID)
+	require.False(t, found, "late span of a cached trace shouldn't start a new trace")
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
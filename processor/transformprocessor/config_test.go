@@ -103,27 +103,27 @@ func TestLoadConfig(t *testing.T) {
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_trace"),
-			errorMessage: "unable to parse OTTL statement: 1:18: unexpected token \"where\" (expected \")\")",
+			errorMessage: `unable to parse OTTL statement "set(name, \"bear\" where attributes[\"http.path\"] == \"/animal\"": column 18 near "where": unexpected token "where" (expected ")")`,
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "unknown_function_trace"),
-			errorMessage: "undefined function not_a_function",
+			errorMessage: `unable to parse OTTL statement "not_a_function(attributes, [\"http.method\", \"http.path\"])": column 1 near "not_a_function": undefined function not_a_function`,
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_metric"),
-			errorMessage: "unable to parse OTTL statement: 1:18: unexpected token \"where\" (expected \")\")",
+			errorMessage: `unable to parse OTTL statement "set(name, \"bear\" where attributes[\"http.path\"] == \"/animal\"": column 18 near "where": unexpected token "where" (expected ")")`,
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "unknown_function_metric"),
-			errorMessage: "undefined function not_a_function",
+			errorMessage: `unable to parse OTTL statement "not_a_function(attributes, [\"http.method\", \"http.path\"])": column 1 near "not_a_function": undefined function not_a_function`,
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "bad_syntax_log"),
-			errorMessage: "unable to parse OTTL statement: 1:18: unexpected token \"where\" (expected \")\")",
+			errorMessage: `unable to parse OTTL statement "set(body, \"bear\" where attributes[\"http.path\"] == \"/animal\"": column 18 near "where": unexpected token "where" (expected ")")`,
 		},
 		{
 			id:           component.NewIDWithName(typeStr, "unknown_function_log"),
-			errorMessage: "undefined function not_a_function",
+			errorMessage: `unable to parse OTTL statement "not_a_function(attributes, [\"http.method\", \"http.path\"])": column 1 near "not_a_function": undefined function not_a_function`,
 		},
 	}
 	for _, tt := range tests {
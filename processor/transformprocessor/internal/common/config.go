@@ -26,15 +26,17 @@ const (
 	Scope     ContextID = "scope"
 	Span      ContextID = "span"
 	SpanEvent ContextID = "spanevent"
+	SpanLink  ContextID = "spanlink"
 	Metric    ContextID = "metric"
 	DataPoint ContextID = "datapoint"
+	Exemplar  ContextID = "exemplar"
 	Log       ContextID = "log"
 )
 
 func (c *ContextID) UnmarshalText(text []byte) error {
 	str := ContextID(strings.ToLower(string(text)))
 	switch str {
-	case Resource, Scope, Span, SpanEvent, Metric, DataPoint, Log:
+	case Resource, Scope, Span, SpanEvent, SpanLink, Metric, DataPoint, Exemplar, Log:
 		*c = str
 		return nil
 	default:
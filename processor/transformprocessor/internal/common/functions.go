@@ -21,28 +21,19 @@ import (
 )
 
 func Functions[K any]() map[string]interface{} {
-	return map[string]interface{}{
-		"TraceID":              ottlfuncs.TraceID[K],
-		"SpanID":               ottlfuncs.SpanID[K],
-		"IsMatch":              ottlfuncs.IsMatch[K],
-		"Concat":               ottlfuncs.Concat[K],
-		"Split":                ottlfuncs.Split[K],
-		"Int":                  ottlfuncs.Int[K],
-		"ConvertCase":          ottlfuncs.ConvertCase[K],
-		"ParseJSON":            ottlfuncs.ParseJSON[K],
-		"Substring":            ottlfuncs.Substring[K],
-		"keep_keys":            ottlfuncs.KeepKeys[K],
-		"set":                  ottlfuncs.Set[K],
-		"truncate_all":         ottlfuncs.TruncateAll[K],
-		"limit":                ottlfuncs.Limit[K],
-		"replace_match":        ottlfuncs.ReplaceMatch[K],
-		"replace_all_matches":  ottlfuncs.ReplaceAllMatches[K],
-		"replace_pattern":      ottlfuncs.ReplacePattern[K],
-		"replace_all_patterns": ottlfuncs.ReplaceAllPatterns[K],
-		"delete_key":           ottlfuncs.DeleteKey[K],
-		"delete_matching_keys": ottlfuncs.DeleteMatchingKeys[K],
-		"merge_maps":           ottlfuncs.MergeMaps[K],
-	}
+	functions := ottlfuncs.StandardConverters[K]()
+	functions["keep_keys"] = ottlfuncs.KeepKeys[K]
+	functions["set"] = ottlfuncs.Set[K]
+	functions["truncate_all"] = ottlfuncs.TruncateAll[K]
+	functions["limit"] = ottlfuncs.Limit[K]
+	functions["replace_match"] = ottlfuncs.ReplaceMatch[K]
+	functions["replace_all_matches"] = ottlfuncs.ReplaceAllMatches[K]
+	functions["replace_pattern"] = ottlfuncs.ReplacePattern[K]
+	functions["replace_all_patterns"] = ottlfuncs.ReplaceAllPatterns[K]
+	functions["delete_key"] = ottlfuncs.DeleteKey[K]
+	functions["delete_matching_keys"] = ottlfuncs.DeleteMatchingKeys[K]
+	functions["merge_maps"] = ottlfuncs.MergeMaps[K]
+	return functions
 }
 
 func ResourceFunctions() map[string]interface{} {
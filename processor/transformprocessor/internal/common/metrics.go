@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
@@ -48,7 +49,7 @@ func (m metricStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 			smetrics := rmetrics.ScopeMetrics().At(j)
 			metrics := smetrics.Metrics()
 			for k := 0; k < metrics.Len(); k++ {
-				tCtx := ottlmetric.NewTransformContext(metrics.At(k), smetrics.Scope(), rmetrics.Resource())
+				tCtx := ottlmetric.NewTransformContext(metrics.At(k), metrics, smetrics.Scope(), rmetrics.Resource())
 				err := m.Execute(ctx, tCtx)
 				if err != nil {
 					return err
@@ -145,10 +146,74 @@ func (d dataPointStatements) handleSummaryDataPoints(ctx context.Context, dps pm
 	return nil
 }
 
+var _ consumer.Metrics = &exemplarStatements{}
+
+type exemplarStatements struct {
+	ottl.Statements[ottlexemplar.TransformContext]
+}
+
+func (e exemplarStatements) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{
+		MutatesData: true,
+	}
+}
+
+func (e exemplarStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rmetrics := md.ResourceMetrics().At(i)
+		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
+			smetrics := rmetrics.ScopeMetrics().At(j)
+			metrics := smetrics.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				var err error
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for n := 0; n < dps.Len() && err == nil; n++ {
+						err = e.handleExemplars(ctx, dps.At(n).Exemplars(), dps.At(n), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for n := 0; n < dps.Len() && err == nil; n++ {
+						err = e.handleExemplars(ctx, dps.At(n).Exemplars(), dps.At(n), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for n := 0; n < dps.Len() && err == nil; n++ {
+						err = e.handleExemplars(ctx, dps.At(n).Exemplars(), dps.At(n), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for n := 0; n < dps.Len() && err == nil; n++ {
+						err = e.handleExemplars(ctx, dps.At(n).Exemplars(), dps.At(n), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (e exemplarStatements) handleExemplars(ctx context.Context, exemplars pmetric.ExemplarSlice, dataPoint interface{}, metric pmetric.Metric, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	for i := 0; i < exemplars.Len(); i++ {
+		tCtx := ottlexemplar.NewTransformContext(exemplars.At(i), dataPoint, metric, is, resource)
+		err := e.Execute(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type MetricParserCollection struct {
 	parserCollection
 	metricParser    ottl.Parser[ottlmetric.TransformContext]
 	dataPointParser ottl.Parser[ottldatapoint.TransformContext]
+	exemplarParser  ottl.Parser[ottlexemplar.TransformContext]
 }
 
 type MetricParserCollectionOption func(*MetricParserCollection) error
@@ -175,6 +240,17 @@ func WithDataPointParser(functions map[string]interface{}) MetricParserCollectio
 	}
 }
 
+func WithExemplarParser(functions map[string]interface{}) MetricParserCollectionOption {
+	return func(mp *MetricParserCollection) error {
+		exemplarParser, err := ottlexemplar.NewParser(functions, mp.settings)
+		if err != nil {
+			return err
+		}
+		mp.exemplarParser = exemplarParser
+		return nil
+	}
+}
+
 func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption {
 	return func(mp *MetricParserCollection) error {
 		mp.errorMode = errorMode
@@ -225,6 +301,13 @@ func (pc MetricParserCollection) ParseContextStatements(contextStatements Contex
 		}
 		dpStatements := ottldatapoint.NewStatements(parsedStatements, pc.settings, ottldatapoint.WithErrorMode(pc.errorMode))
 		return dataPointStatements{dpStatements}, nil
+	case Exemplar:
+		parsedStatements, err := pc.exemplarParser.ParseStatements(contextStatements.Statements)
+		if err != nil {
+			return nil, err
+		}
+		eStatements := ottlexemplar.NewStatements(parsedStatements, pc.settings, ottlexemplar.WithErrorMode(pc.errorMode))
+		return exemplarStatements{eStatements}, nil
 	default:
 		statements, err := pc.parseCommonContextStatements(contextStatements)
 		if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 var _ consumer.Traces = &traceStatements{}
@@ -92,10 +93,45 @@ func (s spanEventStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces
 	return nil
 }
 
+var _ consumer.Traces = &spanLinkStatements{}
+
+type spanLinkStatements struct {
+	ottl.Statements[ottlspanlink.TransformContext]
+}
+
+func (s spanLinkStatements) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{
+		MutatesData: true,
+	}
+}
+
+func (s spanLinkStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rspans := td.ResourceSpans().At(i)
+		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+			sspans := rspans.ScopeSpans().At(j)
+			spans := sspans.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				spanLinks := span.Links()
+				for n := 0; n < spanLinks.Len(); n++ {
+					tCtx := ottlspanlink.NewTransformContext(spanLinks.At(n), span, sspans.Scope(), rspans.Resource())
+					err := s.Execute(ctx, tCtx)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 type TraceParserCollection struct {
 	parserCollection
 	spanParser      ottl.Parser[ottlspan.TransformContext]
 	spanEventParser ottl.Parser[ottlspanevent.TransformContext]
+	spanLinkParser  ottl.Parser[ottlspanlink.TransformContext]
 	errorMode       ottl.ErrorMode
 }
 
@@ -123,6 +159,17 @@ func WithSpanEventParser(functions map[string]interface{}) TraceParserCollection
 	}
 }
 
+func WithSpanLinkParser(functions map[string]interface{}) TraceParserCollectionOption {
+	return func(tp *TraceParserCollection) error {
+		spanLinkParser, err := ottlspanlink.NewParser(functions, tp.settings)
+		if err != nil {
+			return err
+		}
+		tp.spanLinkParser = spanLinkParser
+		return nil
+	}
+}
+
 func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return func(tp *TraceParserCollection) error {
 		tp.errorMode = errorMode
@@ -173,6 +220,13 @@ func (pc TraceParserCollection) ParseContextStatements(contextStatements Context
 		}
 		seStatements := ottlspanevent.NewStatements(parsedStatements, pc.settings, ottlspanevent.WithErrorMode(pc.errorMode))
 		return spanEventStatements{seStatements}, nil
+	case SpanLink:
+		parsedStatements, err := pc.spanLinkParser.ParseStatements(contextStatements.Statements)
+		if err != nil {
+			return nil, err
+		}
+		slStatements := ottlspanlink.NewStatements(parsedStatements, pc.settings, ottlspanlink.WithErrorMode(pc.errorMode))
+		return spanLinkStatements{slStatements}, nil
 	default:
 		return pc.parseCommonContextStatements(contextStatements)
 	}
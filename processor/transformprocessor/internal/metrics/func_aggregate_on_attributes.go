@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+const (
+	aggregateSum  = "sum"
+	aggregateMin  = "min"
+	aggregateMax  = "max"
+	aggregateMean = "mean"
+)
+
+func aggregateOnAttributes(function string, attributes []string) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	switch function {
+	case aggregateSum, aggregateMin, aggregateMax, aggregateMean:
+	default:
+		return nil, fmt.Errorf("unknown aggregation function: %s", function)
+	}
+
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (interface{}, error) {
+		metric := tCtx.GetMetric()
+		switch metric.Type() {
+		case pmetric.MetricTypeSum:
+			aggregateNumberDataPoints(metric.Sum().DataPoints(), function, attributes)
+		case pmetric.MetricTypeGauge:
+			aggregateNumberDataPoints(metric.Gauge().DataPoints(), function, attributes)
+		case pmetric.MetricTypeHistogram:
+			if function != aggregateSum {
+				return nil, fmt.Errorf("aggregation function %s is not supported for histograms", function)
+			}
+			return nil, aggregateHistogramDataPoints(metric.Histogram().DataPoints(), attributes)
+		}
+		return nil, nil
+	}, nil
+}
+
+type numberAggregate struct {
+	dp    pmetric.NumberDataPoint
+	value float64
+	count int
+	isInt bool
+}
+
+func aggregateNumberDataPoints(dps pmetric.NumberDataPointSlice, function string, attributes []string) {
+	aggregated := pmetric.NewNumberDataPointSlice()
+	var aggregates []*numberAggregate
+	groups := map[string]*numberAggregate{}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		value := dp.DoubleValue()
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			value = float64(dp.IntValue())
+		}
+
+		attrs := pcommon.NewMap()
+		keepAttributes(attrs, dp.Attributes(), attributes)
+		key := attributesKey(attrs)
+		agg, ok := groups[key]
+		if !ok {
+			agg = &numberAggregate{dp: aggregated.AppendEmpty(), value: value, isInt: true}
+			keepAttributes(agg.dp.Attributes(), dp.Attributes(), attributes)
+			agg.dp.SetStartTimestamp(dp.StartTimestamp())
+			agg.dp.SetTimestamp(dp.Timestamp())
+			groups[key] = agg
+			aggregates = append(aggregates, agg)
+		} else {
+			switch function {
+			case aggregateSum, aggregateMean:
+				agg.value += value
+			case aggregateMin:
+				agg.value = math.Min(agg.value, value)
+			case aggregateMax:
+				agg.value = math.Max(agg.value, value)
+			}
+			mergeTimestamps(agg.dp, dp.StartTimestamp(), dp.Timestamp())
+		}
+		agg.count++
+		agg.isInt = agg.isInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt
+		dp.Exemplars().MoveAndAppendTo(agg.dp.Exemplars())
+	}
+
+	for _, agg := range aggregates {
+		switch {
+		case function == aggregateMean:
+			agg.dp.SetDoubleValue(agg.value / float64(agg.count))
+		case agg.isInt:
+			agg.dp.SetIntValue(int64(agg.value))
+		default:
+			agg.dp.SetDoubleValue(agg.value)
+		}
+	}
+	dps.RemoveIf(func(pmetric.NumberDataPoint) bool { return true })
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateHistogramDataPoints(dps pmetric.HistogramDataPointSlice, attributes []string) error {
+	aggregated := pmetric.NewHistogramDataPointSlice()
+	groups := map[string]pmetric.HistogramDataPoint{}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		attrs := pcommon.NewMap()
+		keepAttributes(attrs, dp.Attributes(), attributes)
+		key := attributesKey(attrs)
+		agg, ok := groups[key]
+		if !ok {
+			agg = aggregated.AppendEmpty()
+			dp.CopyTo(agg)
+			agg.Attributes().RemoveIf(func(k string, _ pcommon.Value) bool {
+				_, kept := attrs.Get(k)
+				return !kept
+			})
+			groups[key] = agg
+			continue
+		}
+
+		if !equalBounds(agg.ExplicitBounds(), dp.ExplicitBounds()) {
+			return fmt.Errorf("histogram data points with attributes %s have different bucket boundaries", key)
+		}
+		agg.SetCount(agg.Count() + dp.Count())
+		if agg.HasSum() && dp.HasSum() {
+			agg.SetSum(agg.Sum() + dp.Sum())
+		} else {
+			agg.RemoveSum()
+		}
+		if agg.HasMin() && dp.HasMin() {
+			agg.SetMin(math.Min(agg.Min(), dp.Min()))
+		} else {
+			agg.RemoveMin()
+		}
+		if agg.HasMax() && dp.HasMax() {
+			agg.SetMax(math.Max(agg.Max(), dp.Max()))
+		} else {
+			agg.RemoveMax()
+		}
+		for j := 0; j < agg.BucketCounts().Len() && j < dp.BucketCounts().Len(); j++ {
+			agg.BucketCounts().SetAt(j, agg.BucketCounts().At(j)+dp.BucketCounts().At(j))
+		}
+		mergeTimestamps(agg, dp.StartTimestamp(), dp.Timestamp())
+		for j := 0; j < dp.Exemplars().Len(); j++ {
+			dp.Exemplars().At(j).CopyTo(agg.Exemplars().AppendEmpty())
+		}
+	}
+	dps.RemoveIf(func(pmetric.HistogramDataPoint) bool { return true })
+	aggregated.MoveAndAppendTo(dps)
+	return nil
+}
+
+// keepAttributes copies the attributes of src with the given keys to dst.
+func keepAttributes(dst pcommon.Map, src pcommon.Map, keys []string) {
+	for _, key := range keys {
+		if v, ok := src.Get(key); ok {
+			v.CopyTo(dst.PutEmpty(key))
+		}
+	}
+}
+
+// attributesKey returns a key identifying the given attributes, regardless of their order.
+func attributesKey(attrs pcommon.Map) string {
+	// encoding/json sorts map keys, so equal attributes always produce the same key.
+	b, _ := json.Marshal(attrs.AsRaw())
+	return string(b)
+}
+
+// mergeTimestamps widens the time range of the data point to include the given start and end timestamps.
+func mergeTimestamps(dp interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}, start pcommon.Timestamp, end pcommon.Timestamp) {
+	if start != 0 && (dp.StartTimestamp() == 0 || start < dp.StartTimestamp()) {
+		dp.SetStartTimestamp(start)
+	}
+	if end > dp.Timestamp() {
+		dp.SetTimestamp(end)
+	}
+}
+
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
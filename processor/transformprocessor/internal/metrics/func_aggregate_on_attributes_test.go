@@ -0,0 +1,294 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func getTestAggregateSumMetric() pmetric.Metric {
+	metricInput := pmetric.NewMetric()
+	metricInput.SetName("sum_metric")
+	dps := metricInput.SetEmptySum().DataPoints()
+
+	dp0 := dps.AppendEmpty()
+	dp0.SetIntValue(4)
+	dp0.SetStartTimestamp(1)
+	dp0.SetTimestamp(10)
+	dp0.Attributes().PutStr("service", "cart")
+	dp0.Attributes().PutStr("pod", "cart-1")
+
+	dp1 := dps.AppendEmpty()
+	dp1.SetIntValue(2)
+	dp1.SetStartTimestamp(2)
+	dp1.SetTimestamp(20)
+	dp1.Attributes().PutStr("service", "cart")
+	dp1.Attributes().PutStr("pod", "cart-2")
+
+	dp2 := dps.AppendEmpty()
+	dp2.SetIntValue(7)
+	dp2.SetStartTimestamp(3)
+	dp2.SetTimestamp(30)
+	dp2.Attributes().PutStr("service", "checkout")
+	dp2.Attributes().PutStr("pod", "checkout-1")
+	return metricInput
+}
+
+func getTestAggregateHistogramMetric() pmetric.Metric {
+	metricInput := pmetric.NewMetric()
+	metricInput.SetName("histogram_metric")
+	dps := metricInput.SetEmptyHistogram().DataPoints()
+
+	dp0 := dps.AppendEmpty()
+	dp0.SetCount(3)
+	dp0.SetSum(6)
+	dp0.SetMin(1)
+	dp0.SetMax(3)
+	dp0.ExplicitBounds().FromRaw([]float64{1, 2})
+	dp0.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	dp0.Attributes().PutStr("service", "cart")
+	dp0.Attributes().PutStr("pod", "cart-1")
+
+	dp1 := dps.AppendEmpty()
+	dp1.SetCount(2)
+	dp1.SetSum(0.5)
+	dp1.SetMin(0.1)
+	dp1.SetMax(0.4)
+	dp1.ExplicitBounds().FromRaw([]float64{1, 2})
+	dp1.BucketCounts().FromRaw([]uint64{2, 0, 0})
+	dp1.Attributes().PutStr("service", "cart")
+	dp1.Attributes().PutStr("pod", "cart-2")
+	return metricInput
+}
+
+func Test_AggregateOnAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      pmetric.Metric
+		function   string
+		attributes []string
+		want       func(pmetric.Metric)
+	}{
+		{
+			name:       "sum",
+			input:      getTestAggregateSumMetric(),
+			function:   "sum",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+
+				dp0 := dps.AppendEmpty()
+				dp0.SetIntValue(6)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(20)
+				dp0.Attributes().PutStr("service", "cart")
+
+				dp1 := dps.AppendEmpty()
+				dp1.SetIntValue(7)
+				dp1.SetStartTimestamp(3)
+				dp1.SetTimestamp(30)
+				dp1.Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			name:       "min",
+			input:      getTestAggregateSumMetric(),
+			function:   "min",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+
+				dp0 := dps.AppendEmpty()
+				dp0.SetIntValue(2)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(20)
+				dp0.Attributes().PutStr("service", "cart")
+
+				dp1 := dps.AppendEmpty()
+				dp1.SetIntValue(7)
+				dp1.SetStartTimestamp(3)
+				dp1.SetTimestamp(30)
+				dp1.Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			name:       "max",
+			input:      getTestAggregateSumMetric(),
+			function:   "max",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+
+				dp0 := dps.AppendEmpty()
+				dp0.SetIntValue(4)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(20)
+				dp0.Attributes().PutStr("service", "cart")
+
+				dp1 := dps.AppendEmpty()
+				dp1.SetIntValue(7)
+				dp1.SetStartTimestamp(3)
+				dp1.SetTimestamp(30)
+				dp1.Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			name:       "mean",
+			input:      getTestAggregateSumMetric(),
+			function:   "mean",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+
+				dp0 := dps.AppendEmpty()
+				dp0.SetDoubleValue(3)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(20)
+				dp0.Attributes().PutStr("service", "cart")
+
+				dp1 := dps.AppendEmpty()
+				dp1.SetDoubleValue(7)
+				dp1.SetStartTimestamp(3)
+				dp1.SetTimestamp(30)
+				dp1.Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			name:       "no attributes",
+			input:      getTestAggregateSumMetric(),
+			function:   "sum",
+			attributes: []string{},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dp0 := metric.SetEmptySum().DataPoints().AppendEmpty()
+				dp0.SetIntValue(13)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(30)
+			},
+		},
+		{
+			name: "double values",
+			input: func() pmetric.Metric {
+				metric := getTestAggregateSumMetric()
+				metric.Sum().DataPoints().At(1).SetDoubleValue(2.5)
+				return metric
+			}(),
+			function:   "sum",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+
+				dp0 := dps.AppendEmpty()
+				dp0.SetDoubleValue(6.5)
+				dp0.SetStartTimestamp(1)
+				dp0.SetTimestamp(20)
+				dp0.Attributes().PutStr("service", "cart")
+
+				dp1 := dps.AppendEmpty()
+				dp1.SetIntValue(7)
+				dp1.SetStartTimestamp(3)
+				dp1.SetTimestamp(30)
+				dp1.Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			name:       "histogram",
+			input:      getTestAggregateHistogramMetric(),
+			function:   "sum",
+			attributes: []string{"service"},
+			want: func(metric pmetric.Metric) {
+				metric.SetName("histogram_metric")
+				dp0 := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+				dp0.SetCount(5)
+				dp0.SetSum(6.5)
+				dp0.SetMin(0.1)
+				dp0.SetMax(3)
+				dp0.ExplicitBounds().FromRaw([]float64{1, 2})
+				dp0.BucketCounts().FromRaw([]uint64{3, 1, 1})
+				dp0.Attributes().PutStr("service", "cart")
+			},
+		},
+		{
+			name:       "noop",
+			input:      getTestSummaryMetric(),
+			function:   "sum",
+			attributes: []string{"test"},
+			want: func(metric pmetric.Metric) {
+				getTestSummaryMetric().CopyTo(metric)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluate, err := aggregateOnAttributes(tt.function, tt.attributes)
+			assert.NoError(t, err)
+
+			_, err = evaluate(nil, ottlmetric.NewTransformContext(tt.input, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			tt.want(expected)
+			assert.Equal(t, expected, tt.input)
+		})
+	}
+}
+
+func Test_AggregateOnAttributes_HistogramErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pmetric.Metric
+		function string
+	}{
+		{
+			name:     "unsupported function",
+			input:    getTestAggregateHistogramMetric(),
+			function: "mean",
+		},
+		{
+			name: "different bucket boundaries",
+			input: func() pmetric.Metric {
+				metric := getTestAggregateHistogramMetric()
+				metric.Histogram().DataPoints().At(1).ExplicitBounds().FromRaw([]float64{5, 10})
+				return metric
+			}(),
+			function: "sum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluate, err := aggregateOnAttributes(tt.function, []string{"service"})
+			assert.NoError(t, err)
+
+			_, err = evaluate(nil, ottlmetric.NewTransformContext(tt.input, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_AggregateOnAttributes_validation(t *testing.T) {
+	_, err := aggregateOnAttributes("median", []string{"service"})
+	assert.EqualError(t, err, "unknown aggregation function: median")
+}
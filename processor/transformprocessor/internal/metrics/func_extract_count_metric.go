@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+// sourceDataPoint is implemented by the data points a metric can be extracted from.
+type sourceDataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+}
+
+func extractCountMetric(monotonic bool) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (interface{}, error) {
+		metric := tCtx.GetMetric()
+		var aggTemp pmetric.AggregationTemporality
+		switch metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			aggTemp = metric.Histogram().AggregationTemporality()
+		case pmetric.MetricTypeExponentialHistogram:
+			aggTemp = metric.ExponentialHistogram().AggregationTemporality()
+		case pmetric.MetricTypeSummary:
+			aggTemp = pmetric.AggregationTemporalityCumulative
+		default:
+			return nil, nil
+		}
+
+		countMetric := pmetric.NewMetric()
+		countMetric.SetDescription(metric.Description())
+		countMetric.SetName(metric.Name() + "_count")
+		countMetric.SetUnit("1")
+		countMetric.SetEmptySum().SetAggregationTemporality(aggTemp)
+		countMetric.Sum().SetIsMonotonic(monotonic)
+
+		countDps := countMetric.Sum().DataPoints()
+		switch metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			dps := metric.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				addCountDataPoint(countDps, dps.At(i), dps.At(i).Count())
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dps := metric.ExponentialHistogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				addCountDataPoint(countDps, dps.At(i), dps.At(i).Count())
+			}
+		case pmetric.MetricTypeSummary:
+			dps := metric.Summary().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				addCountDataPoint(countDps, dps.At(i), dps.At(i).Count())
+			}
+		}
+
+		countMetric.MoveTo(tCtx.GetMetrics().AppendEmpty())
+		return nil, nil
+	}, nil
+}
+
+func addCountDataPoint(dps pmetric.NumberDataPointSlice, dataPoint sourceDataPoint, count uint64) {
+	dp := dps.AppendEmpty()
+	dataPoint.Attributes().CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(dataPoint.StartTimestamp())
+	dp.SetTimestamp(dataPoint.Timestamp())
+	dp.SetIntValue(int64(count))
+}
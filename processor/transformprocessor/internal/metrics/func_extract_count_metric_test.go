@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func getTestHistogramMetric() pmetric.Metric {
+	metricInput := pmetric.NewMetric()
+	metricInput.SetName("histogram_metric")
+	metricInput.SetDescription("histogram description")
+	metricInput.SetUnit("ms")
+	metricInput.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	input := metricInput.Histogram().DataPoints().AppendEmpty()
+	input.SetCount(5)
+	input.SetSum(12.34)
+	input.SetStartTimestamp(1)
+	input.SetTimestamp(2)
+	input.ExplicitBounds().FromRaw([]float64{1, 10})
+	input.BucketCounts().FromRaw([]uint64{1, 3, 1})
+
+	attrs := getTestAttributes()
+	attrs.CopyTo(input.Attributes())
+	return metricInput
+}
+
+func getTestExponentialHistogramMetric() pmetric.Metric {
+	metricInput := pmetric.NewMetric()
+	metricInput.SetName("exponential_histogram_metric")
+	metricInput.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	input := metricInput.ExponentialHistogram().DataPoints().AppendEmpty()
+	input.SetCount(7)
+	input.SetSum(43.21)
+	input.SetScale(1)
+
+	attrs := getTestAttributes()
+	attrs.CopyTo(input.Attributes())
+	return metricInput
+}
+
+func Test_ExtractCountMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		monotonic bool
+		want      func(pmetric.MetricSlice)
+	}{
+		{
+			name:      "histogram",
+			input:     getTestHistogramMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				countMetric := metrics.AppendEmpty()
+				countMetric.SetName("histogram_metric_count")
+				countMetric.SetDescription("histogram description")
+				countMetric.SetUnit("1")
+				countMetric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				countMetric.Sum().SetIsMonotonic(true)
+
+				dp := countMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(5)
+				dp.SetStartTimestamp(1)
+				dp.SetTimestamp(2)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "exponential histogram",
+			input:     getTestExponentialHistogramMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestExponentialHistogramMetric().CopyTo(metrics.AppendEmpty())
+				countMetric := metrics.AppendEmpty()
+				countMetric.SetName("exponential_histogram_metric_count")
+				countMetric.SetUnit("1")
+				countMetric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				countMetric.Sum().SetIsMonotonic(false)
+
+				dp := countMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(7)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "summary",
+			input:     getTestSummaryMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestSummaryMetric().CopyTo(metrics.AppendEmpty())
+				countMetric := metrics.AppendEmpty()
+				countMetric.SetName("summary_metric_count")
+				countMetric.SetUnit("1")
+				countMetric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				countMetric.Sum().SetIsMonotonic(true)
+
+				dp := countMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(100)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "noop",
+			input:     getTestGaugeMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := extractCountMetric(tt.monotonic)
+			assert.NoError(t, err)
+
+			_, err = evaluate(nil, ottlmetric.NewTransformContext(actualMetrics.At(0), actualMetrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
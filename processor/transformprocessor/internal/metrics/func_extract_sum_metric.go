@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func extractSumMetric(monotonic bool) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (interface{}, error) {
+		metric := tCtx.GetMetric()
+		var aggTemp pmetric.AggregationTemporality
+		switch metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			aggTemp = metric.Histogram().AggregationTemporality()
+		case pmetric.MetricTypeExponentialHistogram:
+			aggTemp = metric.ExponentialHistogram().AggregationTemporality()
+		case pmetric.MetricTypeSummary:
+			aggTemp = pmetric.AggregationTemporalityCumulative
+		default:
+			return nil, nil
+		}
+
+		sumMetric := pmetric.NewMetric()
+		sumMetric.SetDescription(metric.Description())
+		sumMetric.SetName(metric.Name() + "_sum")
+		sumMetric.SetUnit(metric.Unit())
+		sumMetric.SetEmptySum().SetAggregationTemporality(aggTemp)
+		sumMetric.Sum().SetIsMonotonic(monotonic)
+
+		sumDps := sumMetric.Sum().DataPoints()
+		switch metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			dps := metric.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dps.At(i).HasSum() {
+					addSumDataPoint(sumDps, dps.At(i), dps.At(i).Sum())
+				}
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dps := metric.ExponentialHistogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				if dps.At(i).HasSum() {
+					addSumDataPoint(sumDps, dps.At(i), dps.At(i).Sum())
+				}
+			}
+		case pmetric.MetricTypeSummary:
+			dps := metric.Summary().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				addSumDataPoint(sumDps, dps.At(i), dps.At(i).Sum())
+			}
+		}
+
+		// Histograms without a sum have nothing to extract.
+		if sumDps.Len() == 0 {
+			return nil, nil
+		}
+		sumMetric.MoveTo(tCtx.GetMetrics().AppendEmpty())
+		return nil, nil
+	}, nil
+}
+
+func addSumDataPoint(dps pmetric.NumberDataPointSlice, dataPoint sourceDataPoint, sum float64) {
+	dp := dps.AppendEmpty()
+	dataPoint.Attributes().CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(dataPoint.StartTimestamp())
+	dp.SetTimestamp(dataPoint.Timestamp())
+	dp.SetDoubleValue(sum)
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func scaleMetric(factor float64) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	if factor <= 0 {
+		return nil, fmt.Errorf("factor must be positive: %v", factor)
+	}
+
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (interface{}, error) {
+		metric := tCtx.GetMetric()
+		switch metric.Type() {
+		case pmetric.MetricTypeSum:
+			scaleNumberDataPoints(metric.Sum().DataPoints(), factor)
+		case pmetric.MetricTypeGauge:
+			scaleNumberDataPoints(metric.Gauge().DataPoints(), factor)
+		case pmetric.MetricTypeHistogram:
+			scaleHistogramDataPoints(metric.Histogram().DataPoints(), factor)
+		case pmetric.MetricTypeSummary:
+			scaleSummaryDataPoints(metric.Summary().DataPoints(), factor)
+		}
+		return nil, nil
+	}, nil
+}
+
+func scaleNumberDataPoints(dps pmetric.NumberDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			dp.SetIntValue(int64(float64(dp.IntValue()) * factor))
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(dp.DoubleValue() * factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor)
+	}
+}
+
+func scaleHistogramDataPoints(dps pmetric.HistogramDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		bounds := dp.ExplicitBounds()
+		for j := 0; j < bounds.Len(); j++ {
+			bounds.SetAt(j, bounds.At(j)*factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor)
+	}
+}
+
+func scaleSummaryDataPoints(dps pmetric.SummaryDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetSum(dp.Sum() * factor)
+		quantiles := dp.QuantileValues()
+		for j := 0; j < quantiles.Len(); j++ {
+			quantiles.At(j).SetValue(quantiles.At(j).Value() * factor)
+		}
+	}
+}
+
+func scaleExemplars(exemplars pmetric.ExemplarSlice, factor float64) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		switch exemplar.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			exemplar.SetIntValue(int64(float64(exemplar.IntValue()) * factor))
+		case pmetric.ExemplarValueTypeDouble:
+			exemplar.SetDoubleValue(exemplar.DoubleValue() * factor)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func Test_ScaleMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  pmetric.Metric
+		factor float64
+		want   func(pmetric.Metric)
+	}{
+		{
+			name: "sum",
+			input: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+				dps.AppendEmpty().SetDoubleValue(1500)
+				dp := dps.AppendEmpty()
+				dp.SetIntValue(2500)
+				dp.Exemplars().AppendEmpty().SetIntValue(2000)
+				return metric
+			}(),
+			factor: 0.001,
+			want: func(metric pmetric.Metric) {
+				metric.SetName("sum_metric")
+				dps := metric.SetEmptySum().DataPoints()
+				dps.AppendEmpty().SetDoubleValue(1.5)
+				dp := dps.AppendEmpty()
+				dp.SetIntValue(2)
+				dp.Exemplars().AppendEmpty().SetIntValue(2)
+			},
+		},
+		{
+			name:   "gauge",
+			input:  getTestGaugeMetric(),
+			factor: 10,
+			want: func(metric pmetric.Metric) {
+				getTestGaugeMetric().CopyTo(metric)
+				metric.Gauge().DataPoints().At(0).SetIntValue(120)
+			},
+		},
+		{
+			name: "histogram",
+			input: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				dp := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+				dp.SetCount(2)
+				dp.SetSum(300)
+				dp.SetMin(100)
+				dp.SetMax(200)
+				dp.ExplicitBounds().FromRaw([]float64{150, 250})
+				dp.BucketCounts().FromRaw([]uint64{1, 1, 0})
+				dp.Exemplars().AppendEmpty().SetDoubleValue(100)
+				return metric
+			}(),
+			factor: 2,
+			want: func(metric pmetric.Metric) {
+				dp := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+				dp.SetCount(2)
+				dp.SetSum(600)
+				dp.SetMin(200)
+				dp.SetMax(400)
+				dp.ExplicitBounds().FromRaw([]float64{300, 500})
+				dp.BucketCounts().FromRaw([]uint64{1, 1, 0})
+				dp.Exemplars().AppendEmpty().SetDoubleValue(200)
+			},
+		},
+		{
+			name:   "summary",
+			input:  getTestSummaryMetric(),
+			factor: 2,
+			want: func(metric pmetric.Metric) {
+				getTestSummaryMetric().CopyTo(metric)
+				dp := metric.Summary().DataPoints().At(0)
+				dp.SetSum(24.68)
+				dp.QuantileValues().At(0).SetValue(2)
+				dp.QuantileValues().At(1).SetValue(4)
+				dp.QuantileValues().At(2).SetValue(6)
+			},
+		},
+		{
+			name: "noop",
+			input: func() pmetric.Metric {
+				metric := pmetric.NewMetric()
+				metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetSum(10)
+				return metric
+			}(),
+			factor: 2,
+			want: func(metric pmetric.Metric) {
+				metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetSum(10)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluate, err := scaleMetric(tt.factor)
+			assert.NoError(t, err)
+
+			_, err = evaluate(nil, ottlmetric.NewTransformContext(tt.input, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			tt.want(expected)
+			assert.Equal(t, expected, tt.input)
+		})
+	}
+}
+
+func Test_ScaleMetric_validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor float64
+	}{
+		{
+			name:   "zero factor",
+			factor: 0,
+		},
+		{
+			name:   "negative factor",
+			factor: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scaleMetric(tt.factor)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -16,6 +16,7 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
@@ -28,11 +29,23 @@ var datapointRegistry = map[string]interface{}{
 	"convert_summary_count_val_to_sum": convertSummaryCountValToSum,
 }
 
+// metricRegistry is a map of names to functions for the metric context of metrics pipelines
+var metricRegistry = map[string]interface{}{
+	"aggregate_on_attributes": aggregateOnAttributes,
+	"scale_metric":            scaleMetric,
+	"extract_count_metric":    extractCountMetric,
+	"extract_sum_metric":      extractSumMetric,
+	"copy_metric":             copyMetric,
+}
+
 func init() {
 	// Init metrics registry with default functions common to all signals
 	for k, v := range common.Functions[ottldatapoint.TransformContext]() {
 		datapointRegistry[k] = v
 	}
+	for k, v := range common.Functions[ottlmetric.TransformContext]() {
+		metricRegistry[k] = v
+	}
 }
 
 func DataPointFunctions() map[string]interface{} {
@@ -40,5 +53,9 @@ func DataPointFunctions() map[string]interface{} {
 }
 
 func MetricFunctions() map[string]interface{} {
-	return common.Functions[ottlmetric.TransformContext]()
+	return metricRegistry
+}
+
+func ExemplarFunctions() map[string]interface{} {
+	return common.Functions[ottlexemplar.TransformContext]()
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
@@ -42,7 +43,23 @@ func Test_DataPointFunctions(t *testing.T) {
 
 func Test_MetricFunctions(t *testing.T) {
 	expected := common.Functions[ottlmetric.TransformContext]()
+	expected["aggregate_on_attributes"] = aggregateOnAttributes
+	expected["scale_metric"] = scaleMetric
+	expected["extract_count_metric"] = extractCountMetric
+	expected["extract_sum_metric"] = extractSumMetric
+	expected["copy_metric"] = copyMetric
+
 	actual := MetricFunctions()
+
+	require.Equal(t, len(expected), len(actual))
+	for k := range actual {
+		assert.Contains(t, expected, k)
+	}
+}
+
+func Test_ExemplarFunctions(t *testing.T) {
+	expected := common.Functions[ottlexemplar.TransformContext]()
+	actual := ExemplarFunctions()
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
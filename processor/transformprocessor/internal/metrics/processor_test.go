@@ -524,6 +524,50 @@ func Test_ProcessMetrics_DataPointContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessMetrics_ExemplarContext(t *testing.T) {
+	tests := []struct {
+		statements []string
+		want       func(pmetric.Metrics)
+	}{
+		{
+			statements: []string{`replace_pattern(filtered_attributes["user.email"], "^.*@", "***@")`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().PutStr("user.email", "***@example.com")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().PutStr("user.email", "***@example.com")
+			},
+		},
+		{
+			statements: []string{`delete_key(filtered_attributes, "user.email") where metric.name == "operationB"`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().Remove("user.email")
+			},
+		},
+		{
+			statements: []string{`set(filtered_attributes["flags"], datapoint.attributes["flags"])`},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().PutStr("flags", "A|B|C")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().PutStr("flags", "C|D")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statements[0], func(t *testing.T) {
+			td := constructMetrics()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "exemplar", Statements: tt.statements}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessMetrics(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructMetrics()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_ProcessMetrics_MixContext(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -682,6 +726,9 @@ func fillMetricOne(m pmetric.Metric) {
 	dataPoint0.Attributes().PutStr("attr3", "test3")
 	dataPoint0.Attributes().PutStr("flags", "A|B|C")
 	dataPoint0.Attributes().PutStr("total.string", "123456789")
+	exemplar0 := dataPoint0.Exemplars().AppendEmpty()
+	exemplar0.SetDoubleValue(1.0)
+	exemplar0.FilteredAttributes().PutStr("user.email", "user@example.com")
 
 	dataPoint1 := m.Sum().DataPoints().AppendEmpty()
 	dataPoint1.SetStartTimestamp(StartTimestamp)
@@ -706,6 +753,9 @@ func fillMetricTwo(m pmetric.Metric) {
 	dataPoint0.Attributes().PutStr("flags", "C|D")
 	dataPoint0.Attributes().PutStr("total.string", "345678")
 	dataPoint0.SetCount(1)
+	exemplar0 := dataPoint0.Exemplars().AppendEmpty()
+	exemplar0.SetDoubleValue(2.5)
+	exemplar0.FilteredAttributes().PutStr("user.email", "user@example.com")
 
 	dataPoint1 := m.Histogram().DataPoints().AppendEmpty()
 	dataPoint1.SetStartTimestamp(StartTimestamp)
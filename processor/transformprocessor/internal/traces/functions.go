@@ -17,6 +17,7 @@ package traces // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
@@ -29,3 +30,8 @@ func SpanEventFunctions() map[string]interface{} {
 	// No trace-only functions yet.
 	return common.Functions[ottlspanevent.TransformContext]()
 }
+
+func SpanLinkFunctions() map[string]interface{} {
+	// No trace-only functions yet.
+	return common.Functions[ottlspanlink.TransformContext]()
+}
@@ -422,6 +422,49 @@ func Test_ProcessTraces_SpanEventContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessTraces_SpanLinkContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td ptrace.Traces)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where attributes["tenant"] == "external"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["span.name"], span.name)`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(0).Attributes().PutStr("span.name", "operationB")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("span.name", "operationB")
+			},
+		},
+		{
+			statement: `delete_key(attributes, "tenant")`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().Remove("tenant")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "spanlink", Statements: []string{tt.statement}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_ProcessTraces_MixContext(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -687,6 +730,7 @@ func fillSpanTwo(span ptrace.Span) {
 	link0.SetDroppedAttributesCount(4)
 	link1 := span.Links().AppendEmpty()
 	link1.SetDroppedAttributesCount(4)
+	link1.Attributes().PutStr("tenant", "external")
 	span.SetDroppedLinksCount(3)
 	status := span.Status()
 	status.SetCode(ptrace.StatusCodeError)
@@ -76,6 +76,9 @@ func TestLoadConfig(t *testing.T) {
 	}
 	assert.EqualValues(t, expected, cfg.Objects)
 
+	storageID := component.NewID("file_storage")
+	assert.Equal(t, &storageID, cfg.StorageID)
+
 }
 
 func TestValidConfigs(t *testing.T) {
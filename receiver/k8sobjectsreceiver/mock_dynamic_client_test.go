@@ -21,8 +21,10 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 type mockDynamicClient struct {
@@ -48,6 +50,26 @@ func (c mockDynamicClient) getMockDynamicClient() (dynamic.Interface, error) {
 	return c.client, nil
 }
 
+// fakeWatch is a watch started by the receiver, along with the resource version it started from
+type fakeWatch struct {
+	resourceVersion string
+	watcher         *apiWatch.FakeWatcher
+}
+
+// fakeWatches makes watches of pods return fake watchers, which are sent to the returned channel
+func (c mockDynamicClient) fakeWatches() chan fakeWatch {
+	watches := make(chan fakeWatch, 10)
+	c.client.(*fake.FakeDynamicClient).PrependWatchReactor("pods", func(action k8stesting.Action) (bool, apiWatch.Interface, error) {
+		watcher := apiWatch.NewFake()
+		watches <- fakeWatch{
+			resourceVersion: action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion,
+			watcher:         watcher,
+		}
+		return true, watcher, nil
+	})
+	return watches
+}
+
 func (c mockDynamicClient) createPods(objects ...*unstructured.Unstructured) {
 	pods := c.client.Resource(schema.GroupVersionResource{
 		Version:  "v1",
@@ -16,14 +16,18 @@ package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiWatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
@@ -31,6 +35,9 @@ import (
 	"k8s.io/client-go/tools/watch"
 )
 
+// relistRetryInterval is the time to wait before listing the objects again after a failed relist
+const relistRetryInterval = 5 * time.Second
+
 type k8sobjectsreceiver struct {
 	setting         receiver.CreateSettings
 	objects         []*K8sObjectsConfig
@@ -38,7 +45,10 @@ type k8sobjectsreceiver struct {
 	client          dynamic.Interface
 	consumer        consumer.Logs
 	obsrecv         *obsreport.Receiver
+	storageID       *component.ID
+	storageClient   storage.Client
 	mu              sync.Mutex
+	wg              sync.WaitGroup
 }
 
 func newReceiver(params receiver.CreateSettings, config *Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -58,18 +68,25 @@ func newReceiver(params receiver.CreateSettings, config *Config, consumer consum
 	}
 
 	return &k8sobjectsreceiver{
-		client:   client,
-		setting:  params,
-		consumer: consumer,
-		objects:  config.Objects,
-		obsrecv:  obsrecv,
-		mu:       sync.Mutex{},
+		client:    client,
+		setting:   params,
+		consumer:  consumer,
+		objects:   config.Objects,
+		obsrecv:   obsrecv,
+		storageID: config.StorageID,
+		mu:        sync.Mutex{},
 	}, nil
 }
 
 func (kr *k8sobjectsreceiver) Start(ctx context.Context, host component.Host) error {
 	kr.setting.Logger.Info("Object Receiver started")
 
+	storageClient, err := getStorageClient(ctx, host, kr.storageID, kr.setting.ID)
+	if err != nil {
+		return err
+	}
+	kr.storageClient = storageClient
+
 	for _, object := range kr.objects {
 		kr.start(ctx, object)
 	}
@@ -83,7 +100,13 @@ func (kr *k8sobjectsreceiver) Shutdown(context.Context) error {
 		close(stopperChan)
 	}
 	kr.mu.Unlock()
-	return nil
+
+	// Wait for the watches to stop before closing the storage they persist resource versions to
+	kr.wg.Wait()
+	if kr.storageClient == nil {
+		return nil
+	}
+	return kr.storageClient.Close(context.Background())
 }
 
 func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfig) {
@@ -102,10 +125,12 @@ func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfi
 
 	case WatchMode:
 		if len(object.Namespaces) == 0 {
-			go kr.startWatch(ctx, object, resource)
+			kr.wg.Add(1)
+			go kr.startWatch(ctx, object, resource, "", kr.newStopperChan())
 		} else {
 			for _, ns := range object.Namespaces {
-				go kr.startWatch(ctx, object, resource.Namespace(ns))
+				kr.wg.Add(1)
+				go kr.startWatch(ctx, object, resource.Namespace(ns), ns, kr.newStopperChan())
 			}
 		}
 	}
@@ -141,25 +166,64 @@ func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsC
 
 }
 
-func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
-
+// newStopperChan registers a channel closed on shutdown. It is created before starting a watch so
+// that shutdown, which waits for the watches, cannot miss it.
+func (kr *k8sobjectsreceiver) newStopperChan() chan struct{} {
 	stopperChan := make(chan struct{})
 	kr.mu.Lock()
 	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
 	kr.mu.Unlock()
+	return stopperChan
+}
 
+func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, namespace string, stopperChan chan struct{}) {
+	defer kr.wg.Done()
+
+	key := resourceVersionKey(config, namespace)
+	resourceVersion := kr.getResourceVersion(ctx, config, key)
+
+	for {
+		var done bool
+		done, resourceVersion = kr.doWatch(ctx, config, resource, resourceVersion, key, stopperChan)
+		if done {
+			return
+		}
+
+		// The resource version expired, list the objects missed in the meantime and resume from the list.
+		for {
+			var err error
+			resourceVersion, err = kr.relist(ctx, config, resource, resourceVersion, key)
+			if err == nil {
+				break
+			}
+			kr.setting.Logger.Error("error in relisting object", zap.String("resource", config.gvr.String()), zap.Error(err))
+			select {
+			case <-time.After(relistRetryInterval):
+			case <-stopperChan:
+				return
+			}
+		}
+	}
+}
+
+// doWatch watches the objects from the given resource version until the receiver is stopped or the
+// resource version expires. It returns whether the watch is done and the last seen resource version.
+func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, resourceVersion string, key string, stopperChan chan struct{}) (bool, string) {
 	watchFunc := func(options metav1.ListOptions) (apiWatch.Interface, error) {
 		return resource.Watch(ctx, metav1.ListOptions{
-			FieldSelector: config.FieldSelector,
-			LabelSelector: config.LabelSelector,
+			FieldSelector:       config.FieldSelector,
+			LabelSelector:       config.LabelSelector,
+			ResourceVersion:     options.ResourceVersion,
+			AllowWatchBookmarks: options.AllowWatchBookmarks,
 		})
 	}
 
-	watch, err := watch.NewRetryWatcher(config.ResourceVersion, &cache.ListWatch{WatchFunc: watchFunc})
+	watch, err := watch.NewRetryWatcher(resourceVersion, &cache.ListWatch{WatchFunc: watchFunc})
 	if err != nil {
 		kr.setting.Logger.Error("error in watching object", zap.String("resource", config.gvr.String()), zap.Error(err))
-		return
+		return true, resourceVersion
 	}
+	defer watch.Stop()
 
 	res := watch.ResultChan()
 	for {
@@ -167,19 +231,127 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 		case data, ok := <-res:
 			if !ok {
 				kr.setting.Logger.Warn("Watch channel closed unexpectedly", zap.String("resource", config.gvr.String()))
-				return
+				return true, resourceVersion
+			}
+
+			if data.Type == apiWatch.Error {
+				err := apierrors.FromObject(data.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					kr.setting.Logger.Info("Resource version expired, relisting", zap.String("resource", config.gvr.String()), zap.String("resource_version", resourceVersion))
+					return false, resourceVersion
+				}
+				kr.setting.Logger.Error("error in watching object", zap.String("resource", config.gvr.String()), zap.Error(err))
+				continue
 			}
+
 			logs := watchObjectsToLogData(&data, time.Now(), config)
 
 			obsCtx := kr.obsrecv.StartLogsOp(ctx)
 			err := kr.consumer.ConsumeLogs(obsCtx, logs)
 			kr.obsrecv.EndLogsOp(obsCtx, typeStr, 1, err)
+
+			if object, ok := data.Object.(metav1.Object); ok && object.GetResourceVersion() != "" {
+				resourceVersion = object.GetResourceVersion()
+				kr.setResourceVersion(ctx, key, resourceVersion)
+			}
 		case <-stopperChan:
-			watch.Stop()
-			return
+			return true, resourceVersion
 		}
 	}
+}
+
+// relist lists the objects and emits those that changed after the last seen resource version
+// as added. It returns the resource version of the list, from which the watch can resume.
+func (kr *k8sobjectsreceiver) relist(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, lastResourceVersion string, key string) (string, error) {
+	objects, err := resource.List(ctx, metav1.ListOptions{
+		FieldSelector: config.FieldSelector,
+		LabelSelector: config.LabelSelector,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for i := range objects.Items {
+		object := &objects.Items[i]
+		if !isNewerResourceVersion(object.GetResourceVersion(), lastResourceVersion) {
+			continue
+		}
+
+		logs := watchObjectsToLogData(&apiWatch.Event{Type: apiWatch.Added, Object: object}, time.Now(), config)
+
+		obsCtx := kr.obsrecv.StartLogsOp(ctx)
+		err := kr.consumer.ConsumeLogs(obsCtx, logs)
+		kr.obsrecv.EndLogsOp(obsCtx, typeStr, 1, err)
+	}
+
+	resourceVersion := objects.GetResourceVersion()
+	if resourceVersion == "" {
+		return lastResourceVersion, nil
+	}
+	kr.setResourceVersion(ctx, key, resourceVersion)
+	return resourceVersion, nil
+}
+
+// getResourceVersion returns the resource version persisted for the key, or the configured one
+func (kr *k8sobjectsreceiver) getResourceVersion(ctx context.Context, config *K8sObjectsConfig, key string) string {
+	value, err := kr.storageClient.Get(ctx, key)
+	if err != nil {
+		kr.setting.Logger.Warn("error in reading resource version from storage", zap.String("key", key), zap.Error(err))
+		return config.ResourceVersion
+	}
+	if len(value) == 0 {
+		return config.ResourceVersion
+	}
+
+	kr.setting.Logger.Info("Resuming watch from persisted resource version", zap.String("resource", config.gvr.String()), zap.String("resource_version", string(value)))
+	return string(value)
+}
+
+// setResourceVersion persists the last seen resource version for the key
+func (kr *k8sobjectsreceiver) setResourceVersion(ctx context.Context, key string, resourceVersion string) {
+	if err := kr.storageClient.Set(ctx, key, []byte(resourceVersion)); err != nil {
+		kr.setting.Logger.Warn("error in persisting resource version to storage", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// resourceVersionKey is the storage key of the resource version of an object in a namespace.
+// An empty namespace stands for all namespaces.
+func resourceVersionKey(config *K8sObjectsConfig, namespace string) string {
+	return fmt.Sprintf("%s/%s", config.gvr.GroupResource().String(), namespace)
+}
+
+// isNewerResourceVersion checks if an object's resource version is newer than the last seen one.
+// Resource versions are opaque, but the API server uses increasing integers, so objects are
+// de-duplicated when both can be compared as such.
+func isNewerResourceVersion(resourceVersion string, lastResourceVersion string) bool {
+	current, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil {
+		return true
+	}
+	last, err := strconv.ParseUint(lastResourceVersion, 10, 64)
+	if err != nil {
+		return true
+	}
+	return current > last
+}
+
+// getStorageClient returns a client of the storage extension, or a no-op client if none is configured
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
 
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
 }
 
 // Start ticking immediately.
@@ -21,9 +21,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestNewReceiver(t *testing.T) {
@@ -150,3 +154,223 @@ func TestWatchObject(t *testing.T) {
 
 	assert.NoError(t, r.Shutdown(ctx))
 }
+
+func TestWatchObjectResumesFromStorage(t *testing.T) {
+	t.Parallel()
+
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	mockClient := newMockDynamicClient()
+	watches := mockClient.fakeWatches()
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), host))
+
+	watch := receiveWatch(t, watches)
+	assert.Equal(t, "1", watch.resourceVersion)
+
+	pod := generatePod("pod1", "default", nil)
+	pod.SetResourceVersion("5")
+	watch.watcher.Add(pod)
+
+	pod = generatePod("pod1", "default", nil)
+	pod.SetResourceVersion("7")
+	watch.watcher.Modify(pod)
+
+	storageClient := r.(*k8sobjectsreceiver).storageClient
+	assert.Eventually(t, func() bool {
+		value, err := storageClient.Get(context.Background(), "pods/default")
+		return err == nil && string(value) == "7"
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, consumer.Count())
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// A new receiver resumes from the persisted resource version
+	r, err = newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), host))
+
+	watch = receiveWatch(t, watches)
+	assert.Equal(t, "7", watch.resourceVersion)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestWatchObjectRelistsOnExpiredResourceVersion(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+	seen := generatePod("pod1", "default", nil)
+	seen.SetResourceVersion("3")
+	missed := generatePod("pod2", "default", nil)
+	missed.SetResourceVersion("9")
+	mockClient.createPods(seen, missed)
+	watches := mockClient.fakeWatches()
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	watch := receiveWatch(t, watches)
+	pod := generatePod("pod3", "default", nil)
+	pod.SetResourceVersion("5")
+	watch.watcher.Add(pod)
+	watch.watcher.Error(&metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   410,
+		Reason: metav1.StatusReasonExpired,
+	})
+
+	// Only the object changed after the last seen resource version is emitted again
+	watch = receiveWatch(t, watches)
+	assert.Equal(t, "5", watch.resourceVersion)
+	require.Equal(t, 2, consumer.Count())
+	logs := consumer.Logs()
+	body := logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map()
+	eventType, ok := body.Get("type")
+	require.True(t, ok)
+	assert.Equal(t, "ADDED", eventType.Str())
+	object, ok := body.Get("object")
+	require.True(t, ok)
+	metadata, ok := object.Map().Get("metadata")
+	require.True(t, ok)
+	name, ok := metadata.Map().Get("name")
+	require.True(t, ok)
+	assert.Equal(t, "pod2", name.Str())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestGetStorageClient(t *testing.T) {
+	t.Parallel()
+
+	storageID := storagetest.NewStorageID("test")
+	nonStorageID := storagetest.NewNonStorageID("test")
+	missingID := storagetest.NewStorageID("missing")
+	host := storagetest.NewStorageHost().
+		WithInMemoryStorageExtension("test").
+		WithNonStorageExtension("test")
+
+	tests := []struct {
+		name      string
+		storageID *component.ID
+		expectErr string
+	}{
+		{
+			name: "no storage",
+		},
+		{
+			name:      "storage",
+			storageID: &storageID,
+		},
+		{
+			name:      "missing storage",
+			storageID: &missingID,
+			expectErr: "storage extension 'test_storage/missing' not found",
+		},
+		{
+			name:      "non storage",
+			storageID: &nonStorageID,
+			expectErr: "non-storage extension 'non_storage/test' found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := getStorageClient(context.Background(), host, tt.storageID, component.NewID(typeStr))
+			if tt.expectErr != "" {
+				assert.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, client)
+			assert.NoError(t, client.Close(context.Background()))
+		})
+	}
+}
+
+func TestIsNewerResourceVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		resourceVersion     string
+		lastResourceVersion string
+		expected            bool
+	}{
+		{
+			name:                "newer",
+			resourceVersion:     "10",
+			lastResourceVersion: "9",
+			expected:            true,
+		},
+		{
+			name:                "same",
+			resourceVersion:     "9",
+			lastResourceVersion: "9",
+			expected:            false,
+		},
+		{
+			name:                "older",
+			resourceVersion:     "8",
+			lastResourceVersion: "9",
+			expected:            false,
+		},
+		{
+			name:                "not comparable",
+			resourceVersion:     "abc",
+			lastResourceVersion: "9",
+			expected:            true,
+		},
+		{
+			name:                "no last resource version",
+			resourceVersion:     "9",
+			lastResourceVersion: "",
+			expected:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isNewerResourceVersion(tt.resourceVersion, tt.lastResourceVersion))
+		})
+	}
+}
+
+func receiveWatch(t *testing.T, watches chan fakeWatch) fakeWatch {
+	select {
+	case watch := <-watches:
+		return watch
+	case <-time.After(time.Second):
+		require.FailNow(t, "watch not started")
+		return fakeWatch{}
+	}
+}
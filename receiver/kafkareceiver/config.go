@@ -15,6 +15,9 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -42,6 +45,33 @@ type MessageMarking struct {
 	OnError bool `mapstructure:"on_error"`
 }
 
+// HeaderExtraction defines the metadata of the messages passed along their data
+type HeaderExtraction struct {
+	// If true, the values of Headers are extracted from the record headers of the messages
+	ExtractHeaders bool `mapstructure:"extract_headers"`
+
+	// The keys of the record headers to extract
+	Headers []string `mapstructure:"headers"`
+
+	// If true, the topic of the messages is extracted
+	ExtractTopic bool `mapstructure:"extract_topic"`
+
+	// Where the extracted values are set, either "resource_attributes" or "client_metadata"
+	// (default "resource_attributes")
+	Destination string `mapstructure:"destination"`
+}
+
+// DeadLetter defines where the messages which cannot be unmarshaled are written
+type DeadLetter struct {
+	// The name of the kafka topic the messages are written to. The messages are not written if empty.
+	Topic string `mapstructure:"topic"`
+}
+
+const (
+	destinationResourceAttributes = "resource_attributes"
+	destinationClientMetadata     = "client_metadata"
+)
+
 // Config defines configuration for Kafka receiver.
 type Config struct {
 	// The list of kafka brokers (default localhost:9092)
@@ -50,8 +80,16 @@ type Config struct {
 	ProtocolVersion string `mapstructure:"protocol_version"`
 	// The name of the kafka topic to consume from (default "otlp_spans")
 	Topic string `mapstructure:"topic"`
+	// The names of the kafka topics to consume from, replacing Topic
+	Topics []string `mapstructure:"topics"`
+	// A regular expression matching the names of the kafka topics to consume from, replacing Topic
+	TopicRegex string `mapstructure:"topic_regex"`
+	// How often the topics matching TopicRegex are listed (default 1m)
+	TopicRefreshInterval time.Duration `mapstructure:"topic_refresh_interval"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
+	// Encoding of the messages of each topic, overriding Encoding
+	TopicEncodings map[string]string `mapstructure:"topic_encodings"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
 	GroupID string `mapstructure:"group_id"`
 	// The consumer client ID that receiver will use (default "otel-collector")
@@ -68,11 +106,61 @@ type Config struct {
 
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
+
+	// Controls the metadata of the messages passed along their data
+	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
+
+	// Controls where the messages which cannot be unmarshaled are written
+	DeadLetter DeadLetter `mapstructure:"dead_letter"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.TopicRegex != "" {
+		if len(cfg.Topics) > 0 {
+			return errors.New("topics and topic_regex cannot be used together")
+		}
+		if _, err := regexp.Compile(cfg.TopicRegex); err != nil {
+			return fmt.Errorf("topic_regex is invalid: %w", err)
+		}
+		if cfg.TopicRefreshInterval <= 0 {
+			return errors.New("topic_refresh_interval must be positive")
+		}
+	}
+
+	if cfg.DeadLetter.Topic != "" && cfg.consumesFrom(cfg.DeadLetter.Topic) {
+		return errors.New("dead_letter.topic must not be one of the topics to consume from")
+	}
+
+	switch cfg.HeaderExtraction.Destination {
+	case destinationResourceAttributes, destinationClientMetadata:
+	default:
+		return fmt.Errorf("header_extraction.destination should be one of '%s' or '%s'. configured value %v",
+			destinationResourceAttributes, destinationClientMetadata, cfg.HeaderExtraction.Destination)
+	}
 	return nil
 }
+
+// staticTopics returns the topics to consume from when they are not matched by a regular expression
+func (cfg *Config) staticTopics() []string {
+	if len(cfg.Topics) > 0 {
+		return cfg.Topics
+	}
+	return []string{cfg.Topic}
+}
+
+// consumesFrom reports whether a topic is one of the topics to consume from
+func (cfg *Config) consumesFrom(topic string) bool {
+	if cfg.TopicRegex != "" {
+		regex, err := regexp.Compile(cfg.TopicRegex)
+		return err == nil && regex.MatchString(topic)
+	}
+	for _, t := range cfg.staticTopics() {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
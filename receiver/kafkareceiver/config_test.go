@@ -42,11 +42,12 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, ""),
 			expected: &Config{
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
-				ClientID: "otel-collector",
-				GroupID:  "otel-collector",
+				Topic:                "spans",
+				TopicRefreshInterval: time.Minute,
+				Encoding:             "otlp_proto",
+				Brokers:              []string{"foo:123", "bar:456"},
+				ClientID:             "otel-collector",
+				GroupID:              "otel-collector",
 				Authentication: kafkaexporter.Authentication{
 					TLS: &configtls.TLSClientSetting{
 						TLSSetting: configtls.TLSSetting{
@@ -67,17 +68,21 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				HeaderExtraction: HeaderExtraction{
+					Destination: "resource_attributes",
+				},
 			},
 		},
 		{
 
 			id: component.NewIDWithName(typeStr, "logs"),
 			expected: &Config{
-				Topic:    "logs",
-				Encoding: "direct",
-				Brokers:  []string{"coffee:123", "foobar:456"},
-				ClientID: "otel-collector",
-				GroupID:  "otel-collector",
+				Topic:                "logs",
+				TopicRefreshInterval: time.Minute,
+				Encoding:             "direct",
+				Brokers:              []string{"coffee:123", "foobar:456"},
+				ClientID:             "otel-collector",
+				GroupID:              "otel-collector",
 				Authentication: kafkaexporter.Authentication{
 					TLS: &configtls.TLSClientSetting{
 						TLSSetting: configtls.TLSSetting{
@@ -98,6 +103,44 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				HeaderExtraction: HeaderExtraction{
+					Destination: "resource_attributes",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "tenants"),
+			expected: &Config{
+				Topic:                "otlp_spans",
+				TopicRegex:           "^otlp-.+$",
+				TopicRefreshInterval: 30 * time.Second,
+				Encoding:             "otlp_proto",
+				TopicEncodings: map[string]string{
+					"otlp-legacy": "jaeger_proto",
+				},
+				Brokers:  []string{"localhost:9092"},
+				ClientID: "otel-collector",
+				GroupID:  "otel-collector",
+				Metadata: kafkaexporter.Metadata{
+					Full: true,
+					Retry: kafkaexporter.MetadataRetry{
+						Max:     3,
+						Backoff: time.Millisecond * 250,
+					},
+				},
+				AutoCommit: AutoCommit{
+					Enable:   true,
+					Interval: 1 * time.Second,
+				},
+				HeaderExtraction: HeaderExtraction{
+					ExtractHeaders: true,
+					Headers:        []string{"tenant"},
+					ExtractTopic:   true,
+					Destination:    "client_metadata",
+				},
+				DeadLetter: DeadLetter{
+					Topic: "dead-letters",
+				},
 			},
 		},
 	}
@@ -116,3 +159,95 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "topics",
+			modify: func(cfg *Config) {
+				cfg.Topics = []string{"otlp-a", "otlp-b"}
+			},
+		},
+		{
+			name: "topics_and_topic_regex",
+			modify: func(cfg *Config) {
+				cfg.Topics = []string{"otlp-a"}
+				cfg.TopicRegex = "^otlp-"
+			},
+			expectedErr: "topics and topic_regex cannot be used together",
+		},
+		{
+			name: "invalid_topic_regex",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "otlp-("
+			},
+			expectedErr: "topic_regex is invalid: error parsing regexp: missing closing ): `otlp-(`",
+		},
+		{
+			name: "invalid_topic_refresh_interval",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "^otlp-"
+				cfg.TopicRefreshInterval = 0
+			},
+			expectedErr: "topic_refresh_interval must be positive",
+		},
+		{
+			name: "dead_letter_topic",
+			modify: func(cfg *Config) {
+				cfg.DeadLetter.Topic = "otlp_spans_dead_letters"
+			},
+		},
+		{
+			name: "dead_letter_topic_consumed",
+			modify: func(cfg *Config) {
+				cfg.Topics = []string{"otlp-a", "otlp-dead-letters"}
+				cfg.DeadLetter.Topic = "otlp-dead-letters"
+			},
+			expectedErr: "dead_letter.topic must not be one of the topics to consume from",
+		},
+		{
+			name: "dead_letter_topic_matched",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "^otlp-"
+				cfg.DeadLetter.Topic = "otlp-dead-letters"
+			},
+			expectedErr: "dead_letter.topic must not be one of the topics to consume from",
+		},
+		{
+			name: "invalid_destination",
+			modify: func(cfg *Config) {
+				cfg.HeaderExtraction.Destination = "attributes"
+			},
+			expectedErr: "header_extraction.destination should be one of 'resource_attributes' or 'client_metadata'. configured value attributes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestStaticTopics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, []string{"otlp_spans"}, cfg.staticTopics())
+
+	cfg.Topics = []string{"otlp-a", "otlp-b"}
+	assert.Equal(t, []string{"otlp-a", "otlp-b"}, cfg.staticTopics())
+}
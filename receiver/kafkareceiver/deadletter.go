@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// The record headers set on the messages written to the dead letter topic
+const (
+	deadLetterHeaderError     = "kafka.dead_letter.error"
+	deadLetterHeaderEncoding  = "kafka.dead_letter.encoding"
+	deadLetterHeaderTopic     = "kafka.dead_letter.topic"
+	deadLetterHeaderPartition = "kafka.dead_letter.partition"
+	deadLetterHeaderOffset    = "kafka.dead_letter.offset"
+)
+
+// deadLetterWriter writes the messages which cannot be unmarshaled to the dead letter topic,
+// so that they don't block their partition nor are lost. It is nil when there is no dead letter topic.
+type deadLetterWriter struct {
+	topic    string
+	producer sarama.SyncProducer
+	logger   *zap.Logger
+}
+
+func newDeadLetterWriter(config Config, logger *zap.Logger) (*deadLetterWriter, error) {
+	if config.DeadLetter.Topic == "" {
+		return nil, nil
+	}
+	c, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	c.Producer.Return.Successes = true
+	c.Producer.Return.Errors = true
+	producer, err := sarama.NewSyncProducer(config.Brokers, c)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterWriter{
+		topic:    config.DeadLetter.Topic,
+		producer: producer,
+		logger:   logger,
+	}, nil
+}
+
+// newDeadLetterMessage returns the message written to the dead letter topic for a message which
+// cannot be unmarshaled. It has the same key, value and record headers, and record headers describing the failure.
+func newDeadLetterMessage(topic string, message *sarama.ConsumerMessage, encoding string, cause error) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+5)
+	for _, header := range message.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderError), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderEncoding), Value: []byte(encoding)},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderPartition), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+
+	deadLetter := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		deadLetter.Key = sarama.ByteEncoder(message.Key)
+	}
+	return deadLetter
+}
+
+// write writes a message which cannot be unmarshaled to the dead letter topic,
+// and reports whether it was written.
+func (d *deadLetterWriter) write(ctx context.Context, id component.ID, message *sarama.ConsumerMessage, encoding string, cause error) bool {
+	if d == nil {
+		return false
+	}
+
+	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, id.String())}
+	if _, _, err := d.producer.SendMessage(newDeadLetterMessage(d.topic, message, encoding, cause)); err != nil {
+		d.logger.Error("Failed to write the message to the dead letter topic",
+			zap.String("dead_letter_topic", d.topic),
+			zap.String("topic", message.Topic),
+			zap.Int32("partition", message.Partition),
+			zap.Int64("offset", message.Offset),
+			zap.Error(err))
+		_ = stats.RecordWithTags(ctx, statsTags, statDeadLetterFailed.M(1))
+		return false
+	}
+
+	d.logger.Warn("Wrote the message to the dead letter topic",
+		zap.String("dead_letter_topic", d.topic),
+		zap.String("topic", message.Topic),
+		zap.Int32("partition", message.Partition),
+		zap.Int64("offset", message.Offset))
+	_ = stats.RecordWithTags(ctx, statsTags, statDeadLetterWritten.M(1))
+	return true
+}
+
+func (d *deadLetterWriter) close() error {
+	if d == nil {
+		return nil
+	}
+	return d.producer.Close()
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// recordingProducer records the messages sent to a mock producer
+type recordingProducer struct {
+	*mocks.SyncProducer
+	messages []*sarama.ProducerMessage
+}
+
+func (p *recordingProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	p.messages = append(p.messages, message)
+	return p.SyncProducer.SendMessage(message)
+}
+
+func newTestDeadLetterWriter(t *testing.T) (*deadLetterWriter, *recordingProducer) {
+	producer := &recordingProducer{SyncProducer: mocks.NewSyncProducer(t, nil)}
+	return &deadLetterWriter{topic: "dead-letters", producer: producer, logger: zap.NewNop()}, producer
+}
+
+func TestNewDeadLetterWriter(t *testing.T) {
+	d, err := newDeadLetterWriter(Config{}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = newDeadLetterWriter(Config{ProtocolVersion: "none", DeadLetter: DeadLetter{Topic: "dead-letters"}}, zap.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, d)
+}
+
+func TestNewDeadLetterMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  *sarama.ConsumerMessage
+		expected *sarama.ProducerMessage
+	}{
+		{
+			name: "without_key",
+			message: &sarama.ConsumerMessage{
+				Topic:     "otlp-a",
+				Partition: 3,
+				Offset:    42,
+				Value:     []byte("!@#"),
+			},
+			expected: &sarama.ProducerMessage{
+				Topic: "dead-letters",
+				Value: sarama.ByteEncoder("!@#"),
+				Headers: []sarama.RecordHeader{
+					{Key: []byte("kafka.dead_letter.error"), Value: []byte("unexpected EOF")},
+					{Key: []byte("kafka.dead_letter.encoding"), Value: []byte("otlp_proto")},
+					{Key: []byte("kafka.dead_letter.topic"), Value: []byte("otlp-a")},
+					{Key: []byte("kafka.dead_letter.partition"), Value: []byte("3")},
+					{Key: []byte("kafka.dead_letter.offset"), Value: []byte("42")},
+				},
+			},
+		},
+		{
+			name: "with_key_and_headers",
+			message: &sarama.ConsumerMessage{
+				Topic:   "otlp-a",
+				Key:     []byte("tenant-a"),
+				Value:   []byte("!@#"),
+				Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("a")}},
+			},
+			expected: &sarama.ProducerMessage{
+				Topic: "dead-letters",
+				Key:   sarama.ByteEncoder("tenant-a"),
+				Value: sarama.ByteEncoder("!@#"),
+				Headers: []sarama.RecordHeader{
+					{Key: []byte("tenant"), Value: []byte("a")},
+					{Key: []byte("kafka.dead_letter.error"), Value: []byte("unexpected EOF")},
+					{Key: []byte("kafka.dead_letter.encoding"), Value: []byte("otlp_proto")},
+					{Key: []byte("kafka.dead_letter.topic"), Value: []byte("otlp-a")},
+					{Key: []byte("kafka.dead_letter.partition"), Value: []byte("0")},
+					{Key: []byte("kafka.dead_letter.offset"), Value: []byte("0")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := newDeadLetterMessage("dead-letters", tt.message, defaultEncoding, errors.New("unexpected EOF"))
+			assert.Equal(t, tt.expected, message)
+		})
+	}
+}
+
+func TestDeadLetterWriterWrite(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	d, producer := newTestDeadLetterWriter(t)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+	id := component.NewID(typeStr)
+	message := &sarama.ConsumerMessage{Topic: "otlp-a", Value: []byte("!@#")}
+	assert.True(t, d.write(context.Background(), id, message, defaultEncoding, errors.New("unexpected EOF")))
+	assert.False(t, d.write(context.Background(), id, message, defaultEncoding, errors.New("unexpected EOF")))
+	require.Len(t, producer.messages, 2)
+	assert.Equal(t, "dead-letters", producer.messages[0].Topic)
+
+	for _, stat := range []string{statDeadLetterWritten.Name(), statDeadLetterFailed.Name()} {
+		viewData, err := view.RetrieveData(stat)
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value, stat)
+	}
+
+	require.NoError(t, d.close())
+}
+
+func TestNilDeadLetterWriter(t *testing.T) {
+	var d *deadLetterWriter
+	message := &sarama.ConsumerMessage{Topic: "otlp-a", Value: []byte("!@#")}
+	assert.False(t, d.write(context.Background(), component.NewID(typeStr), message, defaultEncoding, errors.New("unexpected EOF")))
+	assert.NoError(t, d.close())
+}
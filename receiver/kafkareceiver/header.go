@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// headerKeyPrefix prefixes the record header keys in the names of the extracted values
+	headerKeyPrefix = "kafka.header."
+	// topicKey is the name of the extracted topic
+	topicKey = "kafka.topic"
+)
+
+// headerExtractor passes the topic and the record headers of the messages along their data,
+// either as resource attributes or as client metadata. It is nil when nothing is extracted.
+type headerExtractor struct {
+	headers      map[string]bool
+	extractTopic bool
+	toMetadata   bool
+}
+
+func newHeaderExtractor(config HeaderExtraction) *headerExtractor {
+	headers := make(map[string]bool)
+	if config.ExtractHeaders {
+		for _, header := range config.Headers {
+			headers[header] = true
+		}
+	}
+	if len(headers) == 0 && !config.ExtractTopic {
+		return nil
+	}
+	return &headerExtractor{
+		headers:      headers,
+		extractTopic: config.ExtractTopic,
+		toMetadata:   config.Destination == destinationClientMetadata,
+	}
+}
+
+// values returns the values extracted from a message by their name.
+// A record header may be repeated, in which case all its values are returned in order.
+func (h *headerExtractor) values(message *sarama.ConsumerMessage) map[string][]string {
+	values := make(map[string][]string)
+	if h.extractTopic {
+		values[topicKey] = []string{message.Topic}
+	}
+	for _, header := range message.Headers {
+		if header == nil || !h.headers[string(header.Key)] {
+			continue
+		}
+		name := headerKeyPrefix + string(header.Key)
+		values[name] = append(values[name], string(header.Value))
+	}
+	return values
+}
+
+// contextWithMetadata returns the context the data of a message is consumed with,
+// carrying the extracted values as client metadata when they are not set as resource attributes.
+func (h *headerExtractor) contextWithMetadata(ctx context.Context, message *sarama.ConsumerMessage) context.Context {
+	if h == nil || !h.toMetadata {
+		return ctx
+	}
+	info := client.FromContext(ctx)
+	info.Metadata = client.NewMetadata(h.values(message))
+	return client.NewContext(ctx, info)
+}
+
+// setAttributes sets the extracted values as attributes of a resource, keeping the first value of repeated headers
+func setAttributes(resource pcommon.Resource, values map[string][]string) {
+	for name, value := range values {
+		resource.Attributes().PutStr(name, value[0])
+	}
+}
+
+// extractTraces sets the values extracted from a message on the resources of its traces
+func (h *headerExtractor) extractTraces(message *sarama.ConsumerMessage, traces ptrace.Traces) {
+	if h == nil || h.toMetadata {
+		return
+	}
+	values := h.values(message)
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		setAttributes(rss.At(i).Resource(), values)
+	}
+}
+
+// extractMetrics sets the values extracted from a message on the resources of its metrics
+func (h *headerExtractor) extractMetrics(message *sarama.ConsumerMessage, metrics pmetric.Metrics) {
+	if h == nil || h.toMetadata {
+		return
+	}
+	values := h.values(message)
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		setAttributes(rms.At(i).Resource(), values)
+	}
+}
+
+// extractLogs sets the values extracted from a message on the resources of its logs
+func (h *headerExtractor) extractLogs(message *sarama.ConsumerMessage, logs plog.Logs) {
+	if h == nil || h.toMetadata {
+		return
+	}
+	values := h.values(message)
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		setAttributes(rls.At(i).Resource(), values)
+	}
+}
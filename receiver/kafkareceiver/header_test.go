@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var testHeaderMessage = &sarama.ConsumerMessage{
+	Topic: "otlp-a",
+	Headers: []*sarama.RecordHeader{
+		{Key: []byte("tenant"), Value: []byte("a")},
+		{Key: []byte("region"), Value: []byte("eu")},
+		{Key: []byte("tenant"), Value: []byte("b")},
+		{Key: []byte("token"), Value: []byte("secret")},
+	},
+}
+
+func TestNewHeaderExtractor(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   HeaderExtraction
+		expected *headerExtractor
+	}{
+		{
+			name:   "disabled",
+			config: HeaderExtraction{Headers: []string{"tenant"}, Destination: destinationResourceAttributes},
+		},
+		{
+			name:   "no_headers",
+			config: HeaderExtraction{ExtractHeaders: true, Destination: destinationResourceAttributes},
+		},
+		{
+			name:   "headers",
+			config: HeaderExtraction{ExtractHeaders: true, Headers: []string{"tenant"}, Destination: destinationResourceAttributes},
+			expected: &headerExtractor{
+				headers: map[string]bool{"tenant": true},
+			},
+		},
+		{
+			name:   "topic_to_metadata",
+			config: HeaderExtraction{ExtractTopic: true, Destination: destinationClientMetadata},
+			expected: &headerExtractor{
+				headers:      map[string]bool{},
+				extractTopic: true,
+				toMetadata:   true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newHeaderExtractor(tt.config))
+		})
+	}
+}
+
+func TestHeaderExtractorValues(t *testing.T) {
+	h := newHeaderExtractor(HeaderExtraction{
+		ExtractHeaders: true,
+		Headers:        []string{"tenant", "region", "missing"},
+		ExtractTopic:   true,
+	})
+	assert.Equal(t, map[string][]string{
+		"kafka.topic":         {"otlp-a"},
+		"kafka.header.tenant": {"a", "b"},
+		"kafka.header.region": {"eu"},
+	}, h.values(testHeaderMessage))
+}
+
+func TestHeaderExtractorResourceAttributes(t *testing.T) {
+	h := newHeaderExtractor(HeaderExtraction{
+		ExtractHeaders: true,
+		Headers:        []string{"tenant", "region"},
+		ExtractTopic:   true,
+		Destination:    destinationResourceAttributes,
+	})
+	expected := map[string]interface{}{
+		"service.name":        "app",
+		"kafka.topic":         "otlp-a",
+		"kafka.header.tenant": "a",
+		"kafka.header.region": "eu",
+	}
+
+	traces := ptrace.NewTraces()
+	for i := 0; i < 2; i++ {
+		traces.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "app")
+	}
+	h.extractTraces(testHeaderMessage, traces)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		assert.Equal(t, expected, traces.ResourceSpans().At(i).Resource().Attributes().AsRaw())
+	}
+
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "app")
+	h.extractMetrics(testHeaderMessage, metrics)
+	assert.Equal(t, expected, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", "app")
+	h.extractLogs(testHeaderMessage, logs)
+	assert.Equal(t, expected, logs.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+
+	// The context is unchanged
+	ctx := context.Background()
+	assert.Equal(t, ctx, h.contextWithMetadata(ctx, testHeaderMessage))
+}
+
+func TestHeaderExtractorClientMetadata(t *testing.T) {
+	h := newHeaderExtractor(HeaderExtraction{
+		ExtractHeaders: true,
+		Headers:        []string{"tenant"},
+		ExtractTopic:   true,
+		Destination:    destinationClientMetadata,
+	})
+
+	info := client.FromContext(h.contextWithMetadata(context.Background(), testHeaderMessage))
+	assert.Equal(t, []string{"otlp-a"}, info.Metadata.Get("kafka.topic"))
+	assert.Equal(t, []string{"a", "b"}, info.Metadata.Get("kafka.header.tenant"))
+	assert.Empty(t, info.Metadata.Get("kafka.header.token"))
+
+	// The resources are unchanged
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty()
+	h.extractTraces(testHeaderMessage, traces)
+	assert.Equal(t, 0, traces.ResourceSpans().At(0).Resource().Attributes().Len())
+}
+
+func TestNilHeaderExtractor(t *testing.T) {
+	var h *headerExtractor
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, h.contextWithMetadata(ctx, testHeaderMessage))
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty()
+	h.extractTraces(testHeaderMessage, traces)
+	assert.Equal(t, 0, traces.ResourceSpans().At(0).Resource().Attributes().Len())
+}
@@ -17,6 +17,7 @@ package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collect
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sync"
 
 	"github.com/Shopify/sarama"
@@ -41,9 +42,12 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 type kafkaTracesConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Traces
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	topicUnmarshalers map[string]TracesUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
 
 	settings receiver.CreateSettings
 
@@ -55,9 +59,12 @@ type kafkaTracesConsumer struct {
 type kafkaMetricsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Metrics
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	topicUnmarshalers map[string]MetricsUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
 
 	settings receiver.CreateSettings
 
@@ -69,9 +76,12 @@ type kafkaMetricsConsumer struct {
 type kafkaLogsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Logs
-	topics            []string
+	subscription      topicSubscription
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	topicUnmarshalers map[string]LogsUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
 
 	settings receiver.CreateSettings
 
@@ -83,12 +93,8 @@ var _ receiver.Traces = (*kafkaTracesConsumer)(nil)
 var _ receiver.Metrics = (*kafkaMetricsConsumer)(nil)
 var _ receiver.Logs = (*kafkaLogsConsumer)(nil)
 
-func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
-	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
+// newSaramaConfig returns the configuration of the sarama clients of a receiver
+func newSaramaConfig(config Config) (*sarama.Config, error) {
 	c := sarama.NewConfig()
 	c.ClientID = config.ClientID
 	c.Metadata.Full = config.Metadata.Full
@@ -106,15 +112,85 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	return c, nil
+}
+
+// newConsumerGroup creates the consumer group of a receiver and its subscription to the topics to consume from
+func newConsumerGroup(config Config) (sarama.ConsumerGroup, topicSubscription, error) {
+	c, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, topicSubscription{}, err
+	}
+
+	if config.TopicRegex == "" {
+		group, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+		if err != nil {
+			return nil, topicSubscription{}, err
+		}
+		return group, topicSubscription{topics: config.staticTopics()}, nil
+	}
+
+	regex, err := regexp.Compile(config.TopicRegex)
+	if err != nil {
+		return nil, topicSubscription{}, err
+	}
+	// The topics matching the regular expression are listed with the client of the consumer group
+	client, err := sarama.NewClient(config.Brokers, c)
+	if err != nil {
+		return nil, topicSubscription{}, err
+	}
+	group, err := sarama.NewConsumerGroupFromClient(config.GroupID, client)
+	if err != nil {
+		_ = client.Close()
+		return nil, topicSubscription{}, err
+	}
+	return group, topicSubscription{
+		regex:           regex,
+		lister:          client,
+		refreshInterval: config.TopicRefreshInterval,
+	}, nil
+}
+
+// unmarshalersByTopic returns the unmarshalers of the topics whose encoding is configured
+func unmarshalersByTopic[T any](unmarshalers map[string]T, encodings map[string]string) (map[string]T, error) {
+	topicUnmarshalers := make(map[string]T, len(encodings))
+	for topic, encoding := range encodings {
+		unmarshaler, ok := unmarshalers[encoding]
+		if !ok {
+			return nil, fmt.Errorf("%w %q of topic %q", errUnrecognizedEncoding, encoding, topic)
+		}
+		topicUnmarshalers[topic] = unmarshaler
+	}
+	return topicUnmarshalers, nil
+}
+
+func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
+	unmarshaler := unmarshalers[config.Encoding]
+	if unmarshaler == nil {
+		return nil, errUnrecognizedEncoding
+	}
+
+	topicUnmarshalers, err := unmarshalersByTopic(unmarshalers, config.TopicEncodings)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, err := newDeadLetterWriter(config, set.Logger)
 	if err != nil {
 		return nil, err
 	}
+	client, subscription, err := newConsumerGroup(config)
+	if err != nil {
+		_ = deadLetter.close()
+		return nil, err
+	}
 	return &kafkaTracesConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
+		deadLetter:        deadLetter,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -135,6 +211,9 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 	consumerGroup := &tracesConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
+		headerExtractor:   c.headerExtractor,
+		deadLetter:        c.deadLetter,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
@@ -150,25 +229,19 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 	return nil
 }
 
-func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
-	for {
-		// `Consume` should be called inside an infinite loop, when a
-		// server-side rebalance happens, the consumer session will need to be
-		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
-			c.settings.Logger.Error("Error from consumer", zap.Error(err))
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
-			return ctx.Err()
-		}
-	}
+func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler consumerGroupHandler) error {
+	return consumeLoop(ctx, c.consumerGroup, &c.subscription, handler, c.settings.Logger)
 }
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	if err := c.consumerGroup.Close(); err != nil {
+		return err
+	}
+	if err := c.subscription.close(); err != nil {
+		return err
+	}
+	return c.deadLetter.close()
 }
 
 func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
@@ -177,33 +250,27 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		return nil, errUnrecognizedEncoding
 	}
 
-	c := sarama.NewConfig()
-	c.ClientID = config.ClientID
-	c.Metadata.Full = config.Metadata.Full
-	c.Metadata.Retry.Max = config.Metadata.Retry.Max
-	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
-	c.Consumer.Offsets.AutoCommit.Enable = config.AutoCommit.Enable
-	c.Consumer.Offsets.AutoCommit.Interval = config.AutoCommit.Interval
-
-	if config.ProtocolVersion != "" {
-		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
-		if err != nil {
-			return nil, err
-		}
-		c.Version = version
+	topicUnmarshalers, err := unmarshalersByTopic(unmarshalers, config.TopicEncodings)
+	if err != nil {
+		return nil, err
 	}
-	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
+	deadLetter, err := newDeadLetterWriter(config, set.Logger)
+	if err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, subscription, err := newConsumerGroup(config)
 	if err != nil {
+		_ = deadLetter.close()
 		return nil, err
 	}
 	return &kafkaMetricsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
+		deadLetter:        deadLetter,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -224,6 +291,9 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 	metricsConsumerGroup := &metricsConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
+		headerExtractor:   c.headerExtractor,
+		deadLetter:        c.deadLetter,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
@@ -239,25 +309,19 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 	return nil
 }
 
-func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
-	for {
-		// `Consume` should be called inside an infinite loop, when a
-		// server-side rebalance happens, the consumer session will need to be
-		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
-			c.settings.Logger.Error("Error from consumer", zap.Error(err))
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
-			return ctx.Err()
-		}
-	}
+func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler consumerGroupHandler) error {
+	return consumeLoop(ctx, c.consumerGroup, &c.subscription, handler, c.settings.Logger)
 }
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	if err := c.consumerGroup.Close(); err != nil {
+		return err
+	}
+	if err := c.subscription.close(); err != nil {
+		return err
+	}
+	return c.deadLetter.close()
 }
 
 func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
@@ -266,32 +330,27 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 		return nil, errUnrecognizedEncoding
 	}
 
-	c := sarama.NewConfig()
-	c.ClientID = config.ClientID
-	c.Metadata.Full = config.Metadata.Full
-	c.Metadata.Retry.Max = config.Metadata.Retry.Max
-	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
-	c.Consumer.Offsets.AutoCommit.Enable = config.AutoCommit.Enable
-	c.Consumer.Offsets.AutoCommit.Interval = config.AutoCommit.Interval
-	if config.ProtocolVersion != "" {
-		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
-		if err != nil {
-			return nil, err
-		}
-		c.Version = version
+	topicUnmarshalers, err := unmarshalersByTopic(unmarshalers, config.TopicEncodings)
+	if err != nil {
+		return nil, err
 	}
-	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
+	deadLetter, err := newDeadLetterWriter(config, set.Logger)
+	if err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, subscription, err := newConsumerGroup(config)
 	if err != nil {
+		_ = deadLetter.close()
 		return nil, err
 	}
 	return &kafkaLogsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		subscription:      subscription,
+		deadLetter:        deadLetter,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -313,6 +372,9 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 	logsConsumerGroup := &logsConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
+		headerExtractor:   c.headerExtractor,
+		deadLetter:        c.deadLetter,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
@@ -328,33 +390,30 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 	return nil
 }
 
-func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
-	for {
-		// `Consume` should be called inside an infinite loop, when a
-		// server-side rebalance happens, the consumer session will need to be
-		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
-			c.settings.Logger.Error("Error from consumer", zap.Error(err))
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			c.settings.Logger.Info("Consumer stopped", zap.Error(ctx.Err()))
-			return ctx.Err()
-		}
-	}
+func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler consumerGroupHandler) error {
+	return consumeLoop(ctx, c.consumerGroup, &c.subscription, handler, c.settings.Logger)
 }
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	if err := c.consumerGroup.Close(); err != nil {
+		return err
+	}
+	if err := c.subscription.close(); err != nil {
+		return err
+	}
+	return c.deadLetter.close()
 }
 
 type tracesConsumerGroupHandler struct {
-	id           component.ID
-	unmarshaler  TracesUnmarshaler
-	nextConsumer consumer.Traces
-	ready        chan bool
-	readyCloser  sync.Once
+	id                component.ID
+	unmarshaler       TracesUnmarshaler
+	topicUnmarshalers map[string]TracesUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
+	nextConsumer      consumer.Traces
+	ready             chan bool
+	readyCloser       sync.Once
 
 	logger *zap.Logger
 
@@ -365,11 +424,14 @@ type tracesConsumerGroupHandler struct {
 }
 
 type metricsConsumerGroupHandler struct {
-	id           component.ID
-	unmarshaler  MetricsUnmarshaler
-	nextConsumer consumer.Metrics
-	ready        chan bool
-	readyCloser  sync.Once
+	id                component.ID
+	unmarshaler       MetricsUnmarshaler
+	topicUnmarshalers map[string]MetricsUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
+	nextConsumer      consumer.Metrics
+	ready             chan bool
+	readyCloser       sync.Once
 
 	logger *zap.Logger
 
@@ -380,11 +442,14 @@ type metricsConsumerGroupHandler struct {
 }
 
 type logsConsumerGroupHandler struct {
-	id           component.ID
-	unmarshaler  LogsUnmarshaler
-	nextConsumer consumer.Logs
-	ready        chan bool
-	readyCloser  sync.Once
+	id                component.ID
+	unmarshaler       LogsUnmarshaler
+	topicUnmarshalers map[string]LogsUnmarshaler
+	headerExtractor   *headerExtractor
+	deadLetter        *deadLetterWriter
+	nextConsumer      consumer.Logs
+	ready             chan bool
+	readyCloser       sync.Once
 
 	logger *zap.Logger
 
@@ -394,14 +459,26 @@ type logsConsumerGroupHandler struct {
 	messageMarking    MessageMarking
 }
 
-var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
-var _ sarama.ConsumerGroupHandler = (*metricsConsumerGroupHandler)(nil)
-var _ sarama.ConsumerGroupHandler = (*logsConsumerGroupHandler)(nil)
+var _ consumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
+var _ consumerGroupHandler = (*metricsConsumerGroupHandler)(nil)
+var _ consumerGroupHandler = (*logsConsumerGroupHandler)(nil)
 
-func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+func (c *tracesConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+// unmarshalerFor returns the unmarshaler of the messages of a topic
+func (c *tracesConsumerGroupHandler) unmarshalerFor(topic string) TracesUnmarshaler {
+	if unmarshaler, ok := c.topicUnmarshalers[topic]; ok {
+		return unmarshaler
+	}
+	return c.unmarshaler
+}
+
+func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
 	return nil
@@ -439,18 +516,30 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			traces, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := c.unmarshalerFor(message.Topic)
+			traces, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
+				if c.deadLetter.write(ctx, c.id, message, unmarshaler.Encoding(), err) {
+					// The message is handled once written to the dead letter topic
+					if c.messageMarking.After {
+						session.MarkMessage(message, "")
+					}
+					if !c.autocommitEnabled {
+						session.Commit()
+					}
+					continue
+				}
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
 
+			c.headerExtractor.extractTraces(message, traces)
 			spanCount := traces.SpanCount()
-			err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
-			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
+			err = c.nextConsumer.ConsumeTraces(c.headerExtractor.contextWithMetadata(session.Context(), message), traces)
+			c.obsrecv.EndTracesOp(ctx, unmarshaler.Encoding(), spanCount, err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
@@ -473,10 +562,22 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 	}
 }
 
-func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+func (c *metricsConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+// unmarshalerFor returns the unmarshaler of the messages of a topic
+func (c *metricsConsumerGroupHandler) unmarshalerFor(topic string) MetricsUnmarshaler {
+	if unmarshaler, ok := c.topicUnmarshalers[topic]; ok {
+		return unmarshaler
+	}
+	return c.unmarshaler
+}
+
+func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
 	return nil
@@ -514,18 +615,30 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			metrics, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := c.unmarshalerFor(message.Topic)
+			metrics, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
+				if c.deadLetter.write(ctx, c.id, message, unmarshaler.Encoding(), err) {
+					// The message is handled once written to the dead letter topic
+					if c.messageMarking.After {
+						session.MarkMessage(message, "")
+					}
+					if !c.autocommitEnabled {
+						session.Commit()
+					}
+					continue
+				}
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
 
+			c.headerExtractor.extractMetrics(message, metrics)
 			dataPointCount := metrics.DataPointCount()
-			err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
-			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
+			err = c.nextConsumer.ConsumeMetrics(c.headerExtractor.contextWithMetadata(session.Context(), message), metrics)
+			c.obsrecv.EndMetricsOp(ctx, unmarshaler.Encoding(), dataPointCount, err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
@@ -548,10 +661,22 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 	}
 }
 
-func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+func (c *logsConsumerGroupHandler) setReady() {
 	c.readyCloser.Do(func() {
 		close(c.ready)
 	})
+}
+
+// unmarshalerFor returns the unmarshaler of the messages of a topic
+func (c *logsConsumerGroupHandler) unmarshalerFor(topic string) LogsUnmarshaler {
+	if unmarshaler, ok := c.topicUnmarshalers[topic]; ok {
+		return unmarshaler
+	}
+	return c.unmarshaler
+}
+
+func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.setReady()
 	_ = stats.RecordWithTags(
 		session.Context(),
 		[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
@@ -594,18 +719,30 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			logs, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := c.unmarshalerFor(message.Topic)
+			logs, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
+				if c.deadLetter.write(ctx, c.id, message, unmarshaler.Encoding(), err) {
+					// The message is handled once written to the dead letter topic
+					if c.messageMarking.After {
+						session.MarkMessage(message, "")
+					}
+					if !c.autocommitEnabled {
+						session.Commit()
+					}
+					continue
+				}
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
 
-			err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+			c.headerExtractor.extractLogs(message, logs)
+			err = c.nextConsumer.ConsumeLogs(c.headerExtractor.contextWithMetadata(session.Context(), message), logs)
 			// TODO
-			c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
+			c.obsrecv.EndLogsOp(ctx, unmarshaler.Encoding(), logs.LogRecordCount(), err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
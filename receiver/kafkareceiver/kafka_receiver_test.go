@@ -61,6 +61,18 @@ func TestNewTracesReceiver_encoding_err(t *testing.T) {
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
+func TestNewTracesReceiver_topic_encoding_err(t *testing.T) {
+	c := Config{
+		Encoding:       defaultEncoding,
+		TopicEncodings: map[string]string{"otlp-a": "foo"},
+	}
+	r, err := newTracesReceiver(c, receivertest.NewNopCreateSettings(), defaultTracesUnmarshalers(), consumertest.NewNop())
+	require.Error(t, err)
+	assert.Nil(t, r)
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+	assert.EqualError(t, err, `unrecognized encoding "foo" of topic "otlp-a"`)
+}
+
 func TestNewTracesReceiver_err_auth_type(t *testing.T) {
 	c := Config{
 		ProtocolVersion: "2.0.0",
@@ -254,6 +266,79 @@ func TestTracesConsumerGroupHandler_error_unmarshal(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTracesConsumerGroupHandler_dead_letter(t *testing.T) {
+	tests := []struct {
+		name        string
+		writeErr    error
+		expectedErr bool
+	}{
+		{
+			name: "written",
+		},
+		{
+			name:        "failed",
+			writeErr:    sarama.ErrOutOfBrokers,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
+			require.NoError(t, err)
+			deadLetter, producer := newTestDeadLetterWriter(t)
+			if tt.writeErr != nil {
+				producer.ExpectSendMessageAndFail(tt.writeErr)
+			} else {
+				producer.ExpectSendMessageAndSucceed()
+			}
+			sink := &consumertest.TracesSink{}
+			c := tracesConsumerGroupHandler{
+				unmarshaler:    newPdataTracesUnmarshaler(&ptrace.ProtoUnmarshaler{}, defaultEncoding),
+				deadLetter:     deadLetter,
+				logger:         zap.NewNop(),
+				ready:          make(chan bool),
+				nextConsumer:   sink,
+				obsrecv:        obsrecv,
+				messageMarking: MessageMarking{After: true},
+			}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			groupClaim := &testConsumerGroupClaim{
+				messageChan: make(chan *sarama.ConsumerMessage),
+			}
+			go func() {
+				err := c.ConsumeClaim(testConsumerGroupSession{ctx: context.Background()}, groupClaim)
+				if tt.expectedErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+				wg.Done()
+			}()
+
+			groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: testTopic, Value: []byte("!@#")}
+			if !tt.expectedErr {
+				// The partition isn't blocked by the message written to the dead letter topic
+				td := ptrace.NewTraces()
+				td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				bts, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+				require.NoError(t, err)
+				groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: testTopic, Value: bts}
+			}
+			close(groupClaim.messageChan)
+			wg.Wait()
+
+			require.Len(t, producer.messages, 1)
+			assert.Equal(t, sarama.ByteEncoder("!@#"), producer.messages[0].Value)
+			if !tt.expectedErr {
+				assert.Equal(t, 1, sink.SpanCount())
+			}
+		})
+	}
+}
+
 func TestTracesConsumerGroupHandler_error_nextConsumer(t *testing.T) {
 	consumerError := errors.New("failed to consume")
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
@@ -775,6 +860,52 @@ func TestLogsConsumerGroupHandler_error_nextConsumer(t *testing.T) {
 	wg.Wait()
 }
 
+func TestLogsConsumerGroupHandler_topic_encoding_and_headers(t *testing.T) {
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
+	require.NoError(t, err)
+	sink := &consumertest.LogsSink{}
+	c := logsConsumerGroupHandler{
+		unmarshaler:       newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
+		topicUnmarshalers: map[string]LogsUnmarshaler{"raw-logs": newRawLogsUnmarshaler()},
+		headerExtractor: newHeaderExtractor(HeaderExtraction{
+			ExtractHeaders: true,
+			Headers:        []string{"tenant"},
+			ExtractTopic:   true,
+			Destination:    destinationResourceAttributes,
+		}),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		obsrecv:      obsrecv,
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testConsumerGroupSession{ctx: context.Background()}, groupClaim))
+		wg.Done()
+	}()
+
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Topic:   "raw-logs",
+		Value:   []byte("hello"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("a")}},
+	}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	require.Len(t, sink.AllLogs(), 1)
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"kafka.topic":         "raw-logs",
+		"kafka.header.tenant": "a",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, []byte("hello"), rl.ScopeLogs().At(0).LogRecords().At(0).Body().Bytes().AsRaw())
+}
+
 type testConsumerGroupClaim struct {
 	messageChan chan *sarama.ConsumerMessage
 }
@@ -29,6 +29,9 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statDeadLetterWritten = stats.Int64("kafka_receiver_dead_letter_written", "Number of messages written to the dead letter topic", stats.UnitDimensionless)
+	statDeadLetterFailed  = stats.Int64("kafka_receiver_dead_letter_failed", "Number of messages which failed to be written to the dead letter topic", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
@@ -75,11 +78,29 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countDeadLetterWritten := &view.View{
+		Name:        statDeadLetterWritten.Name(),
+		Measure:     statDeadLetterWritten,
+		Description: statDeadLetterWritten.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countDeadLetterFailed := &view.View{
+		Name:        statDeadLetterFailed.Name(),
+		Measure:     statDeadLetterFailed,
+		Description: statDeadLetterFailed.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countDeadLetterWritten,
+		countDeadLetterFailed,
 	}
 }
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// topicLister lists the topics of the kafka cluster, as implemented by sarama.Client
+type topicLister interface {
+	RefreshMetadata(topics ...string) error
+	Topics() ([]string, error)
+	Close() error
+}
+
+// topicSubscription is the topics a consumer group consumes from, which are either
+// configured or matched by a regular expression among the topics of the cluster.
+type topicSubscription struct {
+	topics          []string
+	regex           *regexp.Regexp
+	lister          topicLister
+	refreshInterval time.Duration
+}
+
+// consumerGroupHandler is implemented by the handlers of the consumer groups
+type consumerGroupHandler interface {
+	sarama.ConsumerGroupHandler
+
+	// setReady reports that the handler is ready, without having been set up by a session
+	setReady()
+}
+
+// resolve returns the topics to consume from
+func (s *topicSubscription) resolve() ([]string, error) {
+	if s.regex == nil {
+		return s.topics, nil
+	}
+	if err := s.lister.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	topics, err := s.lister.Topics()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+	for _, topic := range topics {
+		if s.regex.MatchString(topic) {
+			matched = append(matched, topic)
+		}
+	}
+	sort.Strings(matched)
+	return matched, nil
+}
+
+// watch cancels the session consuming from topics when the topics matching the regular
+// expression change, so that a new session consumes from the current topics.
+func (s *topicSubscription) watch(ctx context.Context, topics []string, cancel context.CancelFunc, logger *zap.Logger) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			current, err := s.resolve()
+			if err != nil {
+				logger.Error("Failed to list the topics", zap.Error(err))
+				continue
+			}
+			if !equalTopics(topics, current) {
+				logger.Info("Topics matching the regular expression changed", zap.Strings("topics", current))
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *topicSubscription) close() error {
+	if s.lister == nil {
+		return nil
+	}
+	return s.lister.Close()
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// consumeLoop consumes the topics of the subscription until the context is cancelled
+func consumeLoop(ctx context.Context, group sarama.ConsumerGroup, subscription *topicSubscription, handler consumerGroupHandler, logger *zap.Logger) error {
+	for {
+		topics, err := subscription.resolve()
+		if err != nil {
+			logger.Error("Failed to list the topics", zap.Error(err))
+		}
+		if len(topics) == 0 && subscription.regex != nil {
+			// The receiver doesn't wait for the topics to be created to start
+			handler.setReady()
+			select {
+			case <-time.After(subscription.refreshInterval):
+				continue
+			case <-ctx.Done():
+				logger.Info("Consumer stopped", zap.Error(ctx.Err()))
+				return ctx.Err()
+			}
+		}
+
+		sessionCtx, cancel := context.WithCancel(ctx)
+		if subscription.regex != nil {
+			go subscription.watch(sessionCtx, topics, cancel, logger)
+		}
+		// `Consume` should be called inside an infinite loop, when a
+		// server-side rebalance happens, the consumer session will need to be
+		// recreated to get the new claims
+		if err := group.Consume(sessionCtx, topics, handler); err != nil {
+			logger.Error("Error from consumer", zap.Error(err))
+		}
+		cancel()
+		// check if context was cancelled, signaling that the consumer should stop
+		if ctx.Err() != nil {
+			logger.Info("Consumer stopped", zap.Error(ctx.Err()))
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testTopicLister struct {
+	sync.Mutex
+	topics []string
+	err    error
+	closed bool
+}
+
+var _ topicLister = (*testTopicLister)(nil)
+
+func (t *testTopicLister) RefreshMetadata(...string) error {
+	return t.err
+}
+
+func (t *testTopicLister) Topics() ([]string, error) {
+	t.Lock()
+	defer t.Unlock()
+	return t.topics, nil
+}
+
+func (t *testTopicLister) Close() error {
+	t.closed = true
+	return nil
+}
+
+func (t *testTopicLister) setTopics(topics ...string) {
+	t.Lock()
+	defer t.Unlock()
+	t.topics = topics
+}
+
+// topicsConsumerGroup records the topics of its sessions, which last until their context is done
+type topicsConsumerGroup struct {
+	testConsumerGroup
+	sessions chan []string
+}
+
+func (t *topicsConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	_ = handler.Setup(testConsumerGroupSession{ctx: ctx})
+	t.sessions <- topics
+	<-ctx.Done()
+	return nil
+}
+
+func TestTopicSubscriptionResolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription topicSubscription
+		expected     []string
+		expectedErr  string
+	}{
+		{
+			name:         "static",
+			subscription: topicSubscription{topics: []string{"otlp-a", "spans"}},
+			expected:     []string{"otlp-a", "spans"},
+		},
+		{
+			name: "regex",
+			subscription: topicSubscription{
+				regex:  regexp.MustCompile(`^otlp-.+$`),
+				lister: &testTopicLister{topics: []string{"otlp-b", "spans", "otlp-a", "otlp-"}},
+			},
+			expected: []string{"otlp-a", "otlp-b"},
+		},
+		{
+			name: "regex_without_match",
+			subscription: topicSubscription{
+				regex:  regexp.MustCompile(`^otlp-.+$`),
+				lister: &testTopicLister{topics: []string{"spans"}},
+			},
+		},
+		{
+			name: "refresh_error",
+			subscription: topicSubscription{
+				regex:  regexp.MustCompile(`^otlp-.+$`),
+				lister: &testTopicLister{err: errors.New("no broker")},
+			},
+			expectedErr: "no broker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topics, err := tt.subscription.resolve()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, topics)
+		})
+	}
+}
+
+func TestTopicSubscriptionClose(t *testing.T) {
+	assert.NoError(t, (&topicSubscription{}).close())
+
+	lister := &testTopicLister{}
+	assert.NoError(t, (&topicSubscription{lister: lister}).close())
+	assert.True(t, lister.closed)
+}
+
+func TestConsumeLoopStaticTopics(t *testing.T) {
+	group := &topicsConsumerGroup{sessions: make(chan []string, 1)}
+	subscription := &topicSubscription{topics: []string{"otlp-a", "otlp-b"}}
+	handler := &tracesConsumerGroupHandler{ready: make(chan bool)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- consumeLoop(ctx, group, subscription, handler, zap.NewNop())
+	}()
+
+	assert.Equal(t, []string{"otlp-a", "otlp-b"}, <-group.sessions)
+	<-handler.ready
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestConsumeLoopRegexTopics(t *testing.T) {
+	group := &topicsConsumerGroup{sessions: make(chan []string, 1)}
+	lister := &testTopicLister{}
+	subscription := &topicSubscription{
+		regex:           regexp.MustCompile(`^otlp-`),
+		lister:          lister,
+		refreshInterval: 10 * time.Millisecond,
+	}
+	handler := &tracesConsumerGroupHandler{ready: make(chan bool)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- consumeLoop(ctx, group, subscription, handler, zap.NewNop())
+	}()
+
+	// The handler is ready before any topic matches
+	<-handler.ready
+	lister.setTopics("spans", "otlp-a")
+	assert.Equal(t, []string{"otlp-a"}, <-group.sessions)
+
+	// The session is restarted when the matching topics change
+	lister.setTopics("spans", "otlp-a", "otlp-b")
+	assert.Equal(t, []string{"otlp-a", "otlp-b"}, <-group.sessions)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Metrics) string {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	cfg := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: endpoint,
+		},
+		Path: defaultPath,
+	}
+
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), nextConsumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	return fmt.Sprintf("http://%s%s", endpoint, defaultPath)
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func TestReceiver(t *testing.T) {
+	validRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "requests_total"},
+					{Name: "job", Value: "app"},
+					{Name: "instance", Value: "localhost:8080"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1679324645000}},
+			},
+		},
+	}
+	partialRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			validRequest.Timeseries[0],
+			{
+				Labels:  []prompb.Label{{Name: "job", Value: "app"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1679324645000}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           []byte
+		consumerErr    error
+		expectedStatus int
+		expectedPoints int
+	}{
+		{
+			name:           "valid",
+			method:         http.MethodPost,
+			body:           encodeWriteRequest(t, validRequest),
+			expectedStatus: http.StatusNoContent,
+			expectedPoints: 1,
+		},
+		{
+			name:           "partially_valid",
+			method:         http.MethodPost,
+			body:           encodeWriteRequest(t, partialRequest),
+			expectedStatus: http.StatusBadRequest,
+			expectedPoints: 1,
+		},
+		{
+			name:           "not_snappy",
+			method:         http.MethodPost,
+			body:           []byte("not snappy"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not_protobuf",
+			method:         http.MethodPost,
+			body:           snappy.Encode(nil, []byte("not protobuf")),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong_method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "retryable_consumer_error",
+			method:         http.MethodPost,
+			body:           encodeWriteRequest(t, validRequest),
+			consumerErr:    errors.New("temporary failure"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "permanent_consumer_error",
+			method:         http.MethodPost,
+			body:           encodeWriteRequest(t, validRequest),
+			consumerErr:    consumererror.NewPermanent(errors.New("bad data")),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			var nextConsumer consumer.Metrics = sink
+			if tt.consumerErr != nil {
+				nextConsumer = consumertest.NewErr(tt.consumerErr)
+			}
+			url := startReceiver(t, nextConsumer)
+
+			req, err := http.NewRequest(tt.method, url, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Encoding", "snappy")
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedPoints, sink.DataPointCount())
+		})
+	}
+}
+
+func TestReceiverConvertsMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	body := encodeWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "latency_bucket"},
+					{Name: "job", Value: "app"},
+					{Name: "le", Value: "+Inf"},
+				},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1679324645000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "latency_bucket"},
+					{Name: "job", Value: "app"},
+					{Name: "le", Value: "1"},
+				},
+				Samples: []prompb.Sample{{Value: 2, Timestamp: 1679324645000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "latency_sum"},
+					{Name: "job", Value: "app"},
+				},
+				Samples: []prompb.Sample{{Value: 2.5, Timestamp: 1679324645000}},
+			},
+		},
+	})
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.MetricCount())
+
+	rm := md.ResourceMetrics().At(0)
+	serviceName, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "app", serviceName.Str())
+
+	metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "latency", metric.Name())
+	dp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, 2.5, dp.Sum())
+	assert.Equal(t, []float64{1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 1}, dp.BucketCounts().AsRaw())
+}
@@ -125,6 +125,13 @@ func newClient(cfg *Config, logger *zap.Logger) (client, error) {
 // setV3ClientConfigs sets SNMP v3 related configurations on gosnmp client based on config
 func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	client.SetSecurityModel(gosnmp.UserSecurityModel)
+	msgFlags, securityParams := getV3SecurityConfigs(cfg)
+	client.SetMsgFlags(msgFlags)
+	client.SetSecurityParameters(securityParams)
+}
+
+// getV3SecurityConfigs gets the gosnmp message flags and USM security parameters based on config
+func getV3SecurityConfigs(cfg *Config) (gosnmp.SnmpV3MsgFlags, *gosnmp.UsmSecurityParameters) {
 	// Set goSNMP user based on config
 	securityParams := &gosnmp.UsmSecurityParameters{
 		UserName: cfg.User,
@@ -132,13 +139,11 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	// Set goSNMP security level & auth/privacy details based on config
 	switch strings.ToUpper(cfg.SecurityLevel) {
 	case "AUTH_NO_PRIV":
-		client.SetMsgFlags(gosnmp.AuthNoPriv)
 		protocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = protocol
 		securityParams.AuthenticationPassphrase = cfg.AuthPassword
+		return gosnmp.AuthNoPriv, securityParams
 	case "AUTH_PRIV":
-		client.SetMsgFlags(gosnmp.AuthPriv)
-
 		authProtocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = authProtocol
 		securityParams.AuthenticationPassphrase = cfg.AuthPassword
@@ -146,10 +151,10 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 		privProtocol := getPrivacyProtocol(cfg.PrivacyType)
 		securityParams.PrivacyProtocol = privProtocol
 		securityParams.PrivacyPassphrase = cfg.PrivacyPassword
+		return gosnmp.AuthPriv, securityParams
 	default:
-		client.SetMsgFlags(gosnmp.NoAuthNoPriv)
+		return gosnmp.NoAuthNoPriv, securityParams
 	}
-	client.SetSecurityParameters(securityParams)
 }
 
 // getAuthProtocol gets gosnmp auth protocol based on config auth type
@@ -14,6 +14,7 @@
 package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -33,6 +34,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapEndpoint       = "udp://0.0.0.0:162"
 )
 
 var (
@@ -74,6 +76,10 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errTrapsRequired        = errors.New("traps must be configured to receive traps and informs")
+	errEmptyTrapEndpoint    = errors.New("traps endpoint must be specified")
+	errTrapEndpointScheme   = errors.New("traps endpoint scheme must be either tcp or udp")
+	errBadEngineID          = errors.New("traps engine_id must be a hex string")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -137,6 +143,26 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps enables a listener for SNMP traps and informs which are emitted as log records.
+	// The Version, Community, and v3 security configs above are used to authenticate incoming traps.
+	// Metrics are not required when Traps is configured.
+	Traps *TrapConfig `mapstructure:"traps"`
+}
+
+// TrapConfig contains config info about the trap and inform listener
+type TrapConfig struct {
+	// Endpoint is the address to listen on for traps and informs. Must be formatted as [udp|tcp]://{host}:{port}.
+	// Default: udp://0.0.0.0:162
+	// If no scheme is given, udp is assumed.
+	// If no port is given, 162 is assumed.
+	Endpoint string `mapstructure:"endpoint"`
+	// MIBDirectory is optional and is a local directory of MIB files used to resolve
+	// trap and varbind OIDs to their names
+	MIBDirectory string `mapstructure:"mib_directory"`
+	// EngineID is optional and is the hex encoded authoritative engine ID of this listener.
+	// Only valid for version "v3" and needed to acknowledge informs
+	EngineID string `mapstructure:"engine_id"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -250,6 +276,9 @@ func (cfg *Config) Validate() error {
 		combinedErr = multierr.Append(combinedErr, validateSecurity(cfg))
 	}
 	combinedErr = multierr.Append(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Traps != nil {
+		combinedErr = multierr.Append(combinedErr, validateTraps(cfg.Traps))
+	}
 
 	return combinedErr
 }
@@ -279,6 +308,37 @@ func validateEndpoint(cfg *Config) error {
 	return nil
 }
 
+// validateTraps validates the TrapConfig
+func validateTraps(traps *TrapConfig) error {
+	var combinedErr error
+
+	if traps.Endpoint == "" {
+		combinedErr = multierr.Append(combinedErr, errEmptyTrapEndpoint)
+	} else {
+		// Ensure valid endpoint
+		u, err := url.Parse(traps.Endpoint)
+		switch {
+		case err != nil:
+			combinedErr = multierr.Append(combinedErr, fmt.Errorf(errMsgInvalidEndpointWError, traps.Endpoint, err))
+		case u.Host == "" || u.Port() == "":
+			combinedErr = multierr.Append(combinedErr, fmt.Errorf(errMsgInvalidEndpoint, traps.Endpoint))
+		default:
+			// Ensure valid scheme, the trap listener only supports plain tcp and udp
+			switch strings.ToUpper(u.Scheme) {
+			case "TCP", "UDP": // ok
+			default:
+				combinedErr = multierr.Append(combinedErr, errTrapEndpointScheme)
+			}
+		}
+	}
+
+	if _, err := hex.DecodeString(strings.TrimPrefix(traps.EngineID, "0x")); err != nil {
+		combinedErr = multierr.Append(combinedErr, errBadEngineID)
+	}
+
+	return combinedErr
+}
+
 // validateVersion validates the Version
 func validateVersion(cfg *Config) error {
 	if cfg.Version == "" {
@@ -378,9 +438,12 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = multierr.Append(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = multierr.Append(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, unless the receiver only listens for traps
 	metrics := cfg.Metrics
 	if len(metrics) == 0 {
+		if cfg.Traps != nil {
+			return combinedErr
+		}
 		return multierr.Append(combinedErr, errMetricRequired)
 	}
 
@@ -858,6 +858,88 @@ func TestLoadConfigMetricConfigs(t *testing.T) {
 	}
 }
 
+func TestLoadConfigTrapConfigs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	type testCase struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}
+
+	expectedConfigTrapsGood := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsGood.Traps = &TrapConfig{
+		Endpoint:     "udp://0.0.0.0:1162",
+		MIBDirectory: "./testdata/mibs",
+	}
+
+	expectedConfigTrapsBadEndpointScheme := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEndpointScheme.Traps = &TrapConfig{
+		Endpoint: "http://0.0.0.0:162",
+	}
+
+	expectedConfigTrapsNoEndpointPort := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsNoEndpointPort.Traps = &TrapConfig{
+		Endpoint: "udp://0.0.0.0",
+	}
+
+	expectedConfigTrapsBadEngineID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEngineID.Version = "v3"
+	expectedConfigTrapsBadEngineID.User = "u"
+	expectedConfigTrapsBadEngineID.Traps = &TrapConfig{
+		Endpoint: "udp://0.0.0.0:162",
+		EngineID: "not-hex",
+	}
+
+	testCases := []testCase{
+		{
+			name:        "TrapsWithoutMetricsNoErrors",
+			nameVal:     "traps_good",
+			expectedCfg: expectedConfigTrapsGood,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapsBadEndpointSchemeErrors",
+			nameVal:     "traps_bad_endpoint_scheme",
+			expectedCfg: expectedConfigTrapsBadEndpointScheme,
+			expectedErr: errTrapEndpointScheme.Error(),
+		},
+		{
+			name:        "TrapsNoEndpointPortErrors",
+			nameVal:     "traps_no_endpoint_port",
+			expectedCfg: expectedConfigTrapsNoEndpointPort,
+			expectedErr: fmt.Sprintf(errMsgInvalidEndpoint, "udp://0.0.0.0"),
+		},
+		{
+			name:        "TrapsBadEngineIDErrors",
+			nameVal:     "traps_bad_engine_id",
+			expectedCfg: expectedConfigTrapsBadEngineID,
+			expectedErr: errBadEngineID.Error(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(typeStr, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, component.ValidateConfig(cfg))
+			} else {
+				require.ErrorContains(t, component.ValidateConfig(cfg), test.expectedErr)
+			}
+
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
+
 // Testing Validate directly to test that missing data errors when no defaults are provided
 func TestValidate(t *testing.T) {
 	type testCase struct {
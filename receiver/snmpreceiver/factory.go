@@ -28,8 +28,9 @@ import (
 )
 
 const (
-	typeStr   = "snmp"
-	stability = component.StabilityLevelAlpha
+	typeStr       = "snmp"
+	stability     = component.StabilityLevelAlpha
+	logsStability = component.StabilityLevelDevelopment
 )
 
 var errConfigNotSNMP = errors.New("config was not a SNMP receiver config")
@@ -39,7 +40,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, stability))
+		receiver.WithMetrics(createMetricsReceiver, stability),
+		receiver.WithLogs(createLogsReceiver, logsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -73,6 +75,10 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	if len(snmpConfig.Metrics) == 0 {
+		return nil, errMetricRequired
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	scraper, err := scraperhelper.NewScraper(typeStr, snmpScraper.scrape, scraperhelper.WithStart(snmpScraper.start))
 	if err != nil {
@@ -82,21 +88,38 @@ func createMetricsReceiver(
 	return scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
 }
 
+// createLogsReceiver creates the trap and inform receiver for SNMP
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	return newTrapReceiver(params, snmpConfig, consumer)
+}
+
 // addMissingConfigDefaults adds any missing comfig parameters that have defaults
 func addMissingConfigDefaults(cfg *Config) error {
-	// Add the schema prefix to the endpoint if it doesn't contain one
-	if !strings.Contains(cfg.Endpoint, "://") {
-		cfg.Endpoint = "udp://" + cfg.Endpoint
-	}
+	cfg.Endpoint = addMissingEndpointDefaults(cfg.Endpoint, "161")
 
-	// Add default port to endpoint if it doesn't contain one
-	u, err := url.Parse(cfg.Endpoint)
-	if err == nil && u.Port() == "" {
-		portSuffix := "161"
-		if cfg.Endpoint[len(cfg.Endpoint)-1:] != ":" {
-			portSuffix = ":" + portSuffix
+	if cfg.Traps != nil {
+		if cfg.Traps.Endpoint == "" {
+			cfg.Traps.Endpoint = defaultTrapEndpoint
 		}
-		cfg.Endpoint += portSuffix
+		cfg.Traps.Endpoint = addMissingEndpointDefaults(cfg.Traps.Endpoint, "162")
 	}
 
 	// Set defaults for metric configs
@@ -119,3 +142,23 @@ func addMissingConfigDefaults(cfg *Config) error {
 
 	return component.ValidateConfig(cfg)
 }
+
+// addMissingEndpointDefaults adds the udp scheme and the given port to an endpoint if they are missing
+func addMissingEndpointDefaults(endpoint string, port string) string {
+	// Add the schema prefix to the endpoint if it doesn't contain one
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "udp://" + endpoint
+	}
+
+	// Add default port to endpoint if it doesn't contain one
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Port() == "" {
+		portSuffix := port
+		if endpoint[len(endpoint)-1:] != ":" {
+			portSuffix = ":" + portSuffix
+		}
+		endpoint += portSuffix
+	}
+
+	return endpoint
+}
@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// mibDefinitionMacros are the SMI macros which assign an OID to a descriptor
+var mibDefinitionMacros = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+	"TRAP-TYPE":          true,
+}
+
+// mibBaseDefinitions are the well known OIDs from SNMPv2-SMI and SNMPv2-MIB which are
+// needed to resolve most MIBs and to name the standard trap varbinds
+var mibBaseDefinitions = []struct {
+	module string
+	name   string
+	oid    string
+}{
+	{"SNMPv2-SMI", "iso", "1"},
+	{"SNMPv2-SMI", "org", "1.3"},
+	{"SNMPv2-SMI", "dod", "1.3.6"},
+	{"SNMPv2-SMI", "internet", "1.3.6.1"},
+	{"SNMPv2-SMI", "directory", "1.3.6.1.1"},
+	{"SNMPv2-SMI", "mgmt", "1.3.6.1.2"},
+	{"SNMPv2-SMI", "mib-2", "1.3.6.1.2.1"},
+	{"SNMPv2-SMI", "transmission", "1.3.6.1.2.1.10"},
+	{"SNMPv2-SMI", "experimental", "1.3.6.1.3"},
+	{"SNMPv2-SMI", "private", "1.3.6.1.4"},
+	{"SNMPv2-SMI", "enterprises", "1.3.6.1.4.1"},
+	{"SNMPv2-SMI", "security", "1.3.6.1.5"},
+	{"SNMPv2-SMI", "snmpV2", "1.3.6.1.6"},
+	{"SNMPv2-SMI", "snmpDomains", "1.3.6.1.6.1"},
+	{"SNMPv2-SMI", "snmpProxys", "1.3.6.1.6.2"},
+	{"SNMPv2-SMI", "snmpModules", "1.3.6.1.6.3"},
+	{"SNMPv2-MIB", "system", "1.3.6.1.2.1.1"},
+	{"SNMPv2-MIB", "sysUpTime", "1.3.6.1.2.1.1.3"},
+	{"SNMPv2-MIB", "snmpMIB", "1.3.6.1.6.3.1"},
+	{"SNMPv2-MIB", "snmpMIBObjects", "1.3.6.1.6.3.1.1"},
+	{"SNMPv2-MIB", "snmpTrap", "1.3.6.1.6.3.1.1.4"},
+	{"SNMPv2-MIB", "snmpTrapOID", "1.3.6.1.6.3.1.1.4.1"},
+	{"SNMPv2-MIB", "snmpTrapEnterprise", "1.3.6.1.6.3.1.1.4.3"},
+	{"SNMPv2-MIB", "snmpTraps", "1.3.6.1.6.3.1.1.5"},
+	{"SNMPv2-MIB", "coldStart", "1.3.6.1.6.3.1.1.5.1"},
+	{"SNMPv2-MIB", "warmStart", "1.3.6.1.6.3.1.1.5.2"},
+	{"IF-MIB", "linkDown", "1.3.6.1.6.3.1.1.5.3"},
+	{"IF-MIB", "linkUp", "1.3.6.1.6.3.1.1.5.4"},
+	{"SNMPv2-MIB", "authenticationFailure", "1.3.6.1.6.3.1.1.5.5"},
+}
+
+// mibNode is a single descriptor definition found in a MIB file
+type mibNode struct {
+	module string
+	name   string
+	// parent is the descriptor this node is defined relative to
+	parent string
+	// subIDs are the sub identifiers appended to the parent OID
+	subIDs []string
+}
+
+// mibResolver resolves numeric OIDs to their MIB names
+type mibResolver struct {
+	// names maps a numeric OID without a leading dot to its name as MODULE::descriptor
+	names map[string]string
+}
+
+// newMIBResolver loads every MIB file found in dir and its subdirectories
+func newMIBResolver(dir string) (*mibResolver, error) {
+	nodes := map[string]mibNode{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		for _, node := range parseMIB(string(content)) {
+			// Keep the first definition of a descriptor
+			if _, ok := nodes[node.name]; !ok {
+				nodes[node.name] = node
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MIBs from '%s': %w", dir, err)
+	}
+
+	return newMIBResolverFromNodes(nodes), nil
+}
+
+// newMIBResolverFromNodes computes the numeric OID of every node which can be
+// resolved to one of the base definitions
+func newMIBResolverFromNodes(nodes map[string]mibNode) *mibResolver {
+	oids := map[string]string{}
+	resolver := &mibResolver{names: map[string]string{}}
+	for _, base := range mibBaseDefinitions {
+		oids[base.name] = base.oid
+		resolver.names[base.oid] = base.module + "::" + base.name
+	}
+
+	var resolve func(name string, depth int) (string, bool)
+	resolve = func(name string, depth int) (string, bool) {
+		if oid, ok := oids[name]; ok {
+			return oid, true
+		}
+		node, ok := nodes[name]
+		// Guard against definition cycles
+		if !ok || depth > len(nodes) {
+			return "", false
+		}
+		parentOID, ok := resolve(node.parent, depth+1)
+		if !ok {
+			return "", false
+		}
+		oid := strings.Join(append([]string{parentOID}, node.subIDs...), ".")
+		oids[name] = oid
+		return oid, true
+	}
+
+	for name, node := range nodes {
+		if oid, ok := resolve(name, 0); ok {
+			resolver.names[oid] = node.module + "::" + node.name
+		}
+	}
+
+	return resolver
+}
+
+// resolve returns the name of the longest known prefix of the given OID followed by any
+// remaining sub identifiers, e.g. IF-MIB::ifIndex.3. It returns false if no prefix is known.
+func (r *mibResolver) resolve(oid string) (string, bool) {
+	oid = strings.TrimPrefix(oid, ".")
+	prefix := oid
+	for prefix != "" {
+		if name, ok := r.names[prefix]; ok {
+			return name + oid[len(prefix):], true
+		}
+
+		index := strings.LastIndex(prefix, ".")
+		if index < 0 {
+			break
+		}
+		prefix = prefix[:index]
+	}
+	return "", false
+}
+
+// parseMIB extracts every descriptor with an OID assignment from the content of a MIB file.
+// It only understands enough of SMIv1 and SMIv2 to find OID assignments and ignores everything else.
+func parseMIB(content string) []mibNode {
+	tokens := tokenizeMIB(content)
+
+	var nodes []mibNode
+	module := ""
+	for i := 0; i < len(tokens); i++ {
+		// Module header: NAME DEFINITIONS ::= BEGIN
+		if tokens[i] == "DEFINITIONS" && i > 0 {
+			module = tokens[i-1]
+			continue
+		}
+
+		if i+1 >= len(tokens) || !isMIBDescriptor(tokens[i]) {
+			continue
+		}
+
+		switch {
+		case tokens[i+1] == "OBJECT" && i+3 < len(tokens) && tokens[i+2] == "IDENTIFIER" && tokens[i+3] == "::=":
+			// descriptor OBJECT IDENTIFIER ::= { parent 1 }
+			if node, next, ok := parseMIBAssignment(tokens, i+4); ok {
+				node.module, node.name = module, tokens[i]
+				nodes = append(nodes, node)
+				i = next
+			}
+		case tokens[i+1] == "TRAP-TYPE":
+			// descriptor TRAP-TYPE ENTERPRISE enterprise ... ::= 1
+			if node, next, ok := parseMIBTrapType(tokens, i+2); ok {
+				node.module, node.name = module, tokens[i]
+				nodes = append(nodes, node)
+				i = next
+			}
+		case mibDefinitionMacros[tokens[i+1]]:
+			// descriptor OBJECT-TYPE ... ::= { parent 1 }
+			for j := i + 2; j < len(tokens); j++ {
+				if tokens[j] != "::=" {
+					continue
+				}
+				if node, next, ok := parseMIBAssignment(tokens, j+1); ok {
+					node.module, node.name = module, tokens[i]
+					nodes = append(nodes, node)
+					i = next
+				}
+				break
+			}
+		}
+	}
+
+	return nodes
+}
+
+// parseMIBAssignment parses an OID value such as { parent 1 2 } or { iso(1) org(3) 6 }
+// starting at tokens[start] and returns the index of its closing brace
+func parseMIBAssignment(tokens []string, start int) (mibNode, int, bool) {
+	if start >= len(tokens) || tokens[start] != "{" {
+		return mibNode{}, 0, false
+	}
+
+	var components []string
+	end := start + 1
+	for ; end < len(tokens) && tokens[end] != "}"; end++ {
+		components = append(components, tokens[end])
+	}
+	if end == len(tokens) || len(components) < 2 {
+		return mibNode{}, 0, false
+	}
+
+	node := mibNode{parent: components[0]}
+	for _, component := range components[1:] {
+		// Components may be written as name(number)
+		if open := strings.Index(component, "("); open >= 0 && strings.HasSuffix(component, ")") {
+			component = component[open+1 : len(component)-1]
+		}
+		if _, err := strconv.ParseUint(component, 10, 32); err != nil {
+			return mibNode{}, 0, false
+		}
+		node.subIDs = append(node.subIDs, component)
+	}
+
+	// The parent may also be written as name(number), only the name is needed
+	if open := strings.Index(node.parent, "("); open >= 0 {
+		node.parent = node.parent[:open]
+	}
+
+	return node, end, true
+}
+
+// parseMIBTrapType parses the body of an SMIv1 TRAP-TYPE starting at tokens[start].
+// The OID of a v1 trap is its enterprise followed by 0 and the specific trap number.
+func parseMIBTrapType(tokens []string, start int) (mibNode, int, bool) {
+	node := mibNode{}
+	for i := start; i+1 < len(tokens); i++ {
+		switch tokens[i] {
+		case "ENTERPRISE":
+			node.parent = tokens[i+1]
+		case "::=":
+			if _, err := strconv.ParseUint(tokens[i+1], 10, 32); err != nil || node.parent == "" {
+				return mibNode{}, 0, false
+			}
+			node.subIDs = []string{"0", tokens[i+1]}
+			return node, i + 1, true
+		}
+	}
+	return mibNode{}, 0, false
+}
+
+// tokenizeMIB splits MIB content into tokens, dropping comments and quoted strings.
+// Braces and commas are separate tokens, name(number) components are kept as one token.
+func tokenizeMIB(content string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// Comments run to the end of the line or to the next "--"
+			flush()
+			i += 2
+			for ; i < len(runes) && runes[i] != '\n'; i++ {
+				if runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '-' {
+					i++
+					break
+				}
+			}
+		case r == '"':
+			flush()
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+			}
+		case r == '{' || r == '}' || r == ',' || r == ';':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// isMIBDescriptor checks if a token is a valid object descriptor, which must start with a lowercase letter
+func isMIBDescriptor(token string) bool {
+	if token == "" || !unicode.IsLower(rune(token[0])) {
+		return false
+	}
+	for _, r := range token {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIBResolver(t *testing.T) {
+	resolver, err := newMIBResolver(filepath.Join("testdata", "mibs"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		oid          string
+		expectedName string
+		expectedOK   bool
+	}{
+		{
+			desc:         "resolves a module identity",
+			oid:          ".1.3.6.1.4.1.99999",
+			expectedName: "OTEL-TEST-MIB::otelTestMIB",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves a notification",
+			oid:          ".1.3.6.1.4.1.99999.0.1",
+			expectedName: "OTEL-TEST-MIB::otelTestAlarm",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves a column with its index",
+			oid:          ".1.3.6.1.4.1.99999.1.1.1.2.7",
+			expectedName: "OTEL-TEST-MIB::otelTestName.7",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves without a leading dot",
+			oid:          "1.3.6.1.4.1.99999.1.1.1.1.7",
+			expectedName: "OTEL-TEST-MIB::otelTestIndex.7",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves a v1 trap",
+			oid:          ".1.3.6.1.4.1.99998.0.2",
+			expectedName: "OTEL-TEST-V1-MIB::otelTestV1Trap",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves a base definition",
+			oid:          ".1.3.6.1.6.3.1.1.5.3",
+			expectedName: "IF-MIB::linkDown",
+			expectedOK:   true,
+		},
+		{
+			desc:         "resolves an unknown enterprise to its closest known parent",
+			oid:          ".1.3.6.1.4.1.12345.1",
+			expectedName: "SNMPv2-SMI::enterprises.12345.1",
+			expectedOK:   true,
+		},
+		{
+			desc:       "does not resolve an unknown root",
+			oid:        ".2.1",
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			name, ok := resolver.resolve(tc.oid)
+			require.Equal(t, tc.expectedOK, ok)
+			require.Equal(t, tc.expectedName, name)
+		})
+	}
+}
+
+func TestMIBResolverMissingDirectory(t *testing.T) {
+	_, err := newMIBResolver(filepath.Join("testdata", "does-not-exist"))
+	require.ErrorContains(t, err, "failed to load MIBs")
+}
+
+func TestParseMIB(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		content       string
+		expectedNodes []mibNode
+	}{
+		{
+			desc:    "object identifier",
+			content: "M DEFINITIONS ::= BEGIN a OBJECT IDENTIFIER ::= { b 1 2 } END",
+			expectedNodes: []mibNode{
+				{module: "M", name: "a", parent: "b", subIDs: []string{"1", "2"}},
+			},
+		},
+		{
+			desc:    "named components",
+			content: "M DEFINITIONS ::= BEGIN a OBJECT IDENTIFIER ::= { iso(1) org(3) 6 } END",
+			expectedNodes: []mibNode{
+				{module: "M", name: "a", parent: "iso", subIDs: []string{"3", "6"}},
+			},
+		},
+		{
+			desc: "object type with comments",
+			content: `M DEFINITIONS ::= BEGIN
+a OBJECT-TYPE -- a comment ::= { c 3 }
+    SYNTAX  Integer32 -- another comment -- MAX-ACCESS read-only
+    DESCRIPTION "quoted ::= { d 4 }"
+    ::= { b 2 }
+END`,
+			expectedNodes: []mibNode{
+				{module: "M", name: "a", parent: "b", subIDs: []string{"2"}},
+			},
+		},
+		{
+			desc: "sequence members are not definitions",
+			content: `M DEFINITIONS ::= BEGIN
+Entry ::= SEQUENCE { a Integer32, b OBJECT IDENTIFIER }
+c OBJECT IDENTIFIER ::= { d 1 }
+END`,
+			expectedNodes: []mibNode{
+				{module: "M", name: "c", parent: "d", subIDs: []string{"1"}},
+			},
+		},
+		{
+			desc:    "trap type",
+			content: "M DEFINITIONS ::= BEGIN a TRAP-TYPE ENTERPRISE b VARIABLES { c } ::= 5 END",
+			expectedNodes: []mibNode{
+				{module: "M", name: "a", parent: "b", subIDs: []string{"0", "5"}},
+			},
+		},
+		{
+			desc:          "invalid assignment",
+			content:       "M DEFINITIONS ::= BEGIN a OBJECT IDENTIFIER ::= { b c } END",
+			expectedNodes: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expectedNodes, parseMIB(tc.content))
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const (
+	// sysUpTimeOID and snmpTrapOID are the first two varbinds of every v2c and v3 trap
+	sysUpTimeOID   = ".1.3.6.1.2.1.1.3.0"
+	snmpTrapOID    = ".1.3.6.1.6.3.1.1.4.1.0"
+	genericTrapOID = ".1.3.6.1.6.3.1.1.5"
+	// enterpriseSpecificTrap is the v1 generic trap number of enterprise specific traps
+	enterpriseSpecificTrap = 6
+)
+
+// Log record attribute keys
+const (
+	attributeSNMPVersion  = "snmp.version"
+	attributeSNMPPDUType  = "snmp.pdu_type"
+	attributeTrapOID      = "snmp.trap.oid"
+	attributeTrapName     = "snmp.trap.name"
+	attributeAgentAddress = "snmp.agent_address"
+	attributeUptime       = "snmp.uptime"
+	attributeVarbinds     = "snmp.varbinds"
+	attributePeerAddr     = "net.sock.peer.addr"
+	attributePeerPort     = "net.sock.peer.port"
+)
+
+// trapReceiver listens for SNMP traps and informs and emits them as log records
+type trapReceiver struct {
+	cfg      *Config
+	settings receiver.CreateSettings
+	logger   *zap.Logger
+	consumer consumer.Logs
+	obsrecv  *obsreport.Receiver
+	mibs     *mibResolver
+	listener *gosnmp.TrapListener
+	wg       sync.WaitGroup
+}
+
+// newTrapReceiver creates the trap receiver. Relies on config being validated thoroughly
+func newTrapReceiver(settings receiver.CreateSettings, cfg *Config, consumer consumer.Logs) (*trapReceiver, error) {
+	// Checked in config
+	trapURL, _ := url.Parse(cfg.Traps.Endpoint)
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              strings.ToLower(trapURL.Scheme),
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &trapReceiver{
+		cfg:      cfg,
+		settings: settings,
+		logger:   settings.Logger,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+// Start loads the MIBs, if any, and starts listening for traps
+func (r *trapReceiver) Start(_ context.Context, host component.Host) error {
+	if r.cfg.Traps.MIBDirectory != "" {
+		mibs, err := newMIBResolver(r.cfg.Traps.MIBDirectory)
+		if err != nil {
+			return err
+		}
+		r.mibs = mibs
+	}
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = newTrapParams(r.cfg)
+	listener.OnNewTrap = func(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+		r.handleTrap(packet, addr)
+	}
+
+	// Checked in config, gosnmp expects the scheme to be lowercase
+	trapURL, _ := url.Parse(r.cfg.Traps.Endpoint)
+	address := strings.ToLower(trapURL.Scheme) + "://" + trapURL.Host
+
+	errs := make(chan error, 1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		errs <- listener.Listen(address)
+	}()
+
+	select {
+	case <-listener.Listening():
+		r.listener = listener
+		go func() {
+			if err := <-errs; err != nil {
+				host.ReportFatalError(fmt.Errorf("trap listener stopped: %w", err))
+			}
+		}()
+		return nil
+	case err := <-errs:
+		return fmt.Errorf("failed to listen for traps on '%s': %w", r.cfg.Traps.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// newTrapParams creates the gosnmp settings used to decode and authenticate incoming traps
+func newTrapParams(cfg *Config) *gosnmp.GoSNMP {
+	params := &gosnmp.GoSNMP{
+		Community: cfg.Community,
+		Version:   gosnmp.Version2c,
+		Timeout:   gosnmp.Default.Timeout,
+		Retries:   gosnmp.Default.Retries,
+	}
+
+	switch cfg.Version {
+	case "v1":
+		params.Version = gosnmp.Version1
+	case "v3":
+		msgFlags, securityParams := getV3SecurityConfigs(cfg)
+		// Checked in config
+		engineID, _ := hex.DecodeString(strings.TrimPrefix(cfg.Traps.EngineID, "0x"))
+		securityParams.AuthoritativeEngineID = string(engineID)
+
+		params.Version = gosnmp.Version3
+		params.SecurityModel = gosnmp.UserSecurityModel
+		params.MsgFlags = msgFlags
+		params.SecurityParameters = securityParams
+	}
+
+	return params
+}
+
+// handleTrap converts a trap or inform to a log record and passes it to the next consumer
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	// v3 traps are authenticated by gosnmp, v1 and v2c traps must use the configured community
+	if packet.Version != gosnmp.Version3 && packet.Community != r.cfg.Community {
+		r.logger.Debug("dropping trap with unknown community", zap.Stringer("peer", addr))
+		return
+	}
+
+	logs := r.trapToLogs(packet, addr, time.Now())
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.consumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, typeStr, logs.LogRecordCount(), err)
+}
+
+// trapToLogs converts a trap or inform to a single log record
+func (r *trapReceiver) trapToLogs(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(now))
+
+	attrs := record.Attributes()
+	attrs.PutStr(attributeSNMPVersion, versionName(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr(attributeSNMPPDUType, "inform")
+	} else {
+		attrs.PutStr(attributeSNMPPDUType, "trap")
+	}
+	if addr != nil {
+		attrs.PutStr(attributePeerAddr, addr.IP.String())
+		attrs.PutInt(attributePeerPort, int64(addr.Port))
+	}
+
+	trapOID := ""
+	varbinds := attrs.PutEmptySlice(attributeVarbinds)
+	if packet.PDUType == gosnmp.Trap {
+		// v1 traps carry their identity and uptime in the PDU header
+		trapOID = v1TrapOID(packet.Enterprise, packet.GenericTrap, packet.SpecificTrap)
+		attrs.PutStr(attributeAgentAddress, packet.AgentAddress)
+		attrs.PutInt(attributeUptime, int64(packet.Timestamp))
+	}
+	for _, variable := range packet.Variables {
+		switch variable.Name {
+		case snmpTrapOID:
+			trapOID = toString(variable.Value)
+			continue
+		case sysUpTimeOID:
+			if uptime, ok := toBigInt(variable.Value); ok {
+				attrs.PutInt(attributeUptime, uptime.Int64())
+				continue
+			}
+		}
+		r.putVarbind(varbinds.AppendEmpty().SetEmptyMap(), variable)
+	}
+
+	if trapOID == "" {
+		return logs
+	}
+
+	trapOID = "." + strings.TrimPrefix(trapOID, ".")
+	attrs.PutStr(attributeTrapOID, trapOID)
+	record.Body().SetStr(trapOID)
+	if name, ok := r.resolve(trapOID); ok {
+		attrs.PutStr(attributeTrapName, name)
+		record.Body().SetStr(name)
+	}
+
+	return logs
+}
+
+// putVarbind adds the OID, name, type, and value of a varbind to the map
+func (r *trapReceiver) putVarbind(varbind pcommon.Map, variable gosnmp.SnmpPDU) {
+	varbind.PutStr("oid", variable.Name)
+	if name, ok := r.resolve(variable.Name); ok {
+		varbind.PutStr("name", name)
+	}
+	varbind.PutStr("type", variable.Type.String())
+	putVarbindValue(varbind.PutEmpty("value"), variable)
+}
+
+// resolve resolves an OID to its name if MIBs are configured
+func (r *trapReceiver) resolve(oid string) (string, bool) {
+	if r.mibs == nil {
+		return "", false
+	}
+	return r.mibs.resolve(oid)
+}
+
+// putVarbindValue sets the value of a varbind based on its type
+func putVarbindValue(value pcommon.Value, variable gosnmp.SnmpPDU) {
+	switch variable.Type {
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		number, ok := toBigInt(variable.Value)
+		switch {
+		case !ok:
+			value.SetStr(toString(variable.Value))
+		case number.IsInt64():
+			value.SetInt(number.Int64())
+		default:
+			// Counter64 values may not fit in an int64
+			value.SetStr(number.String())
+		}
+	case gosnmp.OpaqueFloat:
+		if f, ok := variable.Value.(float32); ok {
+			value.SetDouble(float64(f))
+		}
+	case gosnmp.OpaqueDouble:
+		if f, ok := variable.Value.(float64); ok {
+			value.SetDouble(f)
+		}
+	case gosnmp.OctetString:
+		raw, ok := variable.Value.([]byte)
+		if ok && !isPrintable(raw) {
+			value.SetEmptyBytes().FromRaw(raw)
+			return
+		}
+		value.SetStr(toString(variable.Value))
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		// No value
+	default:
+		value.SetStr(toString(variable.Value))
+	}
+}
+
+// v1TrapOID converts a v1 trap to its v2 trap OID as described in RFC 3584, section 3.1
+func v1TrapOID(enterprise string, genericTrap, specificTrap int) string {
+	if genericTrap == enterpriseSpecificTrap {
+		return fmt.Sprintf(".%s.0.%d", strings.TrimPrefix(enterprise, "."), specificTrap)
+	}
+	return fmt.Sprintf("%s.%d", genericTrapOID, genericTrap+1)
+}
+
+// versionName gets the config name of a SNMP version
+func versionName(version gosnmp.SnmpVersion) string {
+	switch version {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// toBigInt converts an integer varbind value to a big.Int
+func toBigInt(value interface{}) (*big.Int, bool) {
+	switch value := value.(type) { // shadow
+	case int:
+		return big.NewInt(int64(value)), true
+	case int32:
+		return big.NewInt(int64(value)), true
+	case int64:
+		return big.NewInt(value), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint64:
+		return new(big.Int).SetUint64(value), true
+	case *big.Int:
+		return value, true
+	default:
+		return nil, false
+	}
+}
+
+// isPrintable checks if an octet string is printable text
+func isPrintable(raw []byte) bool {
+	if !utf8.Valid(raw) {
+		return false
+	}
+	for _, r := range string(raw) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newTestTrapConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapConfig{
+		Endpoint: "udp://localhost:0",
+	}
+	return cfg
+}
+
+func TestTrapToLogs(t *testing.T) {
+	peer := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}
+	now := time.Unix(1680000000, 0)
+
+	testCases := []struct {
+		desc               string
+		mibDirectory       string
+		packet             *gosnmp.SnmpPacket
+		expectedBody       string
+		expectedAttributes map[string]interface{}
+	}{
+		{
+			desc: "v2c trap",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: "public",
+				PDUType:   gosnmp.SNMPv2Trap,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(1234)},
+					{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.0.1"},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.1.7", Type: gosnmp.Integer, Value: 7},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.2.7", Type: gosnmp.OctetString, Value: []byte("eth0")},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.3.7", Type: gosnmp.OctetString, Value: []byte{0x00, 0x1a, 0xff}},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.4.7", Type: gosnmp.Counter64, Value: uint64(18446744073709551615)},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.5.7", Type: gosnmp.IPAddress, Value: "192.168.0.1"},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.6.7", Type: gosnmp.Null, Value: nil},
+				},
+			},
+			expectedBody: ".1.3.6.1.4.1.99999.0.1",
+			expectedAttributes: map[string]interface{}{
+				attributeSNMPVersion: "v2c",
+				attributeSNMPPDUType: "trap",
+				attributePeerAddr:    "10.0.0.1",
+				attributePeerPort:    int64(5000),
+				attributeUptime:      int64(1234),
+				attributeTrapOID:     ".1.3.6.1.4.1.99999.0.1",
+				attributeVarbinds: []interface{}{
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.1.7", "type": gosnmp.Integer.String(), "value": int64(7)},
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.2.7", "type": gosnmp.OctetString.String(), "value": "eth0"},
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.3.7", "type": gosnmp.OctetString.String(), "value": []byte{0x00, 0x1a, 0xff}},
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.4.7", "type": gosnmp.Counter64.String(), "value": "18446744073709551615"},
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.5.7", "type": gosnmp.IPAddress.String(), "value": "192.168.0.1"},
+					map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.6.7", "type": gosnmp.Null.String(), "value": nil},
+				},
+			},
+		},
+		{
+			desc:         "v2c inform with MIBs",
+			mibDirectory: filepath.Join("testdata", "mibs"),
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: "public",
+				PDUType:   gosnmp.InformRequest,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(1234)},
+					{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.0.1"},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.2.7", Type: gosnmp.OctetString, Value: []byte("eth0")},
+				},
+			},
+			expectedBody: "OTEL-TEST-MIB::otelTestAlarm",
+			expectedAttributes: map[string]interface{}{
+				attributeSNMPVersion: "v2c",
+				attributeSNMPPDUType: "inform",
+				attributePeerAddr:    "10.0.0.1",
+				attributePeerPort:    int64(5000),
+				attributeUptime:      int64(1234),
+				attributeTrapOID:     ".1.3.6.1.4.1.99999.0.1",
+				attributeTrapName:    "OTEL-TEST-MIB::otelTestAlarm",
+				attributeVarbinds: []interface{}{
+					map[string]interface{}{
+						"oid":   ".1.3.6.1.4.1.99999.1.1.1.2.7",
+						"name":  "OTEL-TEST-MIB::otelTestName.7",
+						"type":  gosnmp.OctetString.String(),
+						"value": "eth0",
+					},
+				},
+			},
+		},
+		{
+			desc: "v1 generic trap",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version1,
+				Community: "public",
+				PDUType:   gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.99998",
+					AgentAddress: "10.0.0.2",
+					GenericTrap:  2,
+					Timestamp:    42,
+				},
+			},
+			expectedBody: ".1.3.6.1.6.3.1.1.5.3",
+			expectedAttributes: map[string]interface{}{
+				attributeSNMPVersion:  "v1",
+				attributeSNMPPDUType:  "trap",
+				attributePeerAddr:     "10.0.0.1",
+				attributePeerPort:     int64(5000),
+				attributeAgentAddress: "10.0.0.2",
+				attributeUptime:       int64(42),
+				attributeTrapOID:      ".1.3.6.1.6.3.1.1.5.3",
+				attributeVarbinds:     []interface{}{},
+			},
+		},
+		{
+			desc:         "v1 enterprise specific trap with MIBs",
+			mibDirectory: filepath.Join("testdata", "mibs"),
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version1,
+				Community: "public",
+				PDUType:   gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.99998",
+					AgentAddress: "10.0.0.2",
+					GenericTrap:  6,
+					SpecificTrap: 2,
+					Timestamp:    42,
+				},
+			},
+			expectedBody: "OTEL-TEST-V1-MIB::otelTestV1Trap",
+			expectedAttributes: map[string]interface{}{
+				attributeSNMPVersion:  "v1",
+				attributeSNMPPDUType:  "trap",
+				attributePeerAddr:     "10.0.0.1",
+				attributePeerPort:     int64(5000),
+				attributeAgentAddress: "10.0.0.2",
+				attributeUptime:       int64(42),
+				attributeTrapOID:      ".1.3.6.1.4.1.99998.0.2",
+				attributeTrapName:     "OTEL-TEST-V1-MIB::otelTestV1Trap",
+				attributeVarbinds:     []interface{}{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newTestTrapConfig()
+			r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+			if tc.mibDirectory != "" {
+				r.mibs, err = newMIBResolver(tc.mibDirectory)
+				require.NoError(t, err)
+			}
+
+			logs := r.trapToLogs(tc.packet, peer, now)
+			require.Equal(t, 1, logs.LogRecordCount())
+
+			record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			require.Equal(t, pcommon.NewTimestampFromTime(now), record.Timestamp())
+			require.Equal(t, pcommon.NewTimestampFromTime(now), record.ObservedTimestamp())
+			require.Equal(t, tc.expectedBody, record.Body().AsString())
+			require.Equal(t, tc.expectedAttributes, record.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestHandleTrap(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		community     string
+		version       gosnmp.SnmpVersion
+		expectedCount int
+	}{
+		{
+			desc:          "matching community",
+			community:     "public",
+			version:       gosnmp.Version2c,
+			expectedCount: 1,
+		},
+		{
+			desc:          "unknown community is dropped",
+			community:     "private",
+			version:       gosnmp.Version2c,
+			expectedCount: 0,
+		},
+		{
+			desc:          "v3 traps ignore the community",
+			community:     "",
+			version:       gosnmp.Version3,
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), newTestTrapConfig(), sink)
+			require.NoError(t, err)
+
+			r.handleTrap(&gosnmp.SnmpPacket{
+				Version:   tc.version,
+				Community: tc.community,
+				PDUType:   gosnmp.SNMPv2Trap,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+				},
+			}, &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000})
+
+			require.Equal(t, tc.expectedCount, sink.LogRecordCount())
+		})
+	}
+}
+
+func TestTrapReceiverStartShutdown(t *testing.T) {
+	cfg := newTestTrapConfig()
+	cfg.Traps.MIBDirectory = filepath.Join("testdata", "mibs")
+	r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NotNil(t, r.mibs)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestTrapReceiverReceivesTraps(t *testing.T) {
+	// Find a free port for the listener
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+
+	cfg := newTestTrapConfig()
+	cfg.Traps.Endpoint = fmt.Sprintf("udp://localhost:%d", port)
+	sink := new(consumertest.LogsSink)
+	r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	sender := &gosnmp.GoSNMP{
+		Target:    "localhost",
+		Port:      uint16(port),
+		Transport: "udp",
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   time.Second,
+	}
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+
+	_, err = sender.SendTrap(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+			{Name: ".1.3.6.1.4.1.99999.1.1.1.2.7", Type: gosnmp.OctetString, Value: "eth0"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, ".1.3.6.1.6.3.1.1.5.1", record.Body().AsString())
+	varbinds, ok := record.Attributes().Get(attributeVarbinds)
+	require.True(t, ok)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"oid": ".1.3.6.1.4.1.99999.1.1.1.2.7", "type": gosnmp.OctetString.String(), "value": "eth0"},
+	}, varbinds.Slice().AsRaw())
+}
+
+func TestTrapReceiverStartBadMIBDirectory(t *testing.T) {
+	cfg := newTestTrapConfig()
+	cfg.Traps.MIBDirectory = filepath.Join("testdata", "does-not-exist")
+	r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "failed to load MIBs")
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestNewTrapParams(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Version = "v3"
+	cfg.User = "u"
+	cfg.SecurityLevel = "auth_priv"
+	cfg.AuthType = "SHA"
+	cfg.AuthPassword = "p"
+	cfg.PrivacyType = "AES"
+	cfg.PrivacyPassword = "pp"
+	cfg.Traps = &TrapConfig{EngineID: "0x8000000001020304"}
+
+	params := newTrapParams(cfg)
+	require.Equal(t, gosnmp.Version3, params.Version)
+	require.Equal(t, gosnmp.UserSecurityModel, params.SecurityModel)
+	require.Equal(t, gosnmp.AuthPriv, params.MsgFlags)
+	require.Equal(t, &gosnmp.UsmSecurityParameters{
+		UserName:                 "u",
+		AuthoritativeEngineID:    string([]byte{0x80, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04}),
+		AuthenticationProtocol:   gosnmp.SHA,
+		AuthenticationPassphrase: "p",
+		PrivacyProtocol:          gosnmp.AES,
+		PrivacyPassphrase:        "pp",
+	}, params.SecurityParameters)
+}